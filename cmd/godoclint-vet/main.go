@@ -0,0 +1,89 @@
+// Godoc-Lint command package, compatible with "go vet -vettool".
+//
+// Usage:
+//
+//	go vet -vettool=$(which godoclint-vet) [-config=path] [-enable=rules] [-disable=rules] ./...
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/go/analysis/unitchecker"
+
+	"github.com/godoc-lint/godoc-lint/pkg/compose"
+	"github.com/godoc-lint/godoc-lint/pkg/config"
+	"github.com/godoc-lint/godoc-lint/pkg/model"
+)
+
+func main() {
+	composition := compose.Compose(compose.CompositionConfig{
+		BaseDir: resolveBaseDir(os.Args[1:]),
+	})
+
+	configOverride := model.NewConfigOverride()
+	composition.ConfigBuilder.SetOverride(configOverride)
+
+	// The flags are registered on the command-line flag set, rather than the
+	// analyzer's, so they are not prefixed with the analyzer name (e.g.,
+	// "-godoclint.config") and are the same as the godoclint command.
+	config.RegisterConfigFileFlag(flag.CommandLine, configOverride)
+	config.RegisterOverrideFlags(flag.CommandLine, configOverride, composition.Registry.GetCoveredRules())
+
+	unitchecker.Main(composition.Analyzer.GetAnalyzer())
+}
+
+// resolveBaseDir returns the base directory for the analysis of the unit
+// described by the config file (i.e., "*.cfg") among the given arguments.
+//
+// Since go vet runs the tool in the directory of each package, the working
+// directory cannot be used as the base directory. Instead, the root directory
+// of the module (i.e., the nearest directory containing a go.mod file) of the
+// analyzed package is used. If the package directory cannot be determined
+// (e.g., when go vet queries the supported flags), the working directory is
+// returned as a best effort.
+func resolveBaseDir(args []string) string {
+	wd, _ := os.Getwd()
+	if len(args) == 0 || !strings.HasSuffix(args[len(args)-1], ".cfg") {
+		return wd
+	}
+
+	pkgDir := readUnitDir(args[len(args)-1])
+	if pkgDir == "" {
+		return wd
+	}
+
+	for dir := pkgDir; ; dir = filepath.Dir(dir) {
+		if fi, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil && !fi.IsDir() {
+			return dir
+		}
+		if filepath.Dir(dir) == dir {
+			// Not in a module (e.g., GOPATH mode).
+			return pkgDir
+		}
+	}
+}
+
+// readUnitDir returns the directory of the package described by the given unit
+// config file, or an empty string if it cannot be determined.
+func readUnitDir(path string) string {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+
+	var cfg unitchecker.Config
+	if err := json.Unmarshal(raw, &cfg); err != nil {
+		return ""
+	}
+	if cfg.Dir != "" {
+		return cfg.Dir
+	}
+	if len(cfg.GoFiles) > 0 {
+		return filepath.Dir(cfg.GoFiles[0])
+	}
+	return ""
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/godoc-lint/godoc-lint/pkg/cache"
+)
+
+// runClean runs the clean subcommand, which removes the cached findings.
+func runClean(env *commandEnv, args []string) int {
+	fs := env.newFlagSet("clean", "[-flag]")
+	cacheDir := fs.String("cache-dir", "", "path to the cache directory (default: godoclint under the user cache directory)")
+	_ = fs.Parse(args)
+
+	if fs.NArg() > 0 {
+		fs.Usage()
+		return exitCodeError
+	}
+
+	c, err := openCache(*cacheDir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitCodeError
+	}
+	if err := c.Clean(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitCodeError
+	}
+	return exitCodeOK
+}
+
+// openCache opens the cache in the given directory, or the default one if the
+// directory is empty.
+func openCache(dir string) (*cache.Cache, error) {
+	if dir == "" {
+		var err error
+		if dir, err = cache.DefaultDir(); err != nil {
+			return nil, err
+		}
+	}
+	return cache.Open(dir)
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/godoc-lint/godoc-lint/pkg/compose"
+	"github.com/godoc-lint/godoc-lint/pkg/model"
+)
+
+// command represents a subcommand (e.g., "godoclint coverage ./...").
+//
+// Running the binary without a subcommand lints the given packages.
+type command struct {
+	// name is the subcommand name, as it appears on the command line.
+	name string
+
+	// summary is a one-line description of the subcommand.
+	summary string
+
+	// run runs the subcommand with the given arguments (i.e., excluding the
+	// subcommand name) and returns the process exit code.
+	run func(env *commandEnv, args []string) int
+}
+
+// commandEnv holds the components shared by the subcommands.
+type commandEnv struct {
+	// baseDir is the directory where the linter is invoked.
+	baseDir string
+
+	// composition holds the composed linter components. Its analyzer flags
+	// (e.g., -config or -include) are shared by all subcommands.
+	composition *compose.Composition
+
+	// override holds the configuration override, populated by the shared
+	// analyzer flags.
+	override *model.ConfigOverride
+}
+
+// getCommands returns the available subcommands.
+func getCommands() []command {
+	return []command{
+		{name: "coverage", summary: "print documentation coverage of packages", run: runCoverage},
+		{name: "serve", summary: "serve rendered package docs, annotated with issues", run: runServe},
+		{name: "render", summary: "print package docs as text, Markdown or HTML", run: runRender},
+		{name: "dump", summary: "export the inspected package docs and symbols as JSON", run: runDump},
+		{name: "lsp", summary: "run a language server over stdio, for editor integration", run: runLSP},
+		{name: "init", summary: "write a config file that enables the rules passing on packages", run: runInit},
+		{name: "migrate-config", summary: "write a config file from the godoc settings of revive or Golangci-lint", run: runMigrateConfig},
+		{name: "clean", summary: "remove the cached findings", run: runClean},
+	}
+}
+
+// findCommand returns the subcommand with the given name, or nil if there is
+// no such subcommand.
+func findCommand(name string) *command {
+	for _, c := range getCommands() {
+		if c.name == name {
+			return &c
+		}
+	}
+	return nil
+}
+
+// newFlagSet returns a new flag set for the given subcommand, populated with
+// the shared analyzer flags. The usage is the synopsis of the subcommand
+// arguments (e.g., "[-flag] [package]").
+func (env *commandEnv) newFlagSet(name, usage string) *flag.FlagSet {
+	analyzer := env.composition.Analyzer.GetAnalyzer()
+	c := findCommand(name)
+
+	fs := flag.NewFlagSet(analyzer.Name+" "+c.name, flag.ExitOnError)
+	analyzer.Flags.VisitAll(func(f *flag.Flag) {
+		fs.Var(f.Value, f.Name, f.Usage)
+	})
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "%s %s: %s\n\n", analyzer.Name, c.name, c.summary)
+		fmt.Fprintf(fs.Output(), "Usage: %s %s %s\n\n", analyzer.Name, c.name, usage)
+		fmt.Fprintln(fs.Output(), "Flags:")
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// createOutput returns a writer to the given output file path, or the given
+// default writer if the path is empty. The returned function should be called
+// to close the output file.
+func createOutput(path string, def io.Writer) (io.Writer, func() error, error) {
+	if path == "" {
+		return def, func() error { return nil }, nil
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot create output file: %w", err)
+	}
+	return f, f.Close, nil
+}
+
+// defaultConfigOut is the default path of the config files written by the
+// subcommands (e.g., init), relative to the base directory.
+const defaultConfigOut = ".godoc-lint.yaml"
+
+// checkConfigOut returns an error if the given config file path exists, unless
+// it should be overwritten. The path "-" represents the standard output.
+func checkConfigOut(path string, force bool) error {
+	if path == "-" || force {
+		return nil
+	}
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("config file %q already exists; use -force to overwrite it", path)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
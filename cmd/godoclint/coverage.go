@@ -0,0 +1,77 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/godoc-lint/godoc-lint/pkg/coverage"
+	"github.com/godoc-lint/godoc-lint/pkg/model"
+)
+
+// runCoverage runs the coverage subcommand, which prints the documentation
+// coverage of the given packages and fails if the total coverage is below the
+// configured minimum (i.e., the "coverage/min" option).
+func runCoverage(env *commandEnv, args []string) int {
+	fs := env.newFlagSet("coverage", "[-flag] [package]")
+	format := fs.String("format", "text", `output format, one of "text" or "json"`)
+	out := fs.String("out", "", "path to the output file (default: standard output)")
+	_ = fs.Parse(args)
+
+	if *format != "text" && *format != "json" {
+		fmt.Fprintf(os.Stderr, "unknown format %q, must be one of %q\n", *format, []string{"text", "json"})
+		return exitCodeError
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return exitCodeError
+	}
+
+	cfg, err := env.composition.ConfigBuilder.GetConfig(env.baseDir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return getErrorExitCode(err)
+	}
+	minimum := cfg.GetCoverageMin()
+
+	pkgs, _, err := inspectPackages(env, fs.Args(), false, false)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return getErrorExitCode(err)
+	}
+
+	r := &coverage.Report{}
+	for _, p := range pkgs {
+		var files []*model.FileInspection
+		for _, fi := range p.files() {
+			files = append(files, fi)
+		}
+		r.Add(p.pkg.PkgPath, files)
+	}
+
+	w, closeOutput, err := createOutput(*out, os.Stdout)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitCodeError
+	}
+	if *format == "json" {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "\t")
+		err = enc.Encode(r)
+	} else {
+		err = r.WriteText(w)
+	}
+	if cerr := closeOutput(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "cannot write coverage report: %v\n", err)
+		return exitCodeError
+	}
+
+	if total := r.Total.All.Percent(); total < minimum {
+		fmt.Fprintf(os.Stderr, "documentation coverage %.1f%% is below the minimum %.1f%%\n", total, minimum)
+		return exitCodeIssues
+	}
+	return exitCodeOK
+}
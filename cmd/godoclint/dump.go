@@ -0,0 +1,52 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/godoc-lint/godoc-lint/pkg/dump"
+)
+
+// runDump runs the dump subcommand, which writes the inspector model of the
+// given packages as a versioned JSON document.
+func runDump(env *commandEnv, args []string) int {
+	fs := env.newFlagSet("dump", "[-flag] [package]")
+	out := fs.String("out", "", "path to the output file (default: standard output)")
+	tests := fs.Bool("test", false, "indicates whether test files should be dumped, too")
+	_ = fs.Parse(args)
+
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return exitCodeError
+	}
+
+	pkgs, _, err := inspectPackages(env, fs.Args(), *tests, false)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return getErrorExitCode(err)
+	}
+
+	doc := dump.New(env.baseDir)
+	for _, p := range pkgs {
+		doc.AddPackage(p.pkg.Fset, p.pkg.PkgPath, p.pkg.Name, p.pkg.Syntax, p.result)
+	}
+
+	w, closeOutput, err := createOutput(*out, os.Stdout)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitCodeError
+	}
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "\t")
+	err = enc.Encode(doc)
+	if cerr := closeOutput(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "cannot write dump: %v\n", err)
+		return exitCodeError
+	}
+	return exitCodeOK
+}
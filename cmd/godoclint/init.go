@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/godoc-lint/godoc-lint/pkg/bootstrap"
+	"github.com/godoc-lint/godoc-lint/pkg/lint"
+	"github.com/godoc-lint/godoc-lint/pkg/model"
+)
+
+// runInit runs the init subcommand, which runs all rules on the given packages
+// and writes a config file that enables the rules without any finding.
+func runInit(env *commandEnv, args []string) int {
+	opts := bootstrap.DefaultOptions()
+
+	fs := env.newFlagSet("init", "[-flag] [package]")
+	out := fs.String("out", defaultConfigOut, `path to the config file to write, or "-" for standard output`)
+	force := fs.Bool("force", false, "overwrite the config file, if it exists")
+	tests := fs.Bool("test", true, "indicates whether test files should be analyzed, too")
+	fs.IntVar(&opts.MaxFindings, "max-findings", opts.MaxFindings, "maximum number of findings for a rule to be suggested as a candidate")
+	fs.BoolVar(&opts.ExcludeHotspots, "exclude-hotspots", false, "exclude the directories with the findings of candidates, to enable them right away")
+	fs.IntVar(&opts.MaxHotspots, "max-hotspots", opts.MaxHotspots, "maximum number of directories to exclude, with -exclude-hotspots")
+	_ = fs.Parse(args)
+
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return exitCodeError
+	}
+
+	if err := checkConfigOut(*out, *force); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitCodeError
+	}
+
+	// All rules are applied, regardless of the configuration, while the other
+	// settings (e.g., the path patterns, or rule options) still apply.
+	all := model.DefaultSetAll
+	env.override.Default = &all
+	env.override.Enable = &model.RuleSet{}
+	env.override.Disable = &model.RuleSet{}
+
+	issues, _, err := lint.AnalyzePackages(context.Background(), env.composition, env.baseDir, fs.Args(), *tests, nil)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return getErrorExitCode(err)
+	}
+
+	s := bootstrap.Suggest(env.baseDir, env.composition.Registry.GetCoveredRules(), issues, opts)
+
+	path := *out
+	if path == "-" {
+		path = ""
+	}
+	w, closeOutput, err := createOutput(path, os.Stdout)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitCodeError
+	}
+	err = s.WriteYAML(w)
+	if cerr := closeOutput(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "cannot write config file: %v\n", err)
+		return exitCodeError
+	}
+
+	if path != "" {
+		if abs, err := filepath.Abs(path); err == nil {
+			path = abs
+		}
+		fmt.Fprintf(os.Stderr, "wrote config file %q: %d rule(s) enabled, %d candidate(s), %d other rule(s)\n",
+			path, len(s.Passing)+len(s.Excluded), len(s.Candidates), len(s.Failing))
+	}
+	return exitCodeOK
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"go/ast"
+	"iter"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+
+	"github.com/godoc-lint/godoc-lint/pkg/lint"
+	"github.com/godoc-lint/godoc-lint/pkg/model"
+)
+
+// inspectedPackage holds a loaded package, together with its inspection
+// result.
+type inspectedPackage struct {
+	pkg    *packages.Package
+	result *model.InspectorResult
+}
+
+// files returns the inspected files of the package, in the order they appear
+// in the package syntax. Files that are not applicable (e.g., excluded by the
+// configuration) are skipped.
+func (p *inspectedPackage) files() iter.Seq2[*ast.File, *model.FileInspection] {
+	return func(yield func(*ast.File, *model.FileInspection) bool) {
+		for _, f := range p.pkg.Syntax {
+			fi, ok := p.result.Files[f]
+			if !ok || fi == nil {
+				continue
+			}
+			if !yield(f, fi) {
+				return
+			}
+		}
+	}
+}
+
+// inspectPackages loads the packages matching the given patterns and runs the
+// inspector on them. The result is sorted by package path.
+//
+// If withIssues is true, the packages are also analyzed by the linter, and the
+// issues are returned.
+//
+// When test files are included, only the test variant of a package (i.e., the
+// one with more files) is returned, and synthesized test main packages (i.e.,
+// "foo.test") are skipped.
+func inspectPackages(env *commandEnv, patterns []string, tests, withIssues bool) ([]inspectedPackage, []model.Issue, error) {
+	pkgs, err := lint.LoadPackages(context.Background(), env.baseDir, patterns, tests)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	inspector := env.composition.Inspector.GetAnalyzer()
+	analyzers := []*analysis.Analyzer{inspector}
+	if withIssues {
+		analyzers = append(analyzers, env.composition.Analyzer.GetAnalyzer())
+	}
+	graph, err := checker.Analyze(analyzers, pkgs, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var issues []model.Issue
+	if withIssues {
+		if issues, err = lint.CollectIssues(graph); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if err := lint.CollectErrors(graph); err != nil {
+		return nil, nil, err
+	}
+
+	byPath := make(map[string]inspectedPackage, len(graph.Roots))
+	for _, act := range graph.Roots {
+		if strings.HasSuffix(act.Package.ID, ".test") {
+			continue
+		}
+		if act.Analyzer != inspector {
+			continue
+		}
+		result, ok := act.Result.(*model.InspectorResult)
+		if !ok || result == nil {
+			continue
+		}
+		if prev, ok := byPath[act.Package.PkgPath]; ok && len(prev.pkg.Syntax) >= len(act.Package.Syntax) {
+			continue
+		}
+		byPath[act.Package.PkgPath] = inspectedPackage{pkg: act.Package, result: result}
+	}
+	result := make([]inspectedPackage, 0, len(byPath))
+	for _, p := range byPath {
+		result = append(result, p)
+	}
+	slices.SortFunc(result, func(a, b inspectedPackage) int {
+		return cmp.Compare(a.pkg.PkgPath, b.pkg.PkgPath)
+	})
+	return result, issues, nil
+}
@@ -0,0 +1,226 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/godoc-lint/godoc-lint/pkg/baseline"
+	"github.com/godoc-lint/godoc-lint/pkg/cache"
+	"github.com/godoc-lint/godoc-lint/pkg/compose"
+	"github.com/godoc-lint/godoc-lint/pkg/diff"
+	"github.com/godoc-lint/godoc-lint/pkg/lint"
+	"github.com/godoc-lint/godoc-lint/pkg/model"
+	"github.com/godoc-lint/godoc-lint/pkg/report"
+)
+
+// Exit codes, compatible with the go/analysis drivers (e.g., singlechecker).
+const (
+	exitCodeOK     = 0
+	exitCodeError  = 1
+	exitCodeConfig = 2
+	exitCodeIssues = 3
+)
+
+// getErrorExitCode returns the exit code for the given error, where
+// configuration errors have a dedicated exit code.
+func getErrorExitCode(err error) int {
+	var cerr *model.ConfigError
+	if errors.As(err, &cerr) {
+		return exitCodeConfig
+	}
+	return exitCodeError
+}
+
+// lintOptions holds the options of the lint command.
+type lintOptions struct {
+	// tests indicates whether test packages should be analyzed.
+	tests bool
+
+	// format is the output format.
+	format report.Format
+
+	// out is the path to the output file. If empty, the output goes to the
+	// standard output (or standard error, for the text format).
+	out string
+
+	// baseline is the path to the baseline file, whose recorded issues should
+	// be suppressed.
+	baseline string
+
+	// writeBaseline is the path to the baseline file to record the issues in.
+	writeBaseline string
+
+	// newFromRev is the Git revision, where only issues on lines changed since
+	// then should be reported.
+	newFromRev string
+
+	// newFromPatch is the path to the patch file, where only issues on lines
+	// changed by the patch should be reported.
+	newFromPatch string
+
+	// showRule indicates whether issue messages should be prefixed with the
+	// rule name (text format only).
+	showRule bool
+
+	// files holds the individual files to lint, instead of packages.
+	files []lint.File
+
+	// cacheDir is the path to the cache directory. If empty, the default
+	// directory is used.
+	cacheDir string
+
+	// noCache indicates whether the cache should be bypassed.
+	noCache bool
+}
+
+// runLint loads the packages matching the given patterns (or the given files),
+// analyzes them, and writes the issues in the requested format. It returns the
+// process exit code.
+func runLint(baseDir string, composition *compose.Composition, patterns []string, opts lintOptions) int {
+	var bl *baseline.Baseline
+	if opts.baseline != "" {
+		b, err := baseline.FromFile(opts.baseline)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return exitCodeError
+		}
+		bl = b
+	}
+
+	var changes *diff.Changes
+	if opts.newFromRev != "" || opts.newFromPatch != "" {
+		var err error
+		if opts.newFromRev != "" {
+			changes, err = diff.FromGitRev(baseDir, opts.newFromRev)
+		} else {
+			changes, err = diff.ParseFile(opts.newFromPatch, baseDir)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return exitCodeError
+		}
+	}
+
+	issues, pkgPaths, err := collectIssues(baseDir, composition, patterns, opts)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return getErrorExitCode(err)
+	}
+
+	if opts.writeBaseline != "" {
+		if err := baseline.New(issues).WriteFile(opts.writeBaseline); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return exitCodeError
+		}
+		fmt.Fprintf(os.Stderr, "recorded %d issue(s) in baseline file %q\n", len(issues), opts.writeBaseline)
+		return exitCodeOK
+	}
+
+	if bl != nil {
+		var stale []baseline.Entry
+		issues, stale = bl.Filter(issues)
+		for _, e := range stale {
+			fmt.Fprintf(os.Stderr, "stale baseline entry (no longer matches any issue): %s\n", e)
+		}
+		if len(stale) > 0 {
+			fmt.Fprintf(os.Stderr, "baseline file %q has %d stale entries; consider re-writing it via -write-baseline\n", opts.baseline, len(stale))
+		}
+	}
+
+	if changes != nil {
+		issues = slices.DeleteFunc(issues, func(issue model.Issue) bool {
+			return !changes.Intersects(issue.Pos.Filename, issue.Pos.Line, issue.End.Line)
+		})
+	}
+
+	reporter, err := report.NewReporter(opts.format, report.Options{
+		BaseDir:  baseDir,
+		Rules:    composition.Registry.ListRuleMetadata(),
+		Packages: pkgPaths,
+		ShowRule: opts.showRule,
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitCodeError
+	}
+
+	var def io.Writer = os.Stdout
+	if opts.format == report.FormatText {
+		// To be consistent with the go/analysis drivers.
+		def = os.Stderr
+	}
+	w, closeOutput, err := createOutput(opts.out, def)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitCodeError
+	}
+
+	if err := reporter.Report(w, issues); err != nil {
+		closeOutput()
+		fmt.Fprintf(os.Stderr, "cannot write report: %v\n", err)
+		return exitCodeError
+	}
+	if err := closeOutput(); err != nil {
+		fmt.Fprintf(os.Stderr, "cannot write report: %v\n", err)
+		return exitCodeError
+	}
+
+	if len(issues) > 0 && failsOnIssues(opts.format) {
+		return exitCodeIssues
+	}
+	return exitCodeOK
+}
+
+// collectIssues lints the packages matching the given patterns, or the given
+// files (if any), and returns the issues, along with the sorted paths of the
+// linted packages. In the latter case, only the packages with issues are
+// known.
+func collectIssues(baseDir string, composition *compose.Composition, patterns []string, opts lintOptions) ([]model.Issue, []string, error) {
+	ctx := context.Background()
+
+	if len(opts.files) > 0 {
+		issues, err := lint.AnalyzeFiles(ctx, composition, baseDir, opts.files)
+		if err != nil {
+			return nil, nil, err
+		}
+		paths := make([]string, 0, len(issues))
+		for _, issue := range issues {
+			paths = append(paths, issue.PkgPath)
+		}
+		slices.Sort(paths)
+		return issues, slices.Compact(paths), nil
+	}
+
+	var c *cache.Cache
+	if !opts.noCache {
+		var err error
+		if c, err = openCache(opts.cacheDir); err != nil {
+			if opts.cacheDir != "" {
+				return nil, nil, err
+			}
+			// The default cache is a mere optimization, so it should not get
+			// in the way (e.g., with no home directory on CI).
+			fmt.Fprintf(os.Stderr, "cache disabled: %v\n", err)
+		}
+	}
+	return lint.AnalyzePackages(ctx, composition, baseDir, patterns, opts.tests, c)
+}
+
+// isGoFile determines whether the given command-line argument is a Go file,
+// rather than a package pattern.
+func isGoFile(arg string) bool {
+	return strings.HasSuffix(arg, ".go")
+}
+
+// failsOnIssues determines whether the process should exit with a non-zero
+// code when there are issues. Formats that are meant to be consumed by other
+// tools (e.g., JSON or SARIF) do not fail, to be consistent with the -json
+// flag of the go/analysis drivers.
+func failsOnIssues(format report.Format) bool {
+	return format == report.FormatText || format == report.FormatGitHub
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/godoc-lint/godoc-lint/pkg/lsp"
+)
+
+// runLSP runs the lsp subcommand, which starts a language server speaking LSP
+// over the standard input and output.
+func runLSP(env *commandEnv, args []string) int {
+	fs := env.newFlagSet("lsp", "[-flag]")
+	tests := fs.Bool("test", true, "indicates whether test files should be analyzed, too")
+	_ = fs.Parse(args)
+
+	server := lsp.NewServer(lsp.Options{
+		Tests:    *tests,
+		Override: env.override,
+	})
+	if err := server.Serve(context.Background(), os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitCodeError
+	}
+	return exitCodeOK
+}
@@ -3,16 +3,20 @@ package main
 
 import (
 	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"os"
-	"regexp"
 	"slices"
 	"strings"
 
 	"golang.org/x/tools/go/analysis/singlechecker"
 
 	"github.com/godoc-lint/godoc-lint/pkg/compose"
+	"github.com/godoc-lint/godoc-lint/pkg/config"
+	"github.com/godoc-lint/godoc-lint/pkg/lint"
 	"github.com/godoc-lint/godoc-lint/pkg/model"
+	"github.com/godoc-lint/godoc-lint/pkg/report"
 	"github.com/godoc-lint/godoc-lint/pkg/version"
 )
 
@@ -28,116 +32,192 @@ func main() {
 		os.Exit(1)
 	}
 
-	composition := compose.Compose(compose.CompositionConfig{
-		BaseDir:  baseDir,
-		ExitFunc: exitFunc,
-	})
+	var cmd *command
+	if len(os.Args) > 1 {
+		cmd = findCommand(os.Args[1])
+	}
+	useAnalysisDriver := cmd == nil && usesAnalysisDriverFlags(os.Args[1:])
+
+	compositionConfig := compose.CompositionConfig{
+		BaseDir: baseDir,
+	}
+	if useAnalysisDriver {
+		// The standard go/analysis driver does not tell the config errors apart
+		// from other errors, so they are reported via the exit function.
+		compositionConfig.ExitFunc = exitFunc
+	}
+	composition := compose.Compose(compositionConfig)
 
 	configOverride := model.NewConfigOverride()
 	composition.ConfigBuilder.SetOverride(configOverride)
 
-	composition.Analyzer.GetAnalyzer().Flags.Func("config", "path to config file", func(s string) error {
-		if configOverride.ConfigFilePath != nil {
-			return errors.New("config file is set multiple times")
-		}
-		if strings.TrimSpace(s) == "" {
-			return errors.New("empty path")
-		}
-		configOverride.ConfigFilePath = &s
+	config.RegisterConfigFileFlag(&composition.Analyzer.GetAnalyzer().Flags, configOverride)
+	config.RegisterOverrideFlags(&composition.Analyzer.GetAnalyzer().Flags, configOverride, composition.Registry.GetCoveredRules())
+
+	composition.Analyzer.GetAnalyzer().Flags.BoolFunc("V", "print version and exit", func(s string) error {
+		fmt.Println(version.Current)
+		os.Exit(0)
 		return nil
 	})
 
-	composition.Analyzer.GetAnalyzer().Flags.Func("default", "default set of rules to enable", func(s string) error {
-		if configOverride.Default != nil {
-			return errors.New("default set is set multiple times")
+	analyzer := composition.Analyzer.GetAnalyzer()
+
+	if cmd != nil {
+		env := &commandEnv{
+			baseDir:     baseDir,
+			composition: composition,
+			override:    configOverride,
 		}
-		if !slices.Contains(model.DefaultSetValues, model.DefaultSet(s)) {
-			return fmt.Errorf("unknown default set %q, must be one of %q", s, model.DefaultSetValues)
+		os.Exit(cmd.run(env, os.Args[2:]))
+	}
+
+	if useAnalysisDriver {
+		// The flags of the standard go/analysis driver (e.g., -fix or -diff)
+		// are handled by the driver itself, where the output formats of the
+		// built-in driver are not available.
+		singlechecker.Main(analyzer)
+		return
+	}
+
+	analyzer.Flags.VisitAll(func(f *flag.Flag) {
+		flag.Var(f.Value, f.Name, f.Usage)
+	})
+
+	opts := lintOptions{
+		format: report.FormatText,
+	}
+
+	var formatSet bool
+	flag.Func("format", fmt.Sprintf("output format, one of %q (default %q)", report.FormatValues, report.FormatText), func(s string) error {
+		if formatSet {
+			return errors.New("format is set multiple times")
+		}
+		if !slices.Contains(report.FormatValues, report.Format(s)) {
+			return fmt.Errorf("unknown format %q, must be one of %q", s, report.FormatValues)
 		}
-		v := model.DefaultSet(s)
-		configOverride.Default = &v
+		formatSet = true
+		opts.format = report.Format(s)
 		return nil
 	})
 
-	walkNonEmptyCSV := func(f func(string) error) func(string) error {
-		return func(value string) error {
-			for v := range strings.SplitSeq(strings.TrimSpace(value), ",") {
-				if strings.TrimSpace(v) == "" {
-					return errors.New("empty element")
-				}
-				if err := f(v); err != nil {
-					return err
-				}
-			}
-			return nil
+	asJSON := flag.Bool("json", false, "emit JSON output (same as -format=json)")
+	flag.StringVar(&opts.out, "out", "", "path to the output file (default: standard output, or standard error for text format)")
+	flag.BoolVar(&opts.tests, "test", true, "indicates whether test files should be analyzed, too")
+	flag.StringVar(&opts.baseline, "baseline", "", "path to the baseline file, whose recorded issues are suppressed")
+	flag.StringVar(&opts.writeBaseline, "write-baseline", "", "path to the baseline file to record the current issues in")
+	flag.StringVar(&opts.newFromRev, "new-from-rev", "", "report only issues on lines changed since the given Git revision")
+	flag.StringVar(&opts.newFromPatch, "new-from-patch", "", "report only issues on lines changed by the given patch (unified diff) file")
+	flag.BoolVar(&opts.showRule, "show-rule", false, "prefix issue messages with the rule name (e.g., \"[max-len] ...\"), for the text format")
+	flag.StringVar(&opts.cacheDir, "cache-dir", "", "path to the cache directory of findings (default: godoclint under the user cache directory)")
+	flag.BoolVar(&opts.noCache, "no-cache", false, "analyze all packages, rather than reusing the cached findings of unchanged ones")
+	stdinFilename := flag.String("stdin-filename", "", "lint the standard input as the content of the given Go file, rather than packages")
+	listRules := flag.Bool("list-rules", false, "print the supported rules and exit")
+	explain := flag.String("explain", "", "print the full description of the given rule and exit")
+
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "%s: %s\n\n", analyzer.Name, analyzer.Doc)
+		fmt.Fprintf(os.Stderr, "Usage: %s [-flag] [package]\n", analyzer.Name)
+		fmt.Fprintf(os.Stderr, "       %s [-flag] [file.go...]\n", analyzer.Name)
+		fmt.Fprintf(os.Stderr, "       %s [-flag] -stdin-filename <file.go> < <content>\n", analyzer.Name)
+		fmt.Fprintf(os.Stderr, "       %s <command> [-flag] [args]\n\n", analyzer.Name)
+		fmt.Fprintln(os.Stderr, "Commands:")
+		for _, c := range getCommands() {
+			fmt.Fprintf(os.Stderr, "  %-14s %s\n", c.name, c.summary)
 		}
+		fmt.Fprintf(os.Stderr, "\nRun '%s <command> -help' for more information on a command.\n\n", analyzer.Name)
+		fmt.Fprintln(os.Stderr, "Flags:")
+		flag.PrintDefaults()
+		fmt.Fprintf(os.Stderr, "\nThe following flags of the standard go/analysis driver are also supported, in\nwhich case the packages are analyzed by that driver, with its own output:\n  -%s\n", strings.Join(analysisDriverFlags, ", -"))
 	}
 
-	walkNonEmpty := func(f func(string) error) func(string) error {
-		return func(value string) error {
-			if strings.TrimSpace(value) == "" {
-				return errors.New("empty value")
-			}
-			if err := f(value); err != nil {
-				return err
-			}
-			return nil
+	flag.Parse()
+
+	if *listRules {
+		if err := writeRuleList(os.Stdout, composition.Registry.ListRuleMetadata()); err != nil {
+			exitFunc(1, err)
 		}
+		os.Exit(0)
 	}
 
-	composition.Analyzer.GetAnalyzer().Flags.Func("include", "regexp path (Unix style) to include (can be used multiple times)", walkNonEmpty(func(s string) error {
-		re, err := regexp.Compile(s)
-		if err != nil {
-			return fmt.Errorf("invalid inclusion regexp pattern %q: %w", s, err)
+	if *explain != "" {
+		m, ok := composition.Registry.GetRuleMetadata(model.Rule(*explain))
+		if !ok {
+			exitFunc(1, fmt.Errorf("unknown rule name to explain %q", *explain))
 		}
-		configOverride.Include = append(configOverride.Include, re)
-		return nil
-	}))
+		if err := writeRuleExplanation(os.Stdout, m); err != nil {
+			exitFunc(1, err)
+		}
+		os.Exit(0)
+	}
 
-	composition.Analyzer.GetAnalyzer().Flags.Func("exclude", "regexp path (Unix style) to exclude (can be used multiple times)", walkNonEmpty(func(s string) error {
-		re, err := regexp.Compile(s)
-		if err != nil {
-			return fmt.Errorf("invalid exclusion regexp pattern %q: %w", s, err)
+	if *asJSON {
+		if formatSet && opts.format != report.FormatJSON {
+			exitFunc(1, errors.New("cannot use -json with a different -format"))
 		}
-		configOverride.Exclude = append(configOverride.Exclude, re)
-		return nil
-	}))
+		opts.format = report.FormatJSON
+	}
+
+	if opts.baseline != "" && opts.writeBaseline != "" {
+		exitFunc(1, errors.New("cannot use -baseline and -write-baseline at the same time"))
+	}
+
+	if opts.noCache && opts.cacheDir != "" {
+		exitFunc(1, errors.New("cannot use -no-cache and -cache-dir at the same time"))
+	}
+
+	if opts.newFromRev != "" && opts.newFromPatch != "" {
+		exitFunc(1, errors.New("cannot use -new-from-rev and -new-from-patch at the same time"))
+	}
+
+	patterns := flag.Args()
 
-	composition.Analyzer.GetAnalyzer().Flags.Func("enable", "comma-separated rule names to enable", walkNonEmptyCSV(func(s string) error {
-		if !model.AllRules.Has(model.Rule(s)) {
-			return fmt.Errorf("unknown rule name to enable %q", s)
+	if *stdinFilename != "" {
+		if len(patterns) > 0 {
+			exitFunc(1, errors.New("cannot use -stdin-filename with package patterns or files"))
 		}
-		if configOverride.Disable != nil && configOverride.Disable.Has(model.Rule(s)) {
-			return fmt.Errorf("cannot enable and disable rule at the same time %q", s)
+		content, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			exitFunc(1, fmt.Errorf("cannot read standard input: %w", err))
 		}
-		if configOverride.Enable == nil {
-			configOverride.Enable = &model.RuleSet{}
+		opts.files = []lint.File{{Path: *stdinFilename, Content: content}}
+	} else if slices.ContainsFunc(patterns, isGoFile) {
+		// Just like the go command, arguments are either package patterns or
+		// files, but not both.
+		if slices.ContainsFunc(patterns, func(arg string) bool { return !isGoFile(arg) }) {
+			exitFunc(1, errors.New("cannot mix package patterns and files"))
 		}
-		updated := configOverride.Enable.Add(model.Rule(s))
-		configOverride.Enable = &updated
-		return nil
-	}))
+		for _, p := range patterns {
+			opts.files = append(opts.files, lint.File{Path: p})
+		}
+	} else if len(patterns) == 0 {
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	os.Exit(runLint(baseDir, composition, patterns, opts))
+}
 
-	composition.Analyzer.GetAnalyzer().Flags.Func("disable", "comma-separated rule names to disable", walkNonEmptyCSV(func(s string) error {
-		if !model.AllRules.Has(model.Rule(s)) {
-			return fmt.Errorf("unknown rule name to disable %q", s)
+// analysisDriverFlags holds the names of the flags that are only supported by
+// the standard go/analysis driver (i.e., singlechecker).
+var analysisDriverFlags = []string{"fix", "diff", "c", "debug", "cpuprofile", "memprofile", "trace", "flags"}
+
+// usesAnalysisDriverFlags determines whether the given command-line arguments
+// include any of the flags that are only supported by the standard go/analysis
+// driver.
+func usesAnalysisDriverFlags(args []string) bool {
+	for _, arg := range args {
+		if arg == "--" {
+			break
 		}
-		if configOverride.Enable != nil && configOverride.Enable.Has(model.Rule(s)) {
-			return fmt.Errorf("cannot enable and disable rule at the same time %q", s)
+		name, ok := strings.CutPrefix(arg, "-")
+		if !ok {
+			continue
 		}
-		if configOverride.Disable == nil {
-			configOverride.Disable = &model.RuleSet{}
+		name = strings.TrimPrefix(name, "-")
+		name, _, _ = strings.Cut(name, "=")
+		if slices.Contains(analysisDriverFlags, name) {
+			return true
 		}
-		updated := configOverride.Disable.Add(model.Rule(s))
-		configOverride.Disable = &updated
-		return nil
-	}))
-
-	composition.Analyzer.GetAnalyzer().Flags.BoolFunc("V", "print version and exit", func(s string) error {
-		fmt.Println(version.Current)
-		os.Exit(0)
-		return nil
-	})
-
-	singlechecker.Main(composition.Analyzer.GetAnalyzer())
+	}
+	return false
 }
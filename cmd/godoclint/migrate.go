@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/godoc-lint/godoc-lint/pkg/migrate"
+)
+
+// runMigrateConfig runs the migrate-config subcommand, which translates the
+// godoc settings of revive, golint, stylecheck and godot, from the given (or
+// detected) config file, into a config file.
+func runMigrateConfig(env *commandEnv, args []string) int {
+	fs := env.newFlagSet("migrate-config", "[-flag] [revive or Golangci-lint config file]")
+	out := fs.String("out", defaultConfigOut, `path to the config file to write, or "-" for standard output`)
+	force := fs.Bool("force", false, "overwrite the config file, if it exists")
+	_ = fs.Parse(args)
+
+	var from string
+	switch fs.NArg() {
+	case 0:
+		for _, name := range migrate.DefaultFiles() {
+			path := filepath.Join(env.baseDir, name)
+			if _, err := os.Stat(path); err == nil {
+				from = path
+				break
+			}
+		}
+		if from == "" {
+			fmt.Fprintf(os.Stderr, "no config file to migrate from; looked up %q\n", migrate.DefaultFiles())
+			return exitCodeError
+		}
+	case 1:
+		from = fs.Arg(0)
+	default:
+		fs.Usage()
+		return exitCodeError
+	}
+
+	if err := checkConfigOut(*out, *force); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitCodeError
+	}
+
+	r, err := migrate.FromFile(from)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return getErrorExitCode(err)
+	}
+
+	if rel, err := filepath.Rel(env.baseDir, from); err == nil {
+		from = rel
+	}
+
+	path := *out
+	if path == "-" {
+		path = ""
+	}
+	w, closeOutput, err := createOutput(path, os.Stdout)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitCodeError
+	}
+	err = r.WriteYAML(w, from)
+	if cerr := closeOutput(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "cannot write config file: %v\n", err)
+		return exitCodeError
+	}
+
+	for _, n := range r.Notes {
+		fmt.Fprintf(os.Stderr, "note: %s\n", n)
+	}
+	if path != "" {
+		if abs, err := filepath.Abs(path); err == nil {
+			path = abs
+		}
+		fmt.Fprintf(os.Stderr, "wrote config file %q: %d rule(s) enabled, %d note(s)\n", path, len(r.Config.Enable), len(r.Notes))
+	}
+	return exitCodeOK
+}
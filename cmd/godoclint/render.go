@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	"github.com/godoc-lint/godoc-lint/pkg/render"
+)
+
+// renderFormats holds the supported output formats of the render subcommand.
+var renderFormats = []string{"text", "markdown", "html"}
+
+// runRender runs the render subcommand, which prints the godocs of the given
+// packages, as parsed by the inspector, in declaration order.
+func runRender(env *commandEnv, args []string) int {
+	fs := env.newFlagSet("render", "[-flag] [package]")
+	format := fs.String("format", "text", fmt.Sprintf("output format, one of %q", renderFormats))
+	out := fs.String("out", "", "path to the output file (default: standard output)")
+	tests := fs.Bool("test", false, "indicates whether test files should be rendered, too")
+	_ = fs.Parse(args)
+
+	if !slices.Contains(renderFormats, *format) {
+		fmt.Fprintf(os.Stderr, "unknown format %q, must be one of %q\n", *format, renderFormats)
+		return exitCodeError
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return exitCodeError
+	}
+
+	pkgs, _, err := inspectPackages(env, fs.Args(), *tests, false)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return getErrorExitCode(err)
+	}
+	if *format == "html" && len(pkgs) != 1 {
+		fmt.Fprintf(os.Stderr, "html format requires exactly one package, got %d\n", len(pkgs))
+		return exitCodeError
+	}
+
+	w, closeOutput, err := createOutput(*out, os.Stdout)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitCodeError
+	}
+
+	for i, p := range pkgs {
+		var files []render.File
+		for f, fi := range p.files() {
+			files = append(files, render.File{AST: f, Inspection: fi})
+		}
+		rp := render.NewPackage(p.pkg.Fset, p.pkg.PkgPath, p.pkg.Name, files)
+
+		if i > 0 {
+			io.WriteString(w, "\n")
+		}
+		switch *format {
+		case "markdown":
+			err = render.WriteMarkdown(w, rp)
+		case "html":
+			err = render.WriteHTML(w, rp, render.HTMLOptions{})
+		default:
+			err = render.WriteText(w, rp)
+		}
+		if err != nil {
+			break
+		}
+	}
+	if cerr := closeOutput(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "cannot render docs: %v\n", err)
+		return exitCodeError
+	}
+	return exitCodeOK
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/godoc-lint/godoc-lint/pkg/model"
+)
+
+// writeRuleList writes a table of the given rules.
+func writeRuleList(w io.Writer, metadata []model.RuleMetadata) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "RULE\tCATEGORY\tPRESETS\tSUMMARY")
+	for _, m := range metadata {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", m.Rule, m.Category, joinPresets(m.Presets()), m.Summary)
+	}
+	return tw.Flush()
+}
+
+// writeRuleExplanation writes the full description of the given rule,
+// including examples and options.
+func writeRuleExplanation(w io.Writer, m model.RuleMetadata) error {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s: %s\n\n", m.Rule, m.Summary)
+	fmt.Fprintf(&sb, "Category: %s\n", m.Category)
+	fmt.Fprintf(&sb, "Presets:  %s\n", joinPresets(m.Presets()))
+	if url := m.Rule.DocURL(); url != "" {
+		fmt.Fprintf(&sb, "Docs:     %s\n", url)
+	}
+	if m.Description != "" {
+		fmt.Fprintf(&sb, "\n%s\n", m.Description)
+	}
+	if m.BadExample != "" {
+		fmt.Fprintf(&sb, "\nBad:\n\n%s\n", indent(m.BadExample, "    "))
+	}
+	if m.GoodExample != "" {
+		fmt.Fprintf(&sb, "\nGood:\n\n%s\n", indent(m.GoodExample, "    "))
+	}
+	if len(m.Options) > 0 {
+		sb.WriteString("\nOptions:\n")
+		for _, o := range m.Options {
+			fmt.Fprintf(&sb, "\n    %s (%s, default: %s)\n        %s\n", o.Name, o.Type, o.Default, o.Description)
+		}
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func joinPresets(presets []model.DefaultSet) string {
+	if len(presets) == 0 {
+		return "-"
+	}
+	names := make([]string, 0, len(presets))
+	for _, p := range presets {
+		names = append(names, string(p))
+	}
+	return strings.Join(names, ",")
+}
+
+// indent prefixes every non-empty line of the given text.
+func indent(text, prefix string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = prefix + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
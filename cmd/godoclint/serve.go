@@ -0,0 +1,169 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/doc/comment"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/godoc-lint/godoc-lint/pkg/model"
+	"github.com/godoc-lint/godoc-lint/pkg/render"
+)
+
+// runServe runs the serve subcommand, which starts a local HTTP server to
+// preview the rendered godocs of the given packages, annotated with the lint
+// issues. Packages are re-loaded and re-analyzed on every page load.
+func runServe(env *commandEnv, args []string) int {
+	fs := env.newFlagSet("serve", "[-flag] [package]")
+	addr := fs.String("addr", "localhost:6061", "address to listen on")
+	tests := fs.Bool("test", false, "indicates whether test files should be rendered, too")
+	_ = fs.Parse(args)
+
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return exitCodeError
+	}
+
+	s := &docServer{
+		env:      env,
+		patterns: fs.Args(),
+		tests:    *tests,
+	}
+
+	ln, err := net.Listen("tcp", *addr)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitCodeError
+	}
+	fmt.Fprintf(os.Stderr, "serving docs at http://%s\n", ln.Addr())
+
+	if err := http.Serve(ln, s.handler()); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitCodeError
+	}
+	return exitCodeOK
+}
+
+// docServer serves the rendered godocs of packages.
+type docServer struct {
+	env      *commandEnv
+	patterns []string
+	tests    bool
+
+	// mu serializes loading and analyzing the packages.
+	mu sync.Mutex
+}
+
+const pkgURLPrefix = "/pkg/"
+
+func (s *docServer) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", s.serveIndex)
+	mux.HandleFunc("GET "+pkgURLPrefix+"{path...}", s.servePackage)
+	return mux
+}
+
+// load loads and analyzes the packages, and returns their rendering models,
+// keyed by package path, together with the issues of each package.
+func (s *docServer) load() ([]*render.Package, map[string][]model.Issue, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pkgs, issues, err := inspectPackages(s.env, s.patterns, s.tests, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result := make([]*render.Package, 0, len(pkgs))
+	for _, p := range pkgs {
+		var files []render.File
+		for f, fi := range p.files() {
+			files = append(files, render.File{AST: f, Inspection: fi})
+		}
+		result = append(result, render.NewPackage(p.pkg.Fset, p.pkg.PkgPath, p.pkg.Name, files))
+	}
+
+	byPkg := make(map[string][]model.Issue, len(result))
+	for _, issue := range issues {
+		byPkg[issue.PkgPath] = append(byPkg[issue.PkgPath], issue)
+	}
+	return result, byPkg, nil
+}
+
+func (s *docServer) serveIndex(w http.ResponseWriter, r *http.Request) {
+	pkgs, issues, err := s.load()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	entries := make([]render.IndexEntry, 0, len(pkgs))
+	for _, p := range pkgs {
+		entries = append(entries, render.IndexEntry{
+			Package: p,
+			URL:     pkgURL(p.Path),
+			Issues:  len(issues[p.Path]),
+		})
+	}
+	s.write(w, func(buf *bytes.Buffer) error {
+		return render.WriteHTMLIndex(buf, entries)
+	})
+}
+
+func (s *docServer) servePackage(w http.ResponseWriter, r *http.Request) {
+	pkgs, issues, err := s.load()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	local := make(map[string]bool, len(pkgs))
+	var pkg *render.Package
+	for _, p := range pkgs {
+		local[p.Path] = true
+		if p.Path == r.PathValue("path") {
+			pkg = p
+		}
+	}
+	if pkg == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	opts := render.HTMLOptions{
+		Issues:   issues[pkg.Path],
+		IndexURL: "/",
+		DocLinkURL: func(link *comment.DocLink) string {
+			if link.ImportPath == "" || local[link.ImportPath] {
+				// Links to symbols of local packages should resolve locally.
+				return link.DefaultURL(strings.TrimSuffix(pkgURLPrefix, "/"))
+			}
+			return link.DefaultURL("https://pkg.go.dev")
+		},
+	}
+	s.write(w, func(buf *bytes.Buffer) error {
+		return render.WriteHTML(buf, pkg, opts)
+	})
+}
+
+// write renders the page into a buffer before writing it to the response, so
+// that rendering errors can be reported properly.
+func (s *docServer) write(w http.ResponseWriter, render func(*bytes.Buffer) error) {
+	var buf bytes.Buffer
+	if err := render(&buf); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	_, _ = buf.WriteTo(w)
+}
+
+func pkgURL(path string) string {
+	return pkgURLPrefix + (&url.URL{Path: path}).EscapedPath()
+}
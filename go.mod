@@ -5,15 +5,18 @@ go 1.24.0
 toolchain go1.26.0
 
 require (
+	github.com/BurntSushi/toml v1.6.0
+	github.com/go-viper/mapstructure/v2 v2.4.0
+	github.com/golangci/plugin-module-register v0.1.2
 	github.com/stretchr/testify v1.11.1
 	go.yaml.in/yaml/v3 v3.0.4
+	golang.org/x/mod v0.31.0
 	golang.org/x/tools v0.40.0
 )
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	golang.org/x/mod v0.31.0 // indirect
 	golang.org/x/sync v0.19.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
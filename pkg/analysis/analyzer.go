@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
+	"sync"
 
 	"golang.org/x/tools/go/analysis"
 
@@ -27,16 +28,27 @@ type Analyzer struct {
 	exitFunc  func(int, error)
 
 	analyzer *analysis.Analyzer
+
+	checkersOnce sync.Once
+	checkers     []registeredChecker
+}
+
+// registeredChecker holds a registered checker along with its covered rules,
+// which are computed once, rather than per package.
+type registeredChecker struct {
+	checker      model.Checker
+	rules        model.RuleSet
+	configurable bool
 }
 
-// NewAnalyzer returns a new instance of the corresponding analyzer.
-func NewAnalyzer(baseDir string, cb model.ConfigBuilder, reg model.Registry, inspector model.Inspector, exitFunc func(int, error)) *Analyzer {
+// NewAnalyzer returns a new instance of the corresponding analyzer. Packages
+// outside the given base directory are skipped, unless it is empty.
+func NewAnalyzer(baseDir string, cb model.ConfigBuilder, reg model.Registry, inspector model.Inspector) *Analyzer {
 	result := &Analyzer{
 		baseDir:   baseDir,
 		cb:        cb,
 		reg:       reg,
 		inspector: inspector,
-		exitFunc:  exitFunc,
 		analyzer: &analysis.Analyzer{
 			Name:     metaName,
 			Doc:      metaDoc,
@@ -49,6 +61,13 @@ func NewAnalyzer(baseDir string, cb model.ConfigBuilder, reg model.Registry, ins
 	return result
 }
 
+// WithExitFunc sets the function to call with exit code 2 and the error, when
+// the analysis fails due to the configuration (i.e., a [model.ConfigError]).
+func (a *Analyzer) WithExitFunc(exitFunc func(int, error)) *Analyzer {
+	a.exitFunc = exitFunc
+	return a
+}
+
 // GetAnalyzer returns the underlying analyzer.
 func (a *Analyzer) GetAnalyzer() *analysis.Analyzer {
 	return a.analyzer
@@ -61,48 +80,75 @@ func (a *Analyzer) run(pass *analysis.Pass) (any, error) {
 
 	ft := util.GetPassFileToken(pass.Files[0], pass)
 	if ft == nil {
-		err := errors.New("cannot prepare config")
-		if a.exitFunc != nil {
-			a.exitFunc(2, err)
-		}
-		return nil, err
+		return nil, &model.InternalError{Err: errors.New("cannot prepare config: no file token for package file")}
 	}
 
-	if !util.IsPathUnderBaseDir(a.baseDir, ft.Name()) {
+	if a.baseDir != "" && !util.IsPathUnderBaseDir(a.baseDir, ft.Name()) {
 		return nil, nil
 	}
 
 	pkgDir := filepath.Dir(ft.Name())
 	cfg, err := a.cb.GetConfig(pkgDir)
 	if err != nil {
-		err := fmt.Errorf("cannot prepare config: %w", err)
-		if a.exitFunc != nil {
-			a.exitFunc(2, err)
-		}
-		return nil, err
+		return nil, a.configError(err)
 	}
 
 	ir := pass.ResultOf[a.inspector.GetAnalyzer()].(*model.InspectorResult)
+	if ir != nil && ir.ConfigErr != nil {
+		return nil, a.configError(ir.ConfigErr)
+	}
 	if ir == nil || ir.Files == nil {
 		return nil, nil
 	}
 
-	actx := &model.AnalysisContext{
-		Config:          cfg,
-		InspectorResult: ir,
-		Pass:            pass,
-	}
-
-	for _, checker := range a.reg.List() {
-		// TODO(babakks): This can be done once to improve performance.
-		ruleSet := checker.GetCoveredRules()
-		if !actx.Config.IsAnyRuleApplicable(ruleSet) {
+	for _, rc := range a.getCheckers() {
+		checker, ruleSet := rc.checker, rc.rules
+		if !cfg.IsAnyRuleApplicable(ruleSet) {
 			continue
 		}
 
+		actx := &model.AnalysisContext{
+			Config:          cfg,
+			InspectorResult: ir,
+			Pass:            pass,
+		}
+
+		if rc.configurable {
+			actx.Options = cfg.GetCheckerOptions(checker)
+			if actx.Options == nil {
+				return nil, &model.InternalError{Err: fmt.Errorf("checker options are not resolved for rules %q; the config builder should use the same registry", ruleSet.List())}
+			}
+		}
+
 		if err := checker.Apply(actx); err != nil {
 			return nil, fmt.Errorf("checker error: %w", err)
 		}
 	}
 	return nil, nil
 }
+
+// configError calls the exit function, if set, when the given error is a config
+// error. It returns the given error.
+func (a *Analyzer) configError(err error) error {
+	var cerr *model.ConfigError
+	if a.exitFunc != nil && errors.As(err, &cerr) {
+		a.exitFunc(2, err)
+	}
+	return err
+}
+
+// getCheckers returns the registered checkers. Since checkers are registered
+// before the analysis starts, the list is computed once, on the first call.
+func (a *Analyzer) getCheckers() []registeredChecker {
+	a.checkersOnce.Do(func() {
+		for _, checker := range a.reg.List() {
+			_, configurable := checker.(model.ConfigurableChecker)
+			a.checkers = append(a.checkers, registeredChecker{
+				checker:      checker,
+				rules:        checker.GetCoveredRules(),
+				configurable: configurable,
+			})
+		}
+	})
+	return a.checkers
+}
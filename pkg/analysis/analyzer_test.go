@@ -1,7 +1,6 @@
 package analysis_test
 
 import (
-	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
@@ -13,6 +12,7 @@ import (
 	"github.com/godoc-lint/godoc-lint/pkg/check"
 	"github.com/godoc-lint/godoc-lint/pkg/config"
 	"github.com/godoc-lint/godoc-lint/pkg/inspect"
+	"github.com/godoc-lint/godoc-lint/pkg/model"
 )
 
 func TestRules(t *testing.T) {
@@ -21,17 +21,21 @@ func TestRules(t *testing.T) {
 	wd, err := os.Getwd()
 	require.NoError(err, "failed to get wd")
 
-	exitFunc := func(code int, err error) {
-		panic(fmt.Sprintf("exit code %d: %v", code, err))
-	}
-
 	testdir := filepath.Join(wd, "../../testdata/rule")
 
 	reg := check.NewPopulatedRegistry()
-	cb := config.NewConfigBuilder(testdir)
+	cb := config.NewConfigBuilder(testdir).WithRegistry(reg)
 	ocb := config.NewOnceConfigBuilder(cb)
-	inspector := inspect.NewInspector(ocb, exitFunc)
-	analyzer := analysis.NewAnalyzer(testdir, ocb, reg, inspector, exitFunc)
+	inspector := inspect.NewInspector(ocb)
+	analyzer := analysis.NewAnalyzer(testdir, ocb, reg, inspector)
 
-	_ = analysistest.Run(t, testdir, analyzer.GetAnalyzer(), "./...")
+	results := analysistest.Run(t, testdir, analyzer.GetAnalyzer(), "./...")
+
+	for _, r := range results {
+		for _, d := range r.Diagnostics {
+			rule := model.Rule(d.Category)
+			require.True(model.AllRules.Has(rule), "diagnostic category is not a rule name: %q", d.Category)
+			require.Equal(rule.DocURL(), d.URL, "diagnostic URL does not point to rule docs")
+		}
+	}
 }
@@ -0,0 +1,185 @@
+// Package baseline provides recording and suppression of known issues.
+//
+// A baseline is a set of entries, each of which identifies an issue by a key
+// that does not depend on line numbers (i.e., rule name, package path, symbol
+// name and a hash of the message). So, the baseline survives unrelated changes
+// that shift lines around.
+package baseline
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/godoc-lint/godoc-lint/pkg/model"
+)
+
+// Version is the current version of the baseline file format.
+const Version = "1"
+
+// messageHashLen is the length of the (hex-encoded) message hashes.
+const messageHashLen = 16
+
+// Baseline represents a set of recorded issues.
+type Baseline struct {
+	// Version is the baseline file format version.
+	Version string `json:"version"`
+
+	// Entries holds the recorded issues.
+	Entries []Entry `json:"entries"`
+}
+
+// Entry represents a recorded issue.
+type Entry struct {
+	// Rule is the rule that produced the issue.
+	Rule model.Rule `json:"rule"`
+
+	// PkgPath is the import path of the package.
+	PkgPath string `json:"package"`
+
+	// Symbol is the name of the symbol the issue is reported on, if any.
+	Symbol string `json:"symbol,omitempty"`
+
+	// MessageHash is the (truncated) SHA-256 hash of the issue message.
+	MessageHash string `json:"message_hash"`
+
+	// Count is the number of identical issues.
+	Count int `json:"count"`
+}
+
+// String returns a human-readable representation of the entry.
+func (e Entry) String() string {
+	symbol := e.Symbol
+	if symbol == "" {
+		symbol = "-"
+	}
+	return fmt.Sprintf("%s %s %s %s (x%d)", e.Rule, e.PkgPath, symbol, e.MessageHash, e.Count)
+}
+
+type entryKey struct {
+	rule        model.Rule
+	pkgPath     string
+	symbol      string
+	messageHash string
+}
+
+func (e Entry) key() entryKey {
+	return entryKey{
+		rule:        e.Rule,
+		pkgPath:     e.PkgPath,
+		symbol:      e.Symbol,
+		messageHash: e.MessageHash,
+	}
+}
+
+func keyOf(issue model.Issue) entryKey {
+	sum := sha256.Sum256([]byte(issue.Message))
+	return entryKey{
+		rule:        issue.Rule,
+		pkgPath:     issue.PkgPath,
+		symbol:      issue.Symbol,
+		messageHash: hex.EncodeToString(sum[:])[:messageHashLen],
+	}
+}
+
+// New returns a baseline recording the given issues.
+func New(issues []model.Issue) *Baseline {
+	counts := make(map[entryKey]int, len(issues))
+	for _, issue := range issues {
+		counts[keyOf(issue)]++
+	}
+
+	entries := make([]Entry, 0, len(counts))
+	for k, n := range counts {
+		entries = append(entries, Entry{
+			Rule:        k.rule,
+			PkgPath:     k.pkgPath,
+			Symbol:      k.symbol,
+			MessageHash: k.messageHash,
+			Count:       n,
+		})
+	}
+	slices.SortFunc(entries, func(a, b Entry) int {
+		return cmp.Or(
+			cmp.Compare(a.PkgPath, b.PkgPath),
+			cmp.Compare(a.Symbol, b.Symbol),
+			cmp.Compare(a.Rule, b.Rule),
+			cmp.Compare(a.MessageHash, b.MessageHash),
+		)
+	})
+
+	return &Baseline{
+		Version: Version,
+		Entries: entries,
+	}
+}
+
+// FromFile reads a baseline from the given file path.
+func FromFile(path string) (*Baseline, error) {
+	in, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read baseline file (%s): %w", path, err)
+	}
+
+	b := &Baseline{}
+	if err := json.Unmarshal(in, b); err != nil {
+		return nil, fmt.Errorf("cannot parse baseline file (%s): %w", path, err)
+	}
+
+	if b.Version != Version {
+		return nil, fmt.Errorf("unsupported baseline version: %q", b.Version)
+	}
+	return b, nil
+}
+
+// WriteFile writes the baseline to the given file path.
+func (b *Baseline) WriteFile(path string) error {
+	out, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, append(out, '\n'), 0o644); err != nil {
+		return fmt.Errorf("cannot write baseline file (%s): %w", path, err)
+	}
+	return nil
+}
+
+// Filter returns the issues that are not recorded in the baseline. It also
+// returns the stale entries, i.e., those that no longer match any issue. For
+// stale entries, the count is the number of unmatched occurrences.
+//
+// The order of issues is preserved.
+func (b *Baseline) Filter(issues []model.Issue) ([]model.Issue, []Entry) {
+	remaining := make(map[entryKey]int, len(b.Entries))
+	for _, e := range b.Entries {
+		remaining[e.key()] += e.Count
+	}
+
+	result := make([]model.Issue, 0, len(issues))
+	for _, issue := range issues {
+		k := keyOf(issue)
+		if remaining[k] > 0 {
+			remaining[k]--
+			continue
+		}
+		result = append(result, issue)
+	}
+
+	var stale []Entry
+	for _, e := range b.Entries {
+		k := e.key()
+		n := remaining[k]
+		if n <= 0 {
+			continue
+		}
+		// Duplicate entries share the same counter, so we drain it here.
+		remaining[k] = 0
+		e.Count = n
+		stale = append(stale, e)
+	}
+	return result, stale
+}
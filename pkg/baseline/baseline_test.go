@@ -0,0 +1,121 @@
+package baseline_test
+
+import (
+	"go/token"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/godoc-lint/godoc-lint/pkg/baseline"
+	"github.com/godoc-lint/godoc-lint/pkg/model"
+)
+
+func issue(rule model.Rule, pkg, symbol string, line int, message string) model.Issue {
+	return model.Issue{
+		Rule:    rule,
+		PkgPath: pkg,
+		Symbol:  symbol,
+		Pos:     token.Position{Filename: "/foo/foo.go", Line: line, Column: 1},
+		Message: message,
+	}
+}
+
+func TestNew(t *testing.T) {
+	require := require.New(t)
+
+	b := baseline.New([]model.Issue{
+		issue(model.MaxLenRule, "foo", "Foo", 1, "too long"),
+		issue(model.MaxLenRule, "foo", "Foo", 2, "too long"),
+		issue(model.RequireDocRule, "foo", "Bar", 3, "no godoc"),
+		issue(model.RequirePkgDocRule, "bar", "", 1, "no package godoc"),
+	})
+
+	require.Equal(baseline.Version, b.Version)
+	require.Len(b.Entries, 3)
+
+	// Entries are sorted by package path, and then by symbol name.
+	require.Equal("bar", b.Entries[0].PkgPath)
+	require.Equal(model.RequirePkgDocRule, b.Entries[0].Rule)
+	require.Equal(1, b.Entries[0].Count)
+
+	require.Equal("Bar", b.Entries[1].Symbol)
+	require.Equal(1, b.Entries[1].Count)
+
+	require.Equal("Foo", b.Entries[2].Symbol)
+	require.Equal(model.MaxLenRule, b.Entries[2].Rule)
+	require.Equal(2, b.Entries[2].Count)
+	require.Len(b.Entries[2].MessageHash, 16)
+}
+
+func TestFilter(t *testing.T) {
+	require := require.New(t)
+
+	b := baseline.New([]model.Issue{
+		issue(model.MaxLenRule, "foo", "Foo", 1, "too long"),
+		issue(model.MaxLenRule, "foo", "Foo", 2, "too long"),
+		issue(model.RequireDocRule, "foo", "Bar", 3, "no godoc"),
+		issue(model.RequirePkgDocRule, "bar", "", 1, "no package godoc"),
+	})
+
+	// Lines are shifted, one of the long lines is fixed, the package godoc is
+	// added, and a new issue is introduced.
+	got, stale := b.Filter([]model.Issue{
+		issue(model.MaxLenRule, "foo", "Foo", 11, "too long"),
+		issue(model.RequireDocRule, "foo", "Bar", 13, "no godoc"),
+		issue(model.RequireDocRule, "foo", "Baz", 14, "no godoc"),
+		issue(model.MaxLenRule, "foo", "Bar", 15, "too long"),
+	})
+
+	require.Equal([]model.Issue{
+		issue(model.RequireDocRule, "foo", "Baz", 14, "no godoc"),
+		issue(model.MaxLenRule, "foo", "Bar", 15, "too long"),
+	}, got)
+
+	require.Len(stale, 2)
+	require.Equal(model.RequirePkgDocRule, stale[0].Rule)
+	require.Equal(1, stale[0].Count)
+	require.Equal(model.MaxLenRule, stale[1].Rule)
+	require.Equal("Foo", stale[1].Symbol)
+	require.Equal(1, stale[1].Count)
+}
+
+func TestFilterChangedMessage(t *testing.T) {
+	b := baseline.New([]model.Issue{
+		issue(model.MaxLenRule, "foo", "Foo", 1, "too long (80 > 77)"),
+	})
+
+	got, stale := b.Filter([]model.Issue{
+		issue(model.MaxLenRule, "foo", "Foo", 1, "too long (90 > 77)"),
+	})
+	require.Len(t, got, 1)
+	require.Len(t, stale, 1)
+}
+
+func TestFileRoundTrip(t *testing.T) {
+	require := require.New(t)
+
+	path := filepath.Join(t.TempDir(), "baseline.json")
+
+	b := baseline.New([]model.Issue{
+		issue(model.MaxLenRule, "foo", "Foo", 1, "too long"),
+		issue(model.RequirePkgDocRule, "bar", "", 1, "no package godoc"),
+	})
+	require.NoError(b.WriteFile(path))
+
+	got, err := baseline.FromFile(path)
+	require.NoError(err)
+	require.Equal(b, got)
+}
+
+func TestFromFileErrors(t *testing.T) {
+	require := require.New(t)
+
+	_, err := baseline.FromFile(filepath.Join(t.TempDir(), "nonexistent.json"))
+	require.Error(err)
+
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	require.NoError((&baseline.Baseline{Version: "999"}).WriteFile(path))
+	_, err = baseline.FromFile(path)
+	require.ErrorContains(err, "unsupported baseline version")
+}
@@ -0,0 +1,222 @@
+// Package bootstrap provides suggestion of an initial configuration for a
+// codebase, based on the findings of all rules on it.
+//
+// Rules without any finding are enabled right away, while the rules with a few
+// findings are suggested as candidates, along with the directories where their
+// findings are (i.e., the hotspots), which can be excluded to adopt the rules
+// sooner.
+package bootstrap
+
+import (
+	"cmp"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/godoc-lint/godoc-lint/pkg/config"
+	"github.com/godoc-lint/godoc-lint/pkg/model"
+)
+
+// configVersion is the version of the suggested configuration.
+const configVersion = "1.0"
+
+// Options holds the options to suggest a configuration.
+type Options struct {
+	// MaxFindings is the maximum number of findings for a rule to be suggested
+	// as a candidate.
+	MaxFindings int
+
+	// ExcludeHotspots indicates whether the hotspots of the candidates should
+	// be excluded, so that the candidates can be enabled. Since exclusions
+	// apply to all rules, candidates are picked greedily (i.e., the ones with
+	// the fewest hotspots first), up to MaxHotspots excluded directories.
+	ExcludeHotspots bool
+
+	// MaxHotspots is the maximum number of directories to exclude, if the
+	// hotspots are excluded.
+	MaxHotspots int
+}
+
+// DefaultOptions returns the default options.
+func DefaultOptions() Options {
+	return Options{
+		MaxFindings: 10,
+		MaxHotspots: 5,
+	}
+}
+
+// DirCount holds the number of findings in a directory.
+type DirCount struct {
+	// Dir is the Unix-style path of the directory, relative to the base
+	// directory (e.g., "foo/bar", or "." for the base directory itself).
+	Dir string
+
+	// Count is the number of findings.
+	Count int
+}
+
+// RuleStats holds the findings of a rule.
+type RuleStats struct {
+	// Rule is the rule.
+	Rule model.Rule
+
+	// Total is the number of findings.
+	Total int
+
+	// Dirs holds the number of findings by directory, sorted by the count (in
+	// descending order) and then the directory.
+	Dirs []DirCount
+}
+
+// Suggestion represents a suggested configuration.
+type Suggestion struct {
+	// Passing holds the rules without any finding, which are enabled.
+	Passing []RuleStats
+
+	// Excluded holds the candidate rules whose hotspots are excluded, which
+	// are enabled too.
+	Excluded []RuleStats
+
+	// Candidates holds the rules with a few findings, which are not enabled.
+	Candidates []RuleStats
+
+	// Failing holds the rules with too many findings, which are not enabled.
+	Failing []RuleStats
+
+	// ExcludedDirs holds the excluded directories, in the same format as
+	// [DirCount.Dir].
+	ExcludedDirs []string
+}
+
+// Suggest suggests a configuration, based on the given issues found by running
+// the given rules. File paths are made relative to the given base directory.
+func Suggest(baseDir string, rules model.RuleSet, issues []model.Issue, opts Options) *Suggestion {
+	counts := make(map[model.Rule]map[string]int)
+	for _, issue := range issues {
+		if !rules.Has(issue.Rule) {
+			continue
+		}
+		if counts[issue.Rule] == nil {
+			counts[issue.Rule] = make(map[string]int)
+		}
+		counts[issue.Rule][relDir(baseDir, issue.Pos.Filename)]++
+	}
+
+	s := &Suggestion{}
+	var candidates []RuleStats
+	for _, rule := range rules.List() {
+		rs := newRuleStats(rule, counts[rule])
+		switch {
+		case rs.Total == 0:
+			s.Passing = append(s.Passing, rs)
+		case rs.Total <= opts.MaxFindings:
+			candidates = append(candidates, rs)
+		default:
+			s.Failing = append(s.Failing, rs)
+		}
+	}
+
+	if !opts.ExcludeHotspots {
+		s.Candidates = candidates
+		return s
+	}
+
+	// Candidates with fewer hotspots are picked first, to enable as many
+	// rules as possible with the fewest exclusions.
+	byHotspots := slices.Clone(candidates)
+	slices.SortStableFunc(byHotspots, func(a, b RuleStats) int {
+		return cmp.Or(cmp.Compare(len(a.Dirs), len(b.Dirs)), cmp.Compare(a.Total, b.Total))
+	})
+	excluded := make(map[string]bool)
+	for _, rs := range byHotspots {
+		var dirs []string
+		for _, dc := range rs.Dirs {
+			if !excluded[dc.Dir] {
+				dirs = append(dirs, dc.Dir)
+			}
+		}
+		if slices.ContainsFunc(dirs, func(dir string) bool { return !isExcludable(dir) }) {
+			continue
+		}
+		if len(excluded)+len(dirs) > opts.MaxHotspots {
+			continue
+		}
+		for _, dir := range dirs {
+			excluded[dir] = true
+		}
+	}
+
+	for _, rs := range candidates {
+		if slices.ContainsFunc(rs.Dirs, func(dc DirCount) bool { return !excluded[dc.Dir] }) {
+			s.Candidates = append(s.Candidates, rs)
+		} else {
+			s.Excluded = append(s.Excluded, rs)
+		}
+	}
+	for dir := range excluded {
+		s.ExcludedDirs = append(s.ExcludedDirs, dir)
+	}
+	slices.Sort(s.ExcludedDirs)
+	return s
+}
+
+// PlainConfig returns the suggested configuration.
+func (s *Suggestion) PlainConfig() *config.PlainConfig {
+	enable := make([]string, 0, len(s.Passing)+len(s.Excluded))
+	for _, rs := range slices.Concat(s.Passing, s.Excluded) {
+		enable = append(enable, string(rs.Rule))
+	}
+	slices.Sort(enable)
+
+	var exclude []string
+	for _, dir := range s.ExcludedDirs {
+		exclude = append(exclude, ExcludePattern(dir))
+	}
+
+	version := configVersion
+	none := string(model.DefaultSetNone)
+	return &config.PlainConfig{
+		Version: &version,
+		Default: &none,
+		Enable:  enable,
+		Exclude: exclude,
+	}
+}
+
+// ExcludePattern returns the path pattern to exclude the files in the given
+// directory (but not its sub-directories), in the same format as
+// [DirCount.Dir].
+func ExcludePattern(dir string) string {
+	return "^" + regexp.QuoteMeta(dir) + "/[^/]+$"
+}
+
+// newRuleStats returns the stats of the given rule, from the given counts by
+// directory.
+func newRuleStats(rule model.Rule, counts map[string]int) RuleStats {
+	rs := RuleStats{Rule: rule}
+	for dir, n := range counts {
+		rs.Total += n
+		rs.Dirs = append(rs.Dirs, DirCount{Dir: dir, Count: n})
+	}
+	slices.SortFunc(rs.Dirs, func(a, b DirCount) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.Dir, b.Dir))
+	})
+	return rs
+}
+
+// relDir returns the Unix-style path of the directory of the given file,
+// relative to the given base directory, if possible.
+func relDir(baseDir, path string) string {
+	dir := filepath.Dir(path)
+	if rel, err := filepath.Rel(baseDir, dir); err == nil {
+		dir = rel
+	}
+	return filepath.ToSlash(dir)
+}
+
+// isExcludable determines whether the given directory can be excluded without
+// excluding the whole base directory, or files outside of it.
+func isExcludable(dir string) bool {
+	return dir != "." && dir != ".." && !strings.HasPrefix(dir, "../") && !filepath.IsAbs(filepath.FromSlash(dir))
+}
@@ -0,0 +1,171 @@
+package bootstrap_test
+
+import (
+	"bytes"
+	"go/token"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/godoc-lint/godoc-lint/pkg/bootstrap"
+	"github.com/godoc-lint/godoc-lint/pkg/config"
+	"github.com/godoc-lint/godoc-lint/pkg/model"
+)
+
+func TestSuggest(t *testing.T) {
+	baseDir := t.TempDir()
+	issue := func(rule model.Rule, path string) model.Issue {
+		return model.Issue{
+			Rule: rule,
+			Pos:  token.Position{Filename: filepath.Join(baseDir, filepath.FromSlash(path)), Line: 1, Column: 1},
+		}
+	}
+
+	rules := model.RuleSet{}.Add(
+		model.MaxLenRule,
+		model.StartWithNameRule,
+		model.RequireDocRule,
+		model.DeprecatedRule,
+	)
+
+	var issues []model.Issue
+	for range 5 {
+		issues = append(issues, issue(model.RequireDocRule, "foo/foo.go"))
+	}
+	issues = append(issues,
+		issue(model.MaxLenRule, "foo/foo.go"),
+		issue(model.MaxLenRule, "foo/bar/bar.go"),
+		issue(model.MaxLenRule, "foo/bar/bar.go"),
+		issue(model.StartWithNameRule, "root.go"),
+		issue(model.PkgDocRule, "foo/foo.go"), // Not among the given rules.
+	)
+
+	opts := bootstrap.Options{MaxFindings: 3, MaxHotspots: 2}
+
+	t.Run("candidates", func(t *testing.T) {
+		require := require.New(t)
+
+		s := bootstrap.Suggest(baseDir, rules, issues, opts)
+		require.Equal([]bootstrap.RuleStats{{Rule: model.DeprecatedRule}}, s.Passing)
+		require.Empty(s.Excluded)
+		require.Equal([]bootstrap.RuleStats{{
+			Rule:  model.MaxLenRule,
+			Total: 3,
+			Dirs:  []bootstrap.DirCount{{Dir: "foo/bar", Count: 2}, {Dir: "foo", Count: 1}},
+		}, {
+			Rule:  model.StartWithNameRule,
+			Total: 1,
+			Dirs:  []bootstrap.DirCount{{Dir: ".", Count: 1}},
+		}}, s.Candidates)
+		require.Equal([]bootstrap.RuleStats{{
+			Rule:  model.RequireDocRule,
+			Total: 5,
+			Dirs:  []bootstrap.DirCount{{Dir: "foo", Count: 5}},
+		}}, s.Failing)
+		require.Empty(s.ExcludedDirs)
+
+		pcfg := s.PlainConfig()
+		require.Equal([]string{"deprecated"}, pcfg.Enable)
+		require.Empty(pcfg.Exclude)
+	})
+
+	t.Run("exclude hotspots", func(t *testing.T) {
+		require := require.New(t)
+
+		opts := opts
+		opts.ExcludeHotspots = true
+		s := bootstrap.Suggest(baseDir, rules, issues, opts)
+		require.Equal([]model.Rule{model.MaxLenRule}, rulesOf(s.Excluded))
+		require.Equal([]model.Rule{model.StartWithNameRule}, rulesOf(s.Candidates), "the base directory cannot be excluded")
+		require.Equal([]string{"foo", "foo/bar"}, s.ExcludedDirs)
+
+		pcfg := s.PlainConfig()
+		require.Equal([]string{"deprecated", "max-len"}, pcfg.Enable)
+		require.Equal([]string{`^foo/[^/]+$`, `^foo/bar/[^/]+$`}, pcfg.Exclude)
+
+		opts.MaxHotspots = 1
+		s = bootstrap.Suggest(baseDir, rules, issues, opts)
+		require.Empty(s.Excluded, "too many hotspots")
+		require.Empty(s.ExcludedDirs)
+	})
+}
+
+func TestWriteYAML(t *testing.T) {
+	require := require.New(t)
+
+	s := &bootstrap.Suggestion{
+		Passing:  []bootstrap.RuleStats{{Rule: model.DeprecatedRule}},
+		Excluded: []bootstrap.RuleStats{{Rule: model.MaxLenRule, Total: 1, Dirs: []bootstrap.DirCount{{Dir: "gen", Count: 1}}}},
+		Candidates: []bootstrap.RuleStats{{
+			Rule:  model.StartWithNameRule,
+			Total: 2,
+			Dirs:  []bootstrap.DirCount{{Dir: "foo", Count: 1}, {Dir: "foo/bar", Count: 1}},
+		}},
+		Failing: []bootstrap.RuleStats{{
+			Rule:  model.RequireDocRule,
+			Total: 40,
+			Dirs: []bootstrap.DirCount{
+				{Dir: "a", Count: 10},
+				{Dir: "b", Count: 10},
+				{Dir: "c", Count: 10},
+				{Dir: "d", Count: 10},
+			},
+		}},
+		ExcludedDirs: []string{"gen"},
+	}
+
+	buf := bytes.NewBuffer(nil)
+	require.NoError(s.WriteYAML(buf))
+	require.Equal(`# Generated by "godoclint init", based on the findings of all rules.
+#
+# Rules without findings are enabled. To adopt more rules, fix the findings
+# of the candidates (listed at the end), and add them to the "enable" list.
+version: "1.0"
+default: none
+enable:
+  - deprecated
+  - max-len # 1 finding, in excluded directories
+exclude:
+  # Hotspots of the rules enabled above; the files in these directories
+  # are not checked by any rule.
+  - "^gen/[^/]+$"
+
+# Candidates (i.e., rules with a few findings):
+#   - start-with-name: 2 findings
+#       foo: 1
+#       foo/bar: 1
+#     To enable it right away, exclude its hotspots, by adding to "exclude":
+#       - "^foo/[^/]+$"
+#       - "^foo/bar/[^/]+$"
+
+# Other rules (i.e., with too many findings):
+#   - require-doc: 40 findings
+#       a: 10
+#       b: 10
+#       c: 10
+#       (and 1 more)
+`, buf.String())
+
+	pcfg, err := config.FromYAML(buf.Bytes())
+	require.NoError(err)
+	require.NoError(pcfg.Validate())
+	require.Equal(s.PlainConfig(), pcfg)
+
+	t.Run("nothing enabled", func(t *testing.T) {
+		buf := bytes.NewBuffer(nil)
+		require.NoError((&bootstrap.Suggestion{}).WriteYAML(buf))
+		pcfg, err := config.FromYAML(buf.Bytes())
+		require.NoError(err)
+		require.Empty(pcfg.Enable)
+		require.NotNil(pcfg.Enable, "an empty list should be written, rather than nothing")
+	})
+}
+
+func rulesOf(stats []bootstrap.RuleStats) []model.Rule {
+	rules := make([]model.Rule, 0, len(stats))
+	for _, rs := range stats {
+		rules = append(rules, rs.Rule)
+	}
+	return rules
+}
@@ -0,0 +1,96 @@
+package bootstrap
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// maxFailingDirs is the maximum number of directories listed for the rules with
+// too many findings.
+const maxFailingDirs = 3
+
+// WriteYAML writes the suggested configuration as a YAML config file, where the
+// findings of the rules are explained in comments.
+func (s *Suggestion) WriteYAML(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	pcfg := s.PlainConfig()
+
+	fmt.Fprintln(bw, `# Generated by "godoclint init", based on the findings of all rules.`)
+	fmt.Fprintln(bw, "#")
+	fmt.Fprintln(bw, "# Rules without findings are enabled. To adopt more rules, fix the findings")
+	fmt.Fprintln(bw, `# of the candidates (listed at the end), and add them to the "enable" list.`)
+	fmt.Fprintf(bw, "version: %s\n", strconv.Quote(*pcfg.Version))
+	fmt.Fprintf(bw, "default: %s\n", *pcfg.Default)
+
+	if len(s.Passing) == 0 && len(s.Excluded) == 0 {
+		fmt.Fprintln(bw, "enable: []")
+	} else {
+		fmt.Fprintln(bw, "enable:")
+		for _, rs := range s.Passing {
+			fmt.Fprintf(bw, "  - %s\n", rs.Rule)
+		}
+		for _, rs := range s.Excluded {
+			fmt.Fprintf(bw, "  - %s # %s, in excluded directories\n", rs.Rule, formatFindings(rs.Total))
+		}
+	}
+
+	if len(pcfg.Exclude) > 0 {
+		fmt.Fprintln(bw, "exclude:")
+		fmt.Fprintln(bw, "  # Hotspots of the rules enabled above; the files in these directories")
+		fmt.Fprintln(bw, "  # are not checked by any rule.")
+		for _, pattern := range pcfg.Exclude {
+			fmt.Fprintf(bw, "  - %s\n", strconv.Quote(pattern))
+		}
+	}
+
+	if len(s.Candidates) > 0 {
+		fmt.Fprintln(bw)
+		fmt.Fprintln(bw, "# Candidates (i.e., rules with a few findings):")
+		for _, rs := range s.Candidates {
+			fmt.Fprintf(bw, "#   - %s: %s\n", rs.Rule, formatFindings(rs.Total))
+			for _, dc := range rs.Dirs {
+				fmt.Fprintf(bw, "#       %s: %d\n", dc.Dir, dc.Count)
+			}
+			var patterns []string
+			for _, dc := range rs.Dirs {
+				if isExcludable(dc.Dir) {
+					patterns = append(patterns, ExcludePattern(dc.Dir))
+				}
+			}
+			if len(patterns) == len(rs.Dirs) {
+				fmt.Fprintln(bw, `#     To enable it right away, exclude its hotspots, by adding to "exclude":`)
+				for _, pattern := range patterns {
+					fmt.Fprintf(bw, "#       - %s\n", strconv.Quote(pattern))
+				}
+			}
+		}
+	}
+
+	if len(s.Failing) > 0 {
+		fmt.Fprintln(bw)
+		fmt.Fprintln(bw, "# Other rules (i.e., with too many findings):")
+		for _, rs := range s.Failing {
+			fmt.Fprintf(bw, "#   - %s: %s\n", rs.Rule, formatFindings(rs.Total))
+			for i, dc := range rs.Dirs {
+				if i == maxFailingDirs {
+					fmt.Fprintf(bw, "#       (and %d more)\n", len(rs.Dirs)-maxFailingDirs)
+					break
+				}
+				fmt.Fprintf(bw, "#       %s: %d\n", dc.Dir, dc.Count)
+			}
+		}
+	}
+
+	return bw.Flush()
+}
+
+// formatFindings returns the given number of findings as a human-readable
+// string (e.g., "1 finding", or "2 findings").
+func formatFindings(n int) string {
+	if n == 1 {
+		return "1 finding"
+	}
+	return strconv.Itoa(n) + " findings"
+}
@@ -0,0 +1,88 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+)
+
+// buildID returns the identity of the running binary, which is included in
+// all keys, since the linter version alone does not change with the code of
+// development builds, or of custom binaries built on the linter (e.g., with
+// custom checkers).
+//
+// The identity is made of the build info (i.e., the main module and all the
+// dependencies, with their versions and checksums, and the VCS revision).
+// Since the build info cannot tell apart the builds of a modified (or
+// unversioned) working tree, or of locally replaced dependencies, the hash of
+// the executable is also included in such cases. If the executable cannot be
+// read either, the identity is unique to the process, so that no entries are
+// reused.
+var buildID = sync.OnceValue(func() string {
+	var sb strings.Builder
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "exe " + hashExecutable() + "\n"
+	}
+
+	// Dependencies without a checksum are replaced by local directories.
+	pinned := true
+	writeModule := func(m *debug.Module) {
+		for m.Replace != nil {
+			m = m.Replace
+		}
+		sb.WriteString(m.Path + "@" + m.Version + " " + m.Sum + "\n")
+	}
+	sb.WriteString(info.GoVersion + "\n")
+	writeModule(&info.Main)
+	for _, dep := range info.Deps {
+		writeModule(dep)
+		if dep.Replace != nil && dep.Replace.Sum == "" {
+			pinned = false
+		}
+	}
+
+	var revision, modified string
+	for _, s := range info.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			revision = s.Value
+		case "vcs.modified":
+			modified = s.Value
+		}
+	}
+	sb.WriteString("vcs " + revision + " " + modified + "\n")
+
+	released := info.Main.Version != "" && info.Main.Version != "(devel)"
+	if !pinned || !released && (revision == "" || modified == "true") {
+		sb.WriteString("exe " + hashExecutable() + "\n")
+	}
+	return sb.String()
+})
+
+// hashExecutable returns the hex-encoded hash of the running executable. If it
+// cannot be read, a value unique to the process is returned.
+func hashExecutable() string {
+	unique := fmt.Sprintf("unknown-%d-%d", os.Getpid(), time.Now().UnixNano())
+	path, err := os.Executable()
+	if err != nil {
+		return unique
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return unique
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return unique
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,176 @@
+// Package cache provides a persistent, on-disk cache of the linter findings.
+//
+// Entries are keyed by a hash of everything that may affect the findings of a
+// package (i.e., the content of its files, the resolved configuration, the
+// registered checkers, and the linter build). So, entries never need to be
+// invalidated; they are just not looked up anymore once any of the inputs
+// changes.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/godoc-lint/godoc-lint/pkg/model"
+	"github.com/godoc-lint/godoc-lint/pkg/version"
+)
+
+// formatVersion is the version of the entry format. It should be bumped
+// whenever the entry format (or the way keys are computed) changes.
+const formatVersion = "2"
+
+// entriesDir is the name of the sub-directory that holds the entries.
+const entriesDir = "entries"
+
+// Cache represents an on-disk cache directory.
+type Cache struct {
+	dir string
+}
+
+// Entry represents the cached findings of a package (or the packages in the
+// same directory, e.g., foo and foo_test).
+type Entry struct {
+	// Packages holds the paths of the analyzed packages.
+	Packages []string `json:"packages"`
+
+	// Issues holds the issues reported on the packages.
+	Issues []model.Issue `json:"issues"`
+}
+
+// DefaultDir returns the default cache directory, under the user cache
+// directory (e.g., "~/.cache/godoclint" on Linux).
+func DefaultDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot determine user cache directory: %w", err)
+	}
+	return filepath.Join(dir, "godoclint"), nil
+}
+
+// Open returns the cache in the given directory, which is created if it does
+// not exist.
+func Open(dir string) (*Cache, error) {
+	if err := os.MkdirAll(filepath.Join(dir, entriesDir), 0o755); err != nil {
+		return nil, fmt.Errorf("cannot create cache directory: %w", err)
+	}
+	return &Cache{dir: dir}, nil
+}
+
+// Dir returns the cache directory.
+func (c *Cache) Dir() string {
+	return c.dir
+}
+
+// Get returns the entry with the given key. Missing or corrupted entries are
+// reported as not found.
+func (c *Cache) Get(key Key) (*Entry, bool) {
+	in, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+	e := &Entry{}
+	if err := json.Unmarshal(in, e); err != nil {
+		return nil, false
+	}
+	return e, true
+}
+
+// Put stores the given entry with the given key. The entry is written to a
+// temporary file first, and then renamed, so concurrent runs never observe
+// partially written entries.
+func (c *Cache) Put(key Key, e *Entry) error {
+	out, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	path := c.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("cannot write cache entry: %w", err)
+	}
+	f, err := os.CreateTemp(filepath.Dir(path), "tmp-*")
+	if err != nil {
+		return fmt.Errorf("cannot write cache entry: %w", err)
+	}
+	_, err = f.Write(out)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), path)
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+		return fmt.Errorf("cannot write cache entry: %w", err)
+	}
+	return nil
+}
+
+// Clean removes all entries from the cache.
+func (c *Cache) Clean() error {
+	if err := os.RemoveAll(filepath.Join(c.dir, entriesDir)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("cannot clean cache: %w", err)
+	}
+	return nil
+}
+
+// path returns the path to the file of the entry with the given key. Entries
+// are spread over sub-directories, to keep the directories small.
+func (c *Cache) path(key Key) string {
+	s := key.String()
+	return filepath.Join(c.dir, entriesDir, s[:2], s+".json")
+}
+
+// Key represents the key of a cache entry.
+type Key [sha256.Size]byte
+
+// String returns the hex representation of the key.
+func (k Key) String() string {
+	return hex.EncodeToString(k[:])
+}
+
+// KeyBuilder computes a cache key from the inputs of the analysis. The linter
+// version, the identity of the running binary (See [buildID]), and the entry
+// format version are always included.
+type KeyBuilder struct {
+	h hash.Hash
+}
+
+// NewKeyBuilder returns a new key builder.
+func NewKeyBuilder() *KeyBuilder {
+	b := &KeyBuilder{h: sha256.New()}
+	b.String("godoclint", version.Current.String(), buildID(), formatVersion)
+	return b
+}
+
+// String adds the given strings to the key.
+func (b *KeyBuilder) String(values ...string) *KeyBuilder {
+	for _, v := range values {
+		_, _ = fmt.Fprintf(b.h, "%d:%s", len(v), v)
+	}
+	return b
+}
+
+// File adds the given file path and its content to the key.
+func (b *KeyBuilder) File(path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	b.String(path, string(content))
+	return nil
+}
+
+// Key returns the computed key.
+func (b *KeyBuilder) Key() Key {
+	var k Key
+	b.h.Sum(k[:0])
+	return k
+}
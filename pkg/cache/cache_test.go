@@ -0,0 +1,99 @@
+package cache_test
+
+import (
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/godoc-lint/godoc-lint/pkg/cache"
+	"github.com/godoc-lint/godoc-lint/pkg/model"
+)
+
+func TestCache(t *testing.T) {
+	require := require.New(t)
+
+	c, err := cache.Open(filepath.Join(t.TempDir(), "cache"))
+	require.NoError(err)
+
+	key := cache.NewKeyBuilder().String("foo").Key()
+	_, ok := c.Get(key)
+	require.False(ok)
+
+	entry := &cache.Entry{
+		Packages: []string{"example.com/foo"},
+		Issues: []model.Issue{{
+			Rule:    model.MaxLenRule,
+			PkgPath: "example.com/foo",
+			Symbol:  "Foo",
+			Pos:     token.Position{Filename: "/foo/foo.go", Offset: 10, Line: 2, Column: 1},
+			End:     token.Position{Filename: "/foo/foo.go", Offset: 20, Line: 2, Column: 11},
+			Message: "too long",
+			Fixes: []model.IssueFix{{
+				Message: "fix it",
+				Edits:   []model.IssueTextEdit{{NewText: "bar"}},
+			}},
+		}},
+	}
+	require.NoError(c.Put(key, entry))
+
+	got, ok := c.Get(key)
+	require.True(ok)
+	require.Equal(entry, got)
+
+	other := cache.NewKeyBuilder().String("bar").Key()
+	_, ok = c.Get(other)
+	require.False(ok)
+
+	require.NoError(c.Clean())
+	_, ok = c.Get(key)
+	require.False(ok)
+
+	// The cache is still usable after cleaning.
+	require.NoError(c.Put(key, entry))
+	_, ok = c.Get(key)
+	require.True(ok)
+}
+
+func TestCacheCorruptedEntry(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	c, err := cache.Open(dir)
+	require.NoError(err)
+
+	key := cache.NewKeyBuilder().String("foo").Key()
+	require.NoError(c.Put(key, &cache.Entry{}))
+
+	s := key.String()
+	require.NoError(os.WriteFile(filepath.Join(dir, "entries", s[:2], s+".json"), []byte("{"), 0o644))
+	_, ok := c.Get(key)
+	require.False(ok)
+}
+
+func TestKeyBuilder(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "foo.go")
+	require.NoError(os.WriteFile(path, []byte("package foo\n"), 0o644))
+
+	key := func() cache.Key {
+		b := cache.NewKeyBuilder().String("a", "b")
+		require.NoError(b.File(path))
+		return b.Key()
+	}
+
+	k1 := key()
+	require.Equal(k1, key())
+
+	// Strings are length-prefixed, so the boundaries matter.
+	require.NotEqual(cache.NewKeyBuilder().String("a", "b").Key(), cache.NewKeyBuilder().String("ab").Key())
+
+	require.NoError(os.WriteFile(path, []byte("package foo // changed\n"), 0o644))
+	require.NotEqual(k1, key())
+
+	require.Error(cache.NewKeyBuilder().File(filepath.Join(dir, "missing.go")))
+}
@@ -6,6 +6,7 @@ import (
 	"go/ast"
 	"go/doc/comment"
 	"regexp"
+	"strings"
 
 	"github.com/godoc-lint/godoc-lint/pkg/model"
 	"github.com/godoc-lint/godoc-lint/pkg/util"
@@ -70,7 +71,7 @@ func checkDeprecations(actx *model.AnalysisContext, doc *model.CommentGroup) {
 		return
 	}
 
-	for _, block := range doc.Parsed.Content {
+	for _, block := range doc.Parsed().Content {
 		// The correct usage of deprecation markers is to put them at the beginning
 		// of a paragraph (i.e. not a heading, code block, etc). Also the syntax is
 		// strict and must only be "Deprecated: " (case-sensitive and with the
@@ -104,11 +105,14 @@ func checkDeprecations(actx *model.AnalysisContext, doc *model.CommentGroup) {
 			continue
 		}
 
-		if match := probableDeprecationRE.FindString(string(text)); match == "" || match == correctDeprecationMarker {
+		match := probableDeprecationRE.FindString(string(text))
+		if match == "" || match == correctDeprecationMarker {
 			continue
 		}
 
-		actx.Pass.ReportRangef(&doc.CG, "deprecation note should be formatted as %q", correctDeprecationMarker)
+		// The range covers the marker, up to (and including) the colon.
+		marker := match[:strings.IndexByte(match, ':')+1]
+		util.ReportRangef(actx.Pass, deprecatedRule, util.CommentLinePrefixRange(&doc.CG, marker), "deprecation note should be formatted as %q", correctDeprecationMarker)
 		break
 	}
 }
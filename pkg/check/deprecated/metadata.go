@@ -0,0 +1,24 @@
+package deprecated
+
+import "github.com/godoc-lint/godoc-lint/pkg/model"
+
+var ruleMetadata = []model.RuleMetadata{{
+	Rule:     deprecatedRule,
+	Category: model.RuleCategoryBasic,
+	Summary:  "Deprecation notes should be formatted as \"Deprecated: \".",
+	Description: `Checks if deprecation notes are formatted correctly. This rule only applies to
+exported symbols.`,
+	BadExample: `// Foo is a symbol.
+//
+// DEPRECATED: do not use
+const Foo = 0`,
+	GoodExample: `// Foo is a symbol.
+//
+// Deprecated: do not use
+const Foo = 0`,
+}}
+
+// ListRuleMetadata implements the corresponding interface method.
+func (r *DeprecatedChecker) ListRuleMetadata() []model.RuleMetadata {
+	return ruleMetadata
+}
@@ -34,9 +34,10 @@ func (r *MaxLenChecker) GetCoveredRules() model.RuleSet {
 
 // Apply implements the corresponding interface method.
 func (r *MaxLenChecker) Apply(actx *model.AnalysisContext) error {
-	includeTests := actx.Config.GetRuleOptions().MaxLenIncludeTests
-	maxLen := int(actx.Config.GetRuleOptions().MaxLenLength)
-	ignoreRegexps := actx.Config.GetRuleOptions().MaxLenIgnorePatterns
+	options := model.GetOptions[*Options](actx)
+	includeTests := options.IncludeTests
+	maxLen := int(options.Length)
+	ignoreRegexps := options.ignoreRegexps
 
 	docs := make(map[*model.CommentGroup]struct{}, 10*len(actx.InspectorResult.Files))
 
@@ -67,14 +68,14 @@ func checkMaxLen(actx *model.AnalysisContext, doc *model.CommentGroup, maxLen in
 		return
 	}
 
-	linkDefsMap := make(map[string]struct{}, len(doc.Parsed.Links))
-	for _, linkDef := range doc.Parsed.Links {
+	linkDefsMap := make(map[string]struct{}, len(doc.Parsed().Links))
+	for _, linkDef := range doc.Parsed().Links {
 		linkDefLine := fmt.Sprintf("[%s]: %s", linkDef.Text, linkDef.URL)
 		linkDefsMap[linkDefLine] = struct{}{}
 	}
 
-	nonCodeBlocks := make([]gdc.Block, 0, len(doc.Parsed.Content))
-	for _, b := range doc.Parsed.Content {
+	nonCodeBlocks := make([]gdc.Block, 0, len(doc.Parsed().Content))
+	for _, b := range doc.Parsed().Content {
 		if _, ok := b.(*gdc.Code); ok {
 			continue
 		}
@@ -137,7 +138,7 @@ func checkMaxLen(actx *model.AnalysisContext, doc *model.CommentGroup, maxLen in
 			rng = &doc.CG
 		}
 
-		actx.Pass.ReportRangef(rng, "godoc line is too long (%d > %d)", lineLen, maxLen)
+		util.ReportRangef(actx.Pass, maxLenRule, rng, "godoc line is too long (%d > %d)", lineLen, maxLen)
 	}
 }
 
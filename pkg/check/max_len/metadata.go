@@ -0,0 +1,41 @@
+package max_len
+
+import "github.com/godoc-lint/godoc-lint/pkg/model"
+
+var ruleMetadata = []model.RuleMetadata{{
+	Rule:     maxLenRule,
+	Category: model.RuleCategoryExtra,
+	Summary:  "Godoc lines should not exceed the maximum length.",
+	Description: `Limits maximum line length for godocs. The default length is 77 characters
+(not including the "// ", "/*", or "*/" tokens). The pre-formatted sections
+(e.g., codes), or link definitions are ignored.
+
+A long hyperlink in the godoc text can break this rule. In such cases, it is
+best to define the link at the end of the godoc and use the reference in the
+text.`,
+	BadExample: `// Foo has a super loooooooooooooooooooooooooooooooooooooooooooooooooooong godoc.
+const Foo = 0`,
+	GoodExample: `// Foo has a reasonably long godoc.
+const Foo = 0`,
+	Options: []model.RuleOptionMetadata{{
+		Name:        "max-len/length",
+		Type:        "uint",
+		Default:     "77",
+		Description: "Maximum line length of godocs.",
+	}, {
+		Name:        "max-len/include-tests",
+		Type:        "bool",
+		Default:     "false",
+		Description: "Include test files.",
+	}, {
+		Name:        "max-len/ignore-patterns",
+		Type:        "[]string",
+		Default:     "[]",
+		Description: "Regexp patterns of godoc lines to ignore.",
+	}},
+}}
+
+// ListRuleMetadata implements the corresponding interface method.
+func (r *MaxLenChecker) ListRuleMetadata() []model.RuleMetadata {
+	return ruleMetadata
+}
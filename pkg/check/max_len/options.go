@@ -0,0 +1,50 @@
+package max_len
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/godoc-lint/godoc-lint/pkg/model"
+)
+
+// Options holds the options of the checker.
+type Options struct {
+	// Length is the maximum line length of godocs.
+	Length uint `yaml:"max-len/length"`
+
+	// IncludeTests indicates whether test files should be checked.
+	IncludeTests bool `yaml:"max-len/include-tests"`
+
+	// IgnorePatterns holds the regexp patterns of godoc lines to ignore.
+	IgnorePatterns []string `yaml:"max-len/ignore-patterns"`
+
+	ignoreRegexps []*regexp.Regexp
+}
+
+// Validate implements the corresponding interface method. It also compiles the
+// ignore patterns.
+func (o *Options) Validate() error {
+	var invalids []string
+	regexps := make([]*regexp.Regexp, 0, len(o.IgnorePatterns))
+	for _, p := range o.IgnorePatterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			invalids = append(invalids, p)
+			continue
+		}
+		regexps = append(regexps, re)
+	}
+	if len(invalids) > 0 {
+		return fmt.Errorf("invalid max-len ignore pattern(s): %q", invalids)
+	}
+	o.ignoreRegexps = regexps
+	return nil
+}
+
+// NewOptions implements the corresponding interface method.
+func (r *MaxLenChecker) NewOptions() model.CheckerOptions {
+	return &Options{
+		Length:         77,
+		IgnorePatterns: []string{},
+	}
+}
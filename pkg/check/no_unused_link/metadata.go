@@ -0,0 +1,29 @@
+package no_unused_link
+
+import "github.com/godoc-lint/godoc-lint/pkg/model"
+
+var ruleMetadata = []model.RuleMetadata{{
+	Rule:        noUnusedLinkRule,
+	Category:    model.RuleCategoryExtra,
+	Summary:     "Godoc link definitions should be used in the text.",
+	Description: `Checks for unused link definitions in the godoc text.`,
+	BadExample: `// Foo godoc has an unused link.
+//
+// [link]: https://foo.com/docs
+const Foo = 0`,
+	GoodExample: `// Foo godoc uses a defined [link].
+//
+// [link]: https://foo.com/docs
+const Foo = 0`,
+	Options: []model.RuleOptionMetadata{{
+		Name:        "no-unused-link/include-tests",
+		Type:        "bool",
+		Default:     "false",
+		Description: "Include test files.",
+	}},
+}}
+
+// ListRuleMetadata implements the corresponding interface method.
+func (r *NoUnusedLinkChecker) ListRuleMetadata() []model.RuleMetadata {
+	return ruleMetadata
+}
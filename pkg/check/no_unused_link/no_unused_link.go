@@ -2,6 +2,12 @@
 package no_unused_link
 
 import (
+	"go/ast"
+	"go/token"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+
 	"github.com/godoc-lint/godoc-lint/pkg/model"
 	"github.com/godoc-lint/godoc-lint/pkg/util"
 )
@@ -25,7 +31,7 @@ func (r *NoUnusedLinkChecker) GetCoveredRules() model.RuleSet {
 
 // Apply implements the corresponding interface method.
 func (r *NoUnusedLinkChecker) Apply(actx *model.AnalysisContext) error {
-	includeTests := actx.Config.GetRuleOptions().NoUnusedLinkIncludeTests
+	includeTests := model.GetOptions[*Options](actx).IncludeTests
 
 	docs := make(map[*model.CommentGroup]struct{}, 10*len(actx.InspectorResult.Files))
 
@@ -60,10 +66,21 @@ func checkNoUnusedLink(actx *model.AnalysisContext, doc *model.CommentGroup) {
 		return
 	}
 
-	for _, linkDef := range doc.Parsed.Links {
+	for _, linkDef := range doc.Parsed().Links {
 		if linkDef.Used {
 			continue
 		}
-		actx.Pass.ReportRangef(&doc.CG, "godoc has unused link (%q)", linkDef.Text)
+		util.ReportRangef(actx.Pass, noUnusedLinkRule, linkDefRange(&doc.CG, linkDef.Text), "godoc has unused link (%q)", linkDef.Text)
+	}
+}
+
+// linkDefRange returns the range of the definition line (e.g., "[foo]: url") of
+// the link with the given text, or the range of the comment group if not found.
+func linkDefRange(cg *ast.CommentGroup, text string) analysis.Range {
+	line, pos, ok := util.FindCommentLinePrefix(cg, "["+text+"]:")
+	if !ok {
+		return cg
 	}
+	trimmed := strings.TrimSpace(line.Text)
+	return util.NewRange(pos, pos+token.Pos(len(trimmed)))
 }
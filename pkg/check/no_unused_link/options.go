@@ -0,0 +1,19 @@
+package no_unused_link
+
+import "github.com/godoc-lint/godoc-lint/pkg/model"
+
+// Options holds the options of the checker.
+type Options struct {
+	// IncludeTests indicates whether test files should be checked.
+	IncludeTests bool `yaml:"no-unused-link/include-tests"`
+}
+
+// Validate implements the corresponding interface method.
+func (o *Options) Validate() error {
+	return nil
+}
+
+// NewOptions implements the corresponding interface method.
+func (r *NoUnusedLinkChecker) NewOptions() model.CheckerOptions {
+	return &Options{}
+}
@@ -0,0 +1,75 @@
+package pkg_doc
+
+import "github.com/godoc-lint/godoc-lint/pkg/model"
+
+var ruleMetadata = []model.RuleMetadata{{
+	Rule:     pkgDocRule,
+	Category: model.RuleCategoryBasic,
+	Summary:  "Package godocs should start with \"Package <NAME>\".",
+	Description: `Ensures all package godocs start with "Package <NAME>".
+
+As of Go Doc Comments, command packages (i.e., packages named "main") are
+exceptions to this rule. So, they and their test packages (i.e., "main_test")
+are ignored.`,
+	BadExample: `// This is an example package.
+package foo`,
+	GoodExample: `// Package foo is an example.
+package foo`,
+	Options: []model.RuleOptionMetadata{{
+		Name:        "pkg-doc/include-tests",
+		Type:        "bool",
+		Default:     "false",
+		Description: "Include test files.",
+	}},
+}, {
+	Rule:     singlePkgDocRule,
+	Category: model.RuleCategoryBasic,
+	Summary:  "Packages should have at most one godoc.",
+	Description: `Technically, every Go file in a package can have a godoc above the "package"
+statement. This rule enforces only one godoc, if any, for any package.`,
+	BadExample: `// a.go
+
+// Package foo is an example.
+package foo
+
+// b.go
+
+// Package foo is an example, again.
+package foo`,
+	GoodExample: `// a.go
+
+// Package foo is an example.
+package foo
+
+// b.go
+
+package foo`,
+	Options: []model.RuleOptionMetadata{{
+		Name:        "single-pkg-doc/include-tests",
+		Type:        "bool",
+		Default:     "false",
+		Description: "Include test files.",
+	}},
+	PackageWide: true,
+}, {
+	Rule:     requirePkgDocRule,
+	Category: model.RuleCategoryStrict,
+	Summary:  "Packages should have a godoc.",
+	Description: `Ensures that every Go package has godoc(s). By default, test files (i.e.,
+"*_test.go") and therefore test packages (i.e., "*_test") are ignored.`,
+	BadExample: `package foo`,
+	GoodExample: `// Package foo is an example.
+package foo`,
+	Options: []model.RuleOptionMetadata{{
+		Name:        "require-pkg-doc/include-tests",
+		Type:        "bool",
+		Default:     "false",
+		Description: "Include test files.",
+	}},
+	PackageWide: true,
+}}
+
+// ListRuleMetadata implements the corresponding interface method.
+func (r *PkgDocChecker) ListRuleMetadata() []model.RuleMetadata {
+	return ruleMetadata
+}
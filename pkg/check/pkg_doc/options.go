@@ -0,0 +1,28 @@
+package pkg_doc
+
+import "github.com/godoc-lint/godoc-lint/pkg/model"
+
+// Options holds the options of the checker.
+type Options struct {
+	// PkgDocIncludeTests indicates whether test files should be checked for
+	// the pkg-doc rule.
+	PkgDocIncludeTests bool `yaml:"pkg-doc/include-tests"`
+
+	// SinglePkgDocIncludeTests indicates whether test files should be checked
+	// for the single-pkg-doc rule.
+	SinglePkgDocIncludeTests bool `yaml:"single-pkg-doc/include-tests"`
+
+	// RequirePkgDocIncludeTests indicates whether test files should be checked
+	// for the require-pkg-doc rule.
+	RequirePkgDocIncludeTests bool `yaml:"require-pkg-doc/include-tests"`
+}
+
+// Validate implements the corresponding interface method.
+func (o *Options) Validate() error {
+	return nil
+}
+
+// NewOptions implements the corresponding interface method.
+func (r *PkgDocChecker) NewOptions() model.CheckerOptions {
+	return &Options{}
+}
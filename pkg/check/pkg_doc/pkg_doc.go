@@ -5,6 +5,8 @@ import (
 	"go/ast"
 	"strings"
 
+	"golang.org/x/tools/go/analysis"
+
 	"github.com/godoc-lint/godoc-lint/pkg/check/shared"
 	"github.com/godoc-lint/godoc-lint/pkg/model"
 	"github.com/godoc-lint/godoc-lint/pkg/util"
@@ -53,7 +55,7 @@ func checkPkgDocRule(actx *model.AnalysisContext) {
 		return
 	}
 
-	includeTests := actx.Config.GetRuleOptions().PkgDocIncludeTests
+	includeTests := model.GetOptions[*Options](actx).PkgDocIncludeTests
 
 	for f, ir := range util.AnalysisApplicableFiles(actx, includeTests, model.RuleSet{}.Add(pkgDocRule)) {
 		if ir.PackageDoc == nil {
@@ -78,7 +80,7 @@ func checkPkgDocRule(actx *model.AnalysisContext) {
 			continue
 		}
 
-		if shared.HasDeprecatedParagraph(ir.PackageDoc.Parsed.Content) {
+		if shared.HasDeprecatedParagraph(ir.PackageDoc.Parsed().Content) {
 			// If there's a paragraph starting with "Deprecated:", we skip the
 			// entire godoc. The reason is a deprecated symbol will not appear
 			// when docs are rendered.
@@ -112,7 +114,7 @@ func checkPkgDocRule(actx *model.AnalysisContext) {
 		}
 
 		if expectedPrefix, ok := checkPkgDocPrefix(ir.PackageDoc.Text, f.Name.Name); !ok {
-			actx.Pass.Reportf(ir.PackageDoc.CG.Pos(), "package godoc should start with %q", expectedPrefix+" ")
+			util.Reportf(actx.Pass, pkgDocRule, ir.PackageDoc.CG.Pos(), "package godoc should start with %q", expectedPrefix+" ")
 		}
 	}
 }
@@ -131,7 +133,7 @@ func checkSinglePkgDocRule(actx *model.AnalysisContext) {
 		return
 	}
 
-	includeTests := actx.Config.GetRuleOptions().SinglePkgDocIncludeTests
+	includeTests := model.GetOptions[*Options](actx).SinglePkgDocIncludeTests
 
 	documentedPkgs := make(map[string][]*ast.File, 2)
 
@@ -157,7 +159,21 @@ func checkSinglePkgDocRule(actx *model.AnalysisContext) {
 		}
 		for _, f := range fs {
 			ir := actx.InspectorResult.Files[f]
-			actx.Pass.Reportf(ir.PackageDoc.CG.Pos(), "package has more than one godoc (%q)", pkg)
+
+			related := make([]analysis.RelatedInformation, 0, len(fs)-1)
+			for _, other := range fs {
+				if other == f {
+					continue
+				}
+				otherCG := &actx.InspectorResult.Files[other].PackageDoc.CG
+				related = append(related, analysis.RelatedInformation{
+					Pos:     otherCG.Pos(),
+					End:     otherCG.End(),
+					Message: "other package godoc",
+				})
+			}
+
+			util.ReportRelatedRangef(actx.Pass, singlePkgDocRule, &ir.PackageDoc.CG, related, "package has more than one godoc (%q)", pkg)
 		}
 	}
 }
@@ -167,7 +183,7 @@ func checkRequirePkgDocRule(actx *model.AnalysisContext) {
 		return
 	}
 
-	includeTests := actx.Config.GetRuleOptions().RequirePkgDocIncludeTests
+	includeTests := model.GetOptions[*Options](actx).RequirePkgDocIncludeTests
 
 	pkgFiles := make(map[string][]*ast.File, 2)
 
@@ -201,6 +217,6 @@ func checkRequirePkgDocRule(actx *model.AnalysisContext) {
 		}
 
 		// Add a diagnostic message to the first file of the package.
-		actx.Pass.Reportf(fs[0].Name.Pos(), "package should have a godoc (%q)", pkg)
+		util.Reportf(actx.Pass, requirePkgDocRule, fs[0].Name.Pos(), "package should have a godoc (%q)", pkg)
 	}
 }
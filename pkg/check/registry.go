@@ -16,12 +16,14 @@ import (
 type Registry struct {
 	checkers     map[model.Checker]struct{}
 	coveredRules model.RuleSet
+	metadata     map[model.Rule]model.RuleMetadata
 }
 
 // NewRegistry returns a new rule registry instance.
 func NewRegistry(checkers ...model.Checker) *Registry {
 	registry := Registry{
 		checkers: make(map[model.Checker]struct{}, len(checkers)+10),
+		metadata: make(map[model.Rule]model.RuleMetadata, len(checkers)+10),
 	}
 	for _, c := range checkers {
 		registry.Add(c)
@@ -47,7 +49,21 @@ func (r *Registry) Add(checker model.Checker) {
 	if _, ok := r.checkers[checker]; ok {
 		return
 	}
-	r.coveredRules = r.coveredRules.Merge(checker.GetCoveredRules())
+	covered := checker.GetCoveredRules()
+	r.coveredRules = r.coveredRules.Merge(covered)
+	if p, ok := checker.(model.RuleMetadataProvider); ok {
+		for _, m := range p.ListRuleMetadata() {
+			r.metadata[m.Rule] = m
+		}
+	}
+	for _, rule := range covered.List() {
+		if _, ok := r.metadata[rule]; !ok {
+			r.metadata[rule] = model.RuleMetadata{
+				Rule:     rule,
+				Category: model.RuleCategoryExtra,
+			}
+		}
+	}
 	r.checkers[checker] = struct{}{}
 }
 
@@ -64,3 +80,19 @@ func (r *Registry) List() []model.Checker {
 func (r *Registry) GetCoveredRules() model.RuleSet {
 	return r.coveredRules
 }
+
+// GetRuleMetadata implements the corresponding interface method.
+func (r *Registry) GetRuleMetadata(rule model.Rule) (model.RuleMetadata, bool) {
+	m, ok := r.metadata[rule]
+	return m, ok
+}
+
+// ListRuleMetadata implements the corresponding interface method.
+func (r *Registry) ListRuleMetadata() []model.RuleMetadata {
+	all := make([]model.RuleMetadata, 0, len(r.metadata))
+	for _, m := range r.metadata {
+		all = append(all, m)
+	}
+	model.SortRuleMetadata(all)
+	return all
+}
@@ -14,3 +14,54 @@ func TestPopulatedRegistryHasAllRules(t *testing.T) {
 	assert.True(t, model.AllRules.IsSupersetOf(rules), "rule not defined in model")
 	assert.True(t, rules.IsSupersetOf(model.AllRules), "checker for rule is not registered")
 }
+
+func TestPopulatedRegistryHasAllRuleMetadata(t *testing.T) {
+	registry := check.NewPopulatedRegistry()
+
+	rules := model.RuleSet{}
+	for _, m := range registry.ListRuleMetadata() {
+		assert.NotEmpty(t, m.Summary, "rule summary is empty: %q", m.Rule)
+		assert.NotEmpty(t, m.Description, "rule description is empty: %q", m.Rule)
+		assert.NotEmpty(t, m.Category, "rule category is empty: %q", m.Rule)
+		assert.NotEmpty(t, m.Presets(), "rule is not included in any preset: %q", m.Rule)
+		for _, o := range m.Options {
+			assert.Regexp(t, "^"+string(m.Rule)+"/", o.Name, "rule option is not prefixed with rule name")
+		}
+
+		got, ok := registry.GetRuleMetadata(m.Rule)
+		assert.True(t, ok)
+		assert.Equal(t, m, got)
+
+		rules = rules.Add(m.Rule)
+	}
+	assert.Equal(t, registry.GetCoveredRules(), rules, "rule metadata does not match covered rules")
+}
+
+func TestPopulatedRegistryDefaultSets(t *testing.T) {
+	metadata := check.NewPopulatedRegistry().ListRuleMetadata()
+	for _, set := range model.DefaultSetValues {
+		assert.Equal(t, model.DefaultSetToRules[set], model.GetDefaultSetRules(set, metadata), "rules of default set %q do not match", set)
+	}
+}
+
+// bareChecker is a checker that does not provide rule metadata.
+type bareChecker struct{}
+
+func (c *bareChecker) GetCoveredRules() model.RuleSet {
+	return model.RuleSet{}.Add("bare")
+}
+
+func (c *bareChecker) Apply(actx *model.AnalysisContext) error {
+	return nil
+}
+
+func TestRegistryRuleMetadataFallback(t *testing.T) {
+	registry := check.NewRegistry(&bareChecker{})
+
+	m, ok := registry.GetRuleMetadata("bare")
+	assert.True(t, ok)
+	assert.Equal(t, model.RuleMetadata{Rule: "bare", Category: model.RuleCategoryExtra}, m)
+	assert.Equal(t, []model.RuleMetadata{m}, registry.ListRuleMetadata())
+	assert.Equal(t, model.RuleSet{}.Add("bare"), model.GetDefaultSetRules(model.DefaultSetAll, registry.ListRuleMetadata()))
+	assert.Empty(t, model.GetDefaultSetRules(model.DefaultSetBasic, registry.ListRuleMetadata()).List())
+}
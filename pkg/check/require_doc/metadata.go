@@ -0,0 +1,36 @@
+package require_doc
+
+import "github.com/godoc-lint/godoc-lint/pkg/model"
+
+var ruleMetadata = []model.RuleMetadata{{
+	Rule:     requireDocRule,
+	Category: model.RuleCategoryStrict,
+	Summary:  "Symbols should have godocs.",
+	Description: `Ensures all exported and/or (optionally) unexported symbols have godocs. By
+default, symbols declared in test files, together with any unexported symbols
+are ignored.`,
+	BadExample: `const Foo = 0`,
+	GoodExample: `// Foo is a constant.
+const Foo = 0`,
+	Options: []model.RuleOptionMetadata{{
+		Name:        "require-doc/include-tests",
+		Type:        "bool",
+		Default:     "false",
+		Description: "Include test files.",
+	}, {
+		Name:        "require-doc/ignore-exported",
+		Type:        "bool",
+		Default:     "false",
+		Description: "Ignore exported symbols.",
+	}, {
+		Name:        "require-doc/ignore-unexported",
+		Type:        "bool",
+		Default:     "true",
+		Description: "Ignore unexported symbols.",
+	}},
+}}
+
+// ListRuleMetadata implements the corresponding interface method.
+func (r *RequireDocChecker) ListRuleMetadata() []model.RuleMetadata {
+	return ruleMetadata
+}
@@ -0,0 +1,27 @@
+package require_doc
+
+import "github.com/godoc-lint/godoc-lint/pkg/model"
+
+// Options holds the options of the checker.
+type Options struct {
+	// IncludeTests indicates whether test files should be checked.
+	IncludeTests bool `yaml:"require-doc/include-tests"`
+
+	// IgnoreExported indicates whether exported symbols should be ignored.
+	IgnoreExported bool `yaml:"require-doc/ignore-exported"`
+
+	// IgnoreUnexported indicates whether unexported symbols should be ignored.
+	IgnoreUnexported bool `yaml:"require-doc/ignore-unexported"`
+}
+
+// Validate implements the corresponding interface method.
+func (o *Options) Validate() error {
+	return nil
+}
+
+// NewOptions implements the corresponding interface method.
+func (r *RequireDocChecker) NewOptions() model.CheckerOptions {
+	return &Options{
+		IgnoreUnexported: true,
+	}
+}
@@ -29,9 +29,10 @@ func (r *RequireDocChecker) GetCoveredRules() model.RuleSet {
 
 // Apply implements the corresponding interface method.
 func (r *RequireDocChecker) Apply(actx *model.AnalysisContext) error {
-	includeTests := actx.Config.GetRuleOptions().RequireDocIncludeTests
-	requirePublic := !actx.Config.GetRuleOptions().RequireDocIgnoreExported
-	requirePrivate := !actx.Config.GetRuleOptions().RequireDocIgnoreUnexported
+	options := model.GetOptions[*Options](actx)
+	includeTests := options.IncludeTests
+	requirePublic := !options.IgnoreExported
+	requirePrivate := !options.IgnoreUnexported
 
 	if !requirePublic && !requirePrivate {
 		return nil
@@ -175,5 +176,5 @@ func (r *RequireDocChecker) Apply(actx *model.AnalysisContext) error {
 }
 
 func reportRange(pass *analysis.Pass, ident *ast.Ident) {
-	pass.ReportRangef(ident, "symbol should have a godoc (%q)", ident.Name)
+	util.ReportRangef(pass, requireDocRule, ident, "symbol should have a godoc (%q)", ident.Name)
 }
@@ -0,0 +1,33 @@
+package start_with_name
+
+import "github.com/godoc-lint/godoc-lint/pkg/model"
+
+var ruleMetadata = []model.RuleMetadata{{
+	Rule:     startWithNameRule,
+	Category: model.RuleCategoryBasic,
+	Summary:  "Symbol godocs should start with the symbol name.",
+	Description: `Checks godocs start with the corresponding symbol name. It allows English
+articles (i.e., "a", "an", and "the") at the beginning of godocs.
+
+By default, unexported symbols and test files are skipped.`,
+	BadExample: `// This is a constant.
+const Foo = 0`,
+	GoodExample: `// Foo is a constant.
+const Foo = 0`,
+	Options: []model.RuleOptionMetadata{{
+		Name:        "start-with-name/include-tests",
+		Type:        "bool",
+		Default:     "false",
+		Description: "Include test files.",
+	}, {
+		Name:        "start-with-name/include-unexported",
+		Type:        "bool",
+		Default:     "false",
+		Description: "Include unexported symbols.",
+	}},
+}}
+
+// ListRuleMetadata implements the corresponding interface method.
+func (r *StartWithNameChecker) ListRuleMetadata() []model.RuleMetadata {
+	return ruleMetadata
+}
@@ -0,0 +1,22 @@
+package start_with_name
+
+import "github.com/godoc-lint/godoc-lint/pkg/model"
+
+// Options holds the options of the checker.
+type Options struct {
+	// IncludeTests indicates whether test files should be checked.
+	IncludeTests bool `yaml:"start-with-name/include-tests"`
+
+	// IncludeUnexported indicates whether unexported symbols should be checked.
+	IncludeUnexported bool `yaml:"start-with-name/include-unexported"`
+}
+
+// Validate implements the corresponding interface method.
+func (o *Options) Validate() error {
+	return nil
+}
+
+// NewOptions implements the corresponding interface method.
+func (r *StartWithNameChecker) NewOptions() model.CheckerOptions {
+	return &Options{}
+}
@@ -3,10 +3,14 @@
 package start_with_name
 
 import (
+	"fmt"
 	"go/ast"
+	"go/token"
 	"regexp"
 	"strings"
 
+	"golang.org/x/tools/go/analysis"
+
 	"github.com/godoc-lint/godoc-lint/pkg/check/shared"
 	"github.com/godoc-lint/godoc-lint/pkg/model"
 	"github.com/godoc-lint/godoc-lint/pkg/util"
@@ -35,8 +39,9 @@ func (r *StartWithNameChecker) Apply(actx *model.AnalysisContext) error {
 		return nil
 	}
 
-	includeTests := actx.Config.GetRuleOptions().StartWithNameIncludeTests
-	includePrivate := actx.Config.GetRuleOptions().StartWithNameIncludeUnexported
+	options := model.GetOptions[*Options](actx)
+	includeTests := options.IncludeTests
+	includePrivate := options.IncludeUnexported
 
 	for _, ir := range util.AnalysisApplicableFiles(actx, includeTests, model.RuleSet{}.Add(startWithNameRule)) {
 		for _, decl := range ir.SymbolDecl {
@@ -74,7 +79,7 @@ func (r *StartWithNameChecker) Apply(actx *model.AnalysisContext) error {
 				continue
 			}
 
-			if shared.HasDeprecatedParagraph(decl.Doc.Parsed.Content) {
+			if shared.HasDeprecatedParagraph(decl.Doc.Parsed().Content) {
 				// If there's a paragraph starting with "Deprecated:", we skip the
 				// entire godoc. The reason is a deprecated symbol will not appear
 				// when docs are rendered.
@@ -111,12 +116,32 @@ func (r *StartWithNameChecker) Apply(actx *model.AnalysisContext) error {
 				continue
 			}
 
-			actx.Pass.ReportRangef(&decl.Doc.CG, "godoc should start with symbol name (%q)", decl.Name)
+			var firstWord string
+			if fields := strings.Fields(strings.SplitN(decl.Doc.Text, "\n", 2)[0]); len(fields) > 0 {
+				firstWord = fields[0]
+			}
+			var related []analysis.RelatedInformation
+			if !isRightAfter(actx.Pass.Fset, &decl.Doc.CG, decl.Ident) {
+				// The declaration is only pointed out when it is not right after
+				// the godoc (e.g., with a multi-line method receiver).
+				related = append(related, analysis.RelatedInformation{
+					Pos:     decl.Ident.Pos(),
+					End:     decl.Ident.End(),
+					Message: fmt.Sprintf("symbol %q is declared here", decl.Name),
+				})
+			}
+			util.ReportRelatedRangef(actx.Pass, startWithNameRule, util.CommentLinePrefixRange(&decl.Doc.CG, firstWord), related, "godoc should start with symbol name (%q)", decl.Name)
 		}
 	}
 	return nil
 }
 
+// isRightAfter determines whether the given identifier is on the line right
+// after the given comment group.
+func isRightAfter(fset *token.FileSet, cg *ast.CommentGroup, ident *ast.Ident) bool {
+	return fset.Position(ident.Pos()).Line == fset.Position(cg.End()).Line+1
+}
+
 var (
 	startPattern                = regexp.MustCompile(`^(?:(A|a|AN|An|an|THE|The|the) )?(?P<symbol_name>.+?)\b`)
 	startPatternSymbolNameIndex = startPattern.SubexpIndex("symbol_name")
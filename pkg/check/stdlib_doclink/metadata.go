@@ -0,0 +1,33 @@
+package stdlib_doclink
+
+import "github.com/godoc-lint/godoc-lint/pkg/model"
+
+var ruleMetadata = []model.RuleMetadata{{
+	Rule:     RequireStdlibDoclinkRule,
+	Category: model.RuleCategoryExtra,
+	Summary:  "Mentions of stdlib symbols should be doc links.",
+	Description: `Suggests turning plain-text mentions of standard-library identifiers into doc
+links, when possible. For example, the text "encoding/json.Encoder" in a godoc
+can be turned into a doc link like "[encoding/json.Encoder]" so that it links
+to the corresponding stdlib symbol on pkg.go.dev.
+
+To avoid false positives, the linter only detects potential doc links of
+longer forms like "pkg.name" or "pkg.recv.name".`,
+	BadExample: `// Println is the same as fmt.Println.
+func Println(a ...any) (n int, err error) {}`,
+	GoodExample: `// Println is the same as [fmt.Println].
+func Println(a ...any) (n int, err error) {}`,
+	Options: []model.RuleOptionMetadata{{
+		Name:        "require-stdlib-doclink/include-tests",
+		Type:        "bool",
+		Default:     "false",
+		Description: "Include test files.",
+	}},
+	// Mentions are resolved against the imports of all package files.
+	PackageWide: true,
+}}
+
+// ListRuleMetadata implements the corresponding interface method.
+func (r *StdlibDoclinkChecker) ListRuleMetadata() []model.RuleMetadata {
+	return ruleMetadata
+}
@@ -0,0 +1,19 @@
+package stdlib_doclink
+
+import "github.com/godoc-lint/godoc-lint/pkg/model"
+
+// Options holds the options of the checker.
+type Options struct {
+	// IncludeTests indicates whether test files should be checked.
+	IncludeTests bool `yaml:"require-stdlib-doclink/include-tests"`
+}
+
+// Validate implements the corresponding interface method.
+func (o *Options) Validate() error {
+	return nil
+}
+
+// NewOptions implements the corresponding interface method.
+func (r *StdlibDoclinkChecker) NewOptions() model.CheckerOptions {
+	return &Options{}
+}
@@ -4,13 +4,17 @@ package stdlib_doclink
 
 import (
 	"fmt"
+	"go/ast"
 	gdc "go/doc/comment"
+	"go/token"
 	"maps"
 	"regexp"
 	"slices"
 	"strconv"
 	"strings"
 
+	"golang.org/x/tools/go/analysis"
+
 	"github.com/godoc-lint/godoc-lint/pkg/check/stdlib_doclink/internal"
 	"github.com/godoc-lint/godoc-lint/pkg/model"
 	"github.com/godoc-lint/godoc-lint/pkg/util"
@@ -36,7 +40,7 @@ func (r *StdlibDoclinkChecker) GetCoveredRules() model.RuleSet {
 
 // Apply implements the corresponding interface method.
 func (r *StdlibDoclinkChecker) Apply(actx *model.AnalysisContext) error {
-	includeTests := actx.Config.GetRuleOptions().RequireStdlibDoclinkIncludeTests
+	includeTests := model.GetOptions[*Options](actx).IncludeTests
 
 	docs := make(map[*model.CommentGroup]struct{}, 10*len(actx.InspectorResult.Files))
 
@@ -134,8 +138,8 @@ func checkStdlibDoclink(
 		return
 	}
 
-	applicableBlocks := make([]gdc.Block, 0, len(doc.Parsed.Content))
-	for _, b := range doc.Parsed.Content {
+	applicableBlocks := make([]gdc.Block, 0, len(doc.Parsed().Content))
+	for _, b := range doc.Parsed().Content {
 		if _, ok := b.(*gdc.Code); ok {
 			continue
 		}
@@ -155,14 +159,78 @@ func checkStdlibDoclink(
 		return
 	}
 
+	lines := applicableLines(&doc.CG, doc.Parsed())
 	for _, pd := range pds {
-		var count string
-		if pd.count > 1 {
-			count = fmt.Sprintf(" (%d instances)", pd.count)
+		ranges := findOccurrences(lines, pd.originalNoStar)
+		if len(ranges) != pd.count {
+			// The occurrences in the raw comment lines do not match those in
+			// the parsed godoc (e.g., due to some markup quirks), so we cannot
+			// point at them precisely.
+			var count string
+			if pd.count > 1 {
+				count = fmt.Sprintf(" (%d instances)", pd.count)
+			}
+			util.ReportRangef(actx.Pass, RequireStdlibDoclinkRule, &doc.CG, "text %q should be replaced with %q to link to stdlib %s%s", pd.originalNoStar, pd.doclink, kindTitle(pd.kind), count)
+			continue
+		}
+
+		for _, rng := range ranges {
+			util.ReportRangef(actx.Pass, RequireStdlibDoclinkRule, rng, "text %q should be replaced with %q to link to stdlib %s", pd.originalNoStar, pd.doclink, kindTitle(pd.kind))
+		}
+	}
+}
+
+// applicableLines returns the lines of the given comment group, excluding those
+// of code blocks and headings, where doc links are not picked up.
+func applicableLines(cg *ast.CommentGroup, parsed *gdc.Doc) []util.CommentLine {
+	excluded := make(map[string]struct{})
+	for _, b := range parsed.Content {
+		code, ok := b.(*gdc.Code)
+		if !ok {
+			continue
+		}
+		for line := range strings.SplitSeq(code.Text, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				excluded[line] = struct{}{}
+			}
+		}
+	}
+
+	lines := util.CommentLines(cg)
+	result := make([]util.CommentLine, 0, len(lines))
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line.Text)
+		if _, ok := excluded[trimmed]; ok {
+			continue
 		}
+		if strings.HasPrefix(trimmed, "# ") {
+			continue
+		}
+		result = append(result, line)
+	}
+	return result
+}
 
-		actx.Pass.ReportRangef(&doc.CG, "text %q should be replaced with %q to link to stdlib %s%s", pd.originalNoStar, pd.doclink, kindTitle(pd.kind), count)
+// findOccurrences returns the ranges of the occurrences of the given potential
+// doc link text (e.g., "json.Encoder") in the given lines.
+func findOccurrences(lines []util.CommentLine, originalNoStar string) []analysis.Range {
+	var result []analysis.Range
+	for _, line := range lines {
+		if !strings.Contains(line.Text, originalNoStar) {
+			continue
+		}
+		for _, m := range potentialDoclinkRE.FindAllStringSubmatchIndex(line.Text, -1) {
+			start, end := m[4], m[7]
+			if m[8] >= 0 {
+				end = m[9]
+			}
+			if line.Text[start:end] != originalNoStar {
+				continue
+			}
+			result = append(result, util.NewRange(line.Pos+token.Pos(start), line.Pos+token.Pos(end)))
+		}
 	}
+	return result
 }
 
 func kindTitle(kind internal.SymbolKind) string {
@@ -21,7 +21,14 @@ type Composition struct {
 
 // CompositionConfig holds the configuration for composing the linter.
 type CompositionConfig struct {
-	BaseDir  string
+	BaseDir string
+
+	// ExitFunc, if set, is called with exit code 2 and the error, when the
+	// analyzer fails due to the configuration (i.e., a [model.ConfigError]).
+	//
+	// This is meant for drivers that do not tell the config errors apart from
+	// other errors (e.g., singlechecker). Other hosts can leave it unset, and
+	// detect the config errors returned by the analyzer via [errors.As].
 	ExitFunc func(int, error)
 
 	// BaseDirPlainConfig holds the plain configuration for the base directory.
@@ -29,20 +36,46 @@ type CompositionConfig struct {
 	// This is meant to be used for integrating with umbrella linters (e.g.
 	// golangci-lint) where the root config comes from a different source/format.
 	BaseDirPlainConfig *config.PlainConfig
+
+	// Checkers holds the custom checkers to register, in addition to the
+	// built-in ones. Their rules can be used in the configuration and disable
+	// directives, just like the built-in rules.
+	//
+	// The rules of custom checkers should not be covered by any other checker.
+	Checkers []model.Checker
+
+	// Hermetic enables the hermetic mode, where the configuration is solely
+	// built from BaseDirPlainConfig and the override, without looking up any
+	// config file, and path patterns are matched against package import paths
+	// (See [config.ConfigBuilder.WithHermetic]). In this mode, BaseDir is not
+	// defaulted to the current working directory, and if empty, packages are
+	// not restricted to it.
+	//
+	// This is meant to be used in sandboxed build systems (e.g., Bazel's nogo).
+	Hermetic bool
 }
 
 // Compose composes the linter components based on the given configuration.
 func Compose(c CompositionConfig) *Composition {
-	if c.BaseDir == "" {
+	if c.BaseDir == "" && !c.Hermetic {
 		// It's a best effort to use the current working directory if not set.
 		c.BaseDir, _ = os.Getwd()
 	}
 
 	reg := check.NewPopulatedRegistry()
-	cb := config.NewConfigBuilder(c.BaseDir).WithBaseDirPlainConfig(c.BaseDirPlainConfig)
+	for _, checker := range c.Checkers {
+		reg.Add(checker)
+	}
+	cb := config.NewConfigBuilder(c.BaseDir).WithBaseDirPlainConfig(c.BaseDirPlainConfig).WithRegistry(reg)
+	if c.Hermetic {
+		cb = cb.WithHermetic()
+	}
 	ocb := config.NewOnceConfigBuilder(cb)
-	inspector := inspect.NewInspector(ocb, c.ExitFunc)
-	analyzer := analysis.NewAnalyzer(c.BaseDir, ocb, reg, inspector, c.ExitFunc)
+	inspector := inspect.NewInspector(ocb).WithRegistry(reg)
+	analyzer := analysis.NewAnalyzer(c.BaseDir, ocb, reg, inspector)
+	if c.ExitFunc != nil {
+		analyzer = analyzer.WithExitFunc(c.ExitFunc)
+	}
 
 	return &Composition{
 		Registry:      reg,
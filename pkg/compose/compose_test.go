@@ -0,0 +1,105 @@
+package compose_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/godoc-lint/godoc-lint/pkg/compose"
+	"github.com/godoc-lint/godoc-lint/pkg/model"
+	"github.com/godoc-lint/godoc-lint/pkg/util"
+)
+
+const noTodoRule model.Rule = "no-todo"
+
+// noTodoChecker is a custom checker that reports godocs containing "TODO".
+type noTodoChecker struct{}
+
+func (c *noTodoChecker) GetCoveredRules() model.RuleSet {
+	return model.RuleSet{}.Add(noTodoRule)
+}
+
+func (c *noTodoChecker) ListRuleMetadata() []model.RuleMetadata {
+	return []model.RuleMetadata{{
+		Rule:        noTodoRule,
+		Category:    model.RuleCategoryExtra,
+		Summary:     "Godocs should not contain TODO notes.",
+		Description: "Godocs should not contain TODO notes.",
+	}}
+}
+
+func (c *noTodoChecker) Apply(actx *model.AnalysisContext) error {
+	for _, ir := range util.AnalysisApplicableFiles(actx, false, c.GetCoveredRules()) {
+		for _, sd := range ir.SymbolDecl {
+			if sd.Doc == nil || sd.Doc.DisabledRules.All || sd.Doc.DisabledRules.Rules.Has(noTodoRule) {
+				continue
+			}
+			if strings.Contains(sd.Doc.Text, "TODO") {
+				util.Reportf(actx.Pass, noTodoRule, sd.Doc.CG.Pos(), "godoc should not contain TODO")
+			}
+		}
+	}
+	return nil
+}
+
+func TestComposeWithCustomChecker(t *testing.T) {
+	require := require.New(t)
+
+	wd, err := os.Getwd()
+	require.NoError(err, "failed to get wd")
+
+	testdir := filepath.Join(wd, "../../testdata/custom")
+
+	composition := compose.Compose(compose.CompositionConfig{
+		BaseDir:  testdir,
+		Checkers: []model.Checker{&noTodoChecker{}},
+	})
+
+	require.True(composition.Registry.GetCoveredRules().Has(noTodoRule))
+	m, ok := composition.Registry.GetRuleMetadata(noTodoRule)
+	require.True(ok)
+	require.Equal([]model.DefaultSet{model.DefaultSetAll}, m.Presets())
+
+	_ = analysistest.Run(t, testdir, composition.Analyzer.GetAnalyzer(), "./src")
+}
+
+// errorRecorder records the errors reported by the analysis test.
+type errorRecorder struct {
+	errs []string
+}
+
+func (r *errorRecorder) Errorf(format string, args ...any) {
+	r.errs = append(r.errs, fmt.Sprintf(format, args...))
+}
+
+func TestComposeWithExitFunc(t *testing.T) {
+	require := require.New(t)
+
+	testdir := t.TempDir()
+	require.NoError(os.WriteFile(filepath.Join(testdir, ".godoc-lint.yaml"), []byte("default: foo\n"), 0o644))
+	require.NoError(os.MkdirAll(filepath.Join(testdir, "src", "foo"), 0o755))
+	require.NoError(os.WriteFile(filepath.Join(testdir, "src", "foo", "foo.go"), []byte("package foo\n"), 0o644))
+
+	var exitCode int
+	var exitErr error
+	composition := compose.Compose(compose.CompositionConfig{
+		BaseDir: testdir,
+		ExitFunc: func(code int, err error) {
+			exitCode, exitErr = code, err
+		},
+	})
+
+	r := &errorRecorder{}
+	_ = analysistest.Run(r, testdir, composition.Analyzer.GetAnalyzer(), "foo")
+	require.NotEmpty(r.errs)
+
+	require.Equal(2, exitCode)
+	var cerr *model.ConfigError
+	require.True(errors.As(exitErr, &cerr))
+}
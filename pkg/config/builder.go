@@ -7,6 +7,8 @@ import (
 	"path/filepath"
 	"regexp"
 	"slices"
+	"strings"
+	"sync"
 
 	"github.com/godoc-lint/godoc-lint/pkg/model"
 	"github.com/godoc-lint/godoc-lint/pkg/util"
@@ -23,6 +25,21 @@ type ConfigBuilder struct {
 	// golangci-lint) where the root config comes from a different
 	// source/format.
 	baseDirPlainConfig *PlainConfig
+
+	// registry provides the set of known rules. If nil, only built-in rules
+	// are known.
+	registry model.Registry
+
+	// hermetic indicates whether config files should not be looked up, and
+	// path patterns should be matched against package import paths.
+	hermetic bool
+
+	// baseDirOnce guards the resolution of the plain config for the base
+	// directory, which is shared by all directories without a config file.
+	baseDirOnce     sync.Once
+	baseDirPCfg     *PlainConfig
+	baseDirFilePath string
+	baseDirErr      error
 }
 
 // NewConfigBuilder crates a new instance of the corresponding struct.
@@ -40,6 +57,27 @@ func (cb *ConfigBuilder) WithBaseDirPlainConfig(baseDirPlainConfig *PlainConfig)
 	return cb
 }
 
+// WithRegistry sets the registry that provides the known rules, including any
+// custom rules. Without a registry, only built-in rules are known.
+func (cb *ConfigBuilder) WithRegistry(registry model.Registry) *ConfigBuilder {
+	cb.registry = registry
+	return cb
+}
+
+// WithHermetic enables the hermetic mode, where the configuration is solely
+// built from the plain config for the base directory (See
+// [ConfigBuilder.WithBaseDirPlainConfig]) and the override, without looking up
+// any config file (including the one in the override). Also, the path patterns
+// to include/exclude are matched against package import paths, rather than file
+// paths (See [model.Config.IsFileApplicable]).
+//
+// This is meant to be used in sandboxed build systems (e.g., Bazel's nogo),
+// where the file system layout is not meaningful.
+func (cb *ConfigBuilder) WithHermetic() *ConfigBuilder {
+	cb.hermetic = true
+	return cb
+}
+
 // GetConfig implements the corresponding interface method.
 func (cb *ConfigBuilder) GetConfig(cwd string) (model.Config, error) {
 	return cb.build(cwd)
@@ -48,6 +86,13 @@ func (cb *ConfigBuilder) GetConfig(cwd string) (model.Config, error) {
 func (cb *ConfigBuilder) resolvePlainConfig(cwd string) (*PlainConfig, *PlainConfig, string, string, error) {
 	def := getDefaultPlainConfig()
 
+	if cb.hermetic {
+		if cb.baseDirPlainConfig != nil {
+			return cb.baseDirPlainConfig, def, cb.baseDir, "", nil
+		}
+		return def, def, cb.baseDir, "", nil
+	}
+
 	if !util.IsPathUnderBaseDir(cb.baseDir, cwd) {
 		if pcfg, filePath, err := cb.resolvePlainConfigAtBaseDir(); err != nil {
 			return nil, nil, "", "", err
@@ -83,15 +128,30 @@ func (cb *ConfigBuilder) resolvePlainConfig(cwd string) (*PlainConfig, *PlainCon
 	}
 }
 
+// resolvePlainConfigAtBaseDir returns the plain config for the base directory,
+// along with the path to its file, if any. The result is resolved once, and
+// reused for all directories.
 func (cb *ConfigBuilder) resolvePlainConfigAtBaseDir() (*PlainConfig, string, error) {
-	// TODO(babakks): refactor this to a sync.OnceValue for performance
+	cb.baseDirOnce.Do(func() {
+		cb.baseDirPCfg, cb.baseDirFilePath, cb.baseDirErr = cb.doResolvePlainConfigAtBaseDir()
+	})
+	return cb.baseDirPCfg, cb.baseDirFilePath, cb.baseDirErr
+}
 
+func (cb *ConfigBuilder) doResolvePlainConfigAtBaseDir() (*PlainConfig, string, error) {
 	if cb.override != nil && cb.override.ConfigFilePath != nil {
-		pcfg, err := FromYAMLFile(*cb.override.ConfigFilePath)
+		// Relative paths are resolved against the base directory, rather than
+		// the working directory, which might be different (e.g., go vet runs
+		// the analyzer in package directories).
+		path := *cb.override.ConfigFilePath
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(cb.baseDir, path)
+		}
+		pcfg, err := FromYAMLFile(path)
 		if err != nil {
 			return nil, "", err
 		}
-		return pcfg, *cb.override.ConfigFilePath, nil
+		return pcfg, path, nil
 	}
 
 	if pcfg, filePath, err := findConventionalConfigFile(cb.baseDir); err != nil {
@@ -132,14 +192,22 @@ func findConventionalConfigFile(dir string) (*PlainConfig, string, error) {
 // The method also does the following:
 //   - Applies override flags (e.g., enable, or disable).
 //   - Validates the final configuration.
+//
+// The returned error, if any, is a [model.ConfigError].
 func (cb *ConfigBuilder) build(cwd string) (*config, error) {
 	pcfg, def, configCWD, configFilePath, err := cb.resolvePlainConfig(cwd)
 	if err != nil {
-		return nil, err
+		var cerr *model.ConfigError
+		if errors.As(err, &cerr) {
+			return nil, err
+		}
+		return nil, &model.ConfigError{Err: err}
 	}
 
-	if err := pcfg.Validate(); err != nil {
-		return nil, fmt.Errorf("invalid config at %q: %w", configFilePath, err)
+	knownRules := cb.getRules()
+
+	if err := pcfg.ValidateWithRules(knownRules); err != nil {
+		return nil, &model.ConfigError{File: configFilePath, Err: err}
 	}
 
 	toValidRuleSet := func(s []string) (*model.RuleSet, []string) {
@@ -149,7 +217,7 @@ func (cb *ConfigBuilder) build(cwd string) (*config, error) {
 		invalids := make([]string, 0, len(s))
 		rules := make([]model.Rule, 0, len(s))
 		for _, v := range s {
-			if !model.AllRules.Has(model.Rule(v)) {
+			if !knownRules.Has(model.Rule(v)) {
 				invalids = append(invalids, v)
 				continue
 			}
@@ -181,6 +249,7 @@ func (cb *ConfigBuilder) build(cwd string) (*config, error) {
 	result := &config{
 		cwd:            configCWD,
 		configFilePath: configFilePath,
+		hermetic:       cb.hermetic,
 	}
 
 	var enabledRules *model.RuleSet
@@ -246,7 +315,7 @@ func (cb *ConfigBuilder) build(cwd string) (*config, error) {
 	}
 
 	if cb.override != nil && cb.override.Default != nil {
-		result.rulesToApply = model.DefaultSetToRules[*cb.override.Default]
+		result.rulesToApply = cb.getDefaultSetRules(*cb.override.Default)
 	} else {
 		raw := pcfg.Default
 		if raw == nil {
@@ -256,26 +325,41 @@ func (cb *ConfigBuilder) build(cwd string) (*config, error) {
 		if !slices.Contains(model.DefaultSetValues, model.DefaultSet(*raw)) {
 			errs = append(errs, fmt.Errorf("invalid default set %q; must be one of %q", *raw, model.DefaultSetValues))
 		} else {
-			result.rulesToApply = model.DefaultSetToRules[model.DefaultSet(*raw)]
+			result.rulesToApply = cb.getDefaultSetRules(model.DefaultSet(*raw))
 		}
 	}
 
-	var maxLenIgnore []*regexp.Regexp
-	rawMaxLenIgnore := def.Options.MaxLenIgnorePatterns
-	if pcfg.Options != nil && pcfg.Options.MaxLenIgnorePatterns != nil {
-		rawMaxLenIgnore = pcfg.Options.MaxLenIgnorePatterns
+	plainOptions := pcfg.Options.Map()
+	if coverage, err := decodeCoverageOptions(plainOptions); err != nil {
+		errs = append(errs, err)
+	} else {
+		result.coverageMin = coverage.Min
 	}
-	if len(rawMaxLenIgnore) > 0 {
-		rs, invalids := toValidRegexpSlice(rawMaxLenIgnore)
-		if len(invalids) > 0 {
-			errs = append(errs, fmt.Errorf("invalid max-len ignore pattern(s): %q", invalids))
-		} else {
-			maxLenIgnore = rs
+
+	if cb.registry != nil {
+		result.checkerOptions = make(map[model.Checker]model.CheckerOptions, 10)
+		var optionErrs []error
+		for _, checker := range cb.registry.List() {
+			c, ok := checker.(model.ConfigurableChecker)
+			if !ok {
+				continue
+			}
+			options, err := decodeCheckerOptions(c, plainOptions)
+			if err != nil {
+				optionErrs = append(optionErrs, err)
+				continue
+			}
+			result.checkerOptions[checker] = options
 		}
+		// Checkers are not listed in a particular order.
+		slices.SortFunc(optionErrs, func(a, b error) int {
+			return strings.Compare(a.Error(), b.Error())
+		})
+		errs = append(errs, optionErrs...)
 	}
 
 	if errs != nil {
-		return nil, errors.Join(errs...)
+		return nil, &model.ConfigError{File: configFilePath, Err: errors.Join(errs...)}
 	}
 
 	if enabledRules != nil {
@@ -287,41 +371,31 @@ func (cb *ConfigBuilder) build(cwd string) (*config, error) {
 
 	// To avoid being too strict, we don't complain if a rule is enabled and disabled at the same time.
 
-	resolvedOptions := &model.RuleOptions{}
-	transferPrimitiveOptions(resolvedOptions, def.Options) // def.Options is never nil
-	if pcfg.Options != nil {
-		transferPrimitiveOptions(resolvedOptions, pcfg.Options)
-	}
-	resolvedOptions.MaxLenIgnorePatterns = maxLenIgnore
-
-	result.options = resolvedOptions
-
 	return result, nil
 }
 
-// SetOverride implements the corresponding interface method.
-func (cb *ConfigBuilder) SetOverride(override *model.ConfigOverride) {
-	cb.override = override
+// getRules returns the set of known rules.
+func (cb *ConfigBuilder) getRules() model.RuleSet {
+	if cb.registry == nil {
+		return model.AllRules
+	}
+	return cb.registry.GetCoveredRules()
 }
 
-func transferPrimitiveOptions(target *model.RuleOptions, source *PlainRuleOptions) {
-	transferIfNotNil(&target.MaxLenLength, source.MaxLenLength)
-	transferIfNotNil(&target.MaxLenIncludeTests, source.MaxLenIncludeTests)
-	transferIfNotNil(&target.PkgDocIncludeTests, source.PkgDocIncludeTests)
-	transferIfNotNil(&target.SinglePkgDocIncludeTests, source.SinglePkgDocIncludeTests)
-	transferIfNotNil(&target.RequirePkgDocIncludeTests, source.RequirePkgDocIncludeTests)
-	transferIfNotNil(&target.RequireDocIncludeTests, source.RequireDocIncludeTests)
-	transferIfNotNil(&target.RequireDocIgnoreExported, source.RequireDocIgnoreExported)
-	transferIfNotNil(&target.RequireDocIgnoreUnexported, source.RequireDocIgnoreUnexported)
-	transferIfNotNil(&target.StartWithNameIncludeTests, source.StartWithNameIncludeTests)
-	transferIfNotNil(&target.StartWithNameIncludeUnexported, source.StartWithNameIncludeUnexported)
-	transferIfNotNil(&target.RequireStdlibDoclinkIncludeTests, source.RequireStdlibDoclinkIncludeTests)
-	transferIfNotNil(&target.NoUnusedLinkIncludeTests, source.NoUnusedLinkIncludeTests)
+// getDefaultSetRules returns the set of known rules included in the given
+// default set.
+func (cb *ConfigBuilder) getDefaultSetRules(set model.DefaultSet) model.RuleSet {
+	if cb.registry == nil {
+		return model.DefaultSetToRules[set]
+	}
+	return model.GetDefaultSetRules(set, cb.registry.ListRuleMetadata())
 }
 
-func transferIfNotNil[T any](dst, src *T) {
-	if src == nil {
-		return
-	}
-	*dst = *src
+// SetOverride implements the corresponding interface method.
+//
+// Since the override may change the config file for the base directory, the
+// resolved plain config for the base directory is reset.
+func (cb *ConfigBuilder) SetOverride(override *model.ConfigOverride) {
+	cb.override = override
+	cb.baseDirOnce = sync.Once{}
 }
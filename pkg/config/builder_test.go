@@ -9,76 +9,98 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/godoc-lint/godoc-lint/pkg/check"
+	"github.com/godoc-lint/godoc-lint/pkg/check/max_len"
+	"github.com/godoc-lint/godoc-lint/pkg/check/require_doc"
 	"github.com/godoc-lint/godoc-lint/pkg/config"
 	"github.com/godoc-lint/godoc-lint/pkg/model"
 )
 
 var _ model.ConfigBuilder = &config.ConfigBuilder{}
 
-func TestTransferPrimitiveOptions(t *testing.T) {
-	uintPtr := func(x uint) *uint {
-		return &x
-	}
-
-	def, err := config.FromYAML(config.DefaultConfigYAML)
-	require.NoError(t, err)
-
+func TestCheckerOptions(t *testing.T) {
 	tests := []struct {
-		name     string
-		sources  []*config.PlainRuleOptions
-		expected *model.RuleOptions
+		name                 string
+		options              map[string]any
+		deprecated           *config.PlainRuleOptions
+		expectedLength       uint
+		expectedIncludeTests bool
+		expectedPatterns     []string
+		expectedCoverageMin  float64
+		wantErr              string
 	}{
 		{
-			name:     "empty",
-			sources:  []*config.PlainRuleOptions{{}},
-			expected: &model.RuleOptions{},
+			name:             "empty",
+			expectedLength:   77,
+			expectedPatterns: []string{},
+		},
+		{
+			name: "non-empty",
+			options: map[string]any{
+				"max-len/length":          999,
+				"max-len/include-tests":   true,
+				"max-len/ignore-patterns": []any{"^foo$"},
+				"coverage/min":            50.5,
+			},
+			expectedLength:       999,
+			expectedIncludeTests: true,
+			expectedPatterns:     []string{"^foo$"},
+			expectedCoverageMin:  50.5,
+		},
+		{
+			name: "deprecated fields",
+			deprecated: &config.PlainRuleOptions{
+				Values:             map[string]any{"max-len/length": 999},
+				MaxLenLength:       ptr[uint](100),
+				MaxLenIncludeTests: ptr(true),
+			},
+			expectedLength:       100,
+			expectedIncludeTests: true,
+			expectedPatterns:     []string{},
+		},
+		{
+			name: "other rules",
+			options: map[string]any{
+				"require-doc/ignore-exported": true,
+			},
+			expectedLength:   77,
+			expectedPatterns: []string{},
+		},
+		{
+			name: "invalid type",
+			options: map[string]any{
+				"max-len/length": "foo",
+			},
+			wantErr: `invalid option(s) for ["max-len"]: cannot unmarshal !!str ` + "`foo`" + ` into uint`,
 		},
 		{
-			name: "empty and then non-empty",
-			sources: []*config.PlainRuleOptions{{}, {
-				MaxLenLength: uintPtr(999),
-			}},
-			expected: &model.RuleOptions{
-				MaxLenLength: 999,
+			name: "unknown option of known rule",
+			options: map[string]any{
+				"max-len/foo": 0,
 			},
+			wantErr: `invalid option(s) for ["max-len"]: field max-len/foo not found in type max_len.Options`,
 		},
 		{
-			name: "non-empty and then empty",
-			sources: []*config.PlainRuleOptions{{
-				MaxLenLength: uintPtr(999),
-			}, {}},
-			expected: &model.RuleOptions{
-				MaxLenLength: 999,
+			name: "unknown rule",
+			options: map[string]any{
+				"foo/bar": 0,
+				"baz":     0,
 			},
+			wantErr: `unknown option(s): ["baz" "foo/bar"]`,
 		},
 		{
-			name: "non-empty and then non-empty",
-			sources: []*config.PlainRuleOptions{{
-				MaxLenLength: uintPtr(888),
-			}, {
-				MaxLenLength: uintPtr(999),
-			}},
-			expected: &model.RuleOptions{
-				MaxLenLength: 999,
+			name: "invalid by checker",
+			options: map[string]any{
+				"max-len/ignore-patterns": []any{"(", "^foo$", ")"},
 			},
+			wantErr: `invalid option(s) for ["max-len"]: invalid max-len ignore pattern(s): ["(" ")"]`,
 		},
 		{
-			name:    "default",
-			sources: []*config.PlainRuleOptions{def.Options},
-			expected: &model.RuleOptions{
-				MaxLenLength:                     77,
-				MaxLenIncludeTests:               false,
-				PkgDocIncludeTests:               false,
-				SinglePkgDocIncludeTests:         false,
-				RequirePkgDocIncludeTests:        false,
-				RequireDocIncludeTests:           false,
-				RequireDocIgnoreExported:         false,
-				RequireDocIgnoreUnexported:       true,
-				StartWithNameIncludeTests:        false,
-				StartWithNameIncludeUnexported:   false,
-				RequireStdlibDoclinkIncludeTests: false,
-				NoUnusedLinkIncludeTests:         false,
+			name: "invalid coverage",
+			options: map[string]any{
+				"coverage/min": 100.5,
 			},
+			wantErr: `invalid coverage minimum 100.5; must be between 0 and 100`,
 		},
 	}
 
@@ -86,14 +108,77 @@ func TestTransferPrimitiveOptions(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			require := require.New(t)
 
-			target := &model.RuleOptions{}
-			for _, source := range tt.sources {
-				config.TransferPrimitiveOptions(target, source)
+			baseDir := t.TempDir()
+			checker := max_len.NewMaxLenChecker()
+			plain := &config.PlainRuleOptions{Values: tt.options}
+			if tt.deprecated != nil {
+				plain = tt.deprecated
+			}
+			cb := config.NewConfigBuilder(baseDir).
+				WithBaseDirPlainConfig(&config.PlainConfig{Options: plain}).
+				WithRegistry(check.NewRegistry(checker, require_doc.NewRequireDocChecker()))
+
+			cfg, err := cb.GetConfig(baseDir)
+			if tt.wantErr != "" {
+				require.ErrorContains(err, tt.wantErr)
+				return
 			}
+			require.NoError(err)
 
-			require.Equal(tt.expected, target)
+			options, ok := cfg.GetCheckerOptions(checker).(*max_len.Options)
+			require.True(ok)
+			require.Equal(tt.expectedLength, options.Length)
+			require.Equal(tt.expectedIncludeTests, options.IncludeTests)
+			require.Equal(tt.expectedPatterns, options.IgnorePatterns)
+			require.Equal(tt.expectedCoverageMin, cfg.GetCoverageMin())
+
+			ruleOptions := cfg.GetRuleOptions() //nolint:staticcheck // The deprecated options should still be filled.
+			require.Equal(tt.expectedLength, ruleOptions.MaxLenLength)
+			require.Equal(tt.expectedIncludeTests, ruleOptions.MaxLenIncludeTests)
+			require.Len(ruleOptions.MaxLenIgnorePatterns, len(tt.expectedPatterns))
+			require.Equal(tt.options["require-doc/ignore-exported"] == true, ruleOptions.RequireDocIgnoreExported)
+			require.True(ruleOptions.RequireDocIgnoreUnexported)
+		})
+	}
+}
+
+func TestConfigFingerprint(t *testing.T) {
+	fingerprint := func(t *testing.T, baseDir string, pcfg *config.PlainConfig) string {
+		t.Helper()
+		cb := config.NewConfigBuilder(baseDir).
+			WithBaseDirPlainConfig(pcfg).
+			WithRegistry(check.NewRegistry(max_len.NewMaxLenChecker(), require_doc.NewRequireDocChecker()))
+		cfg, err := cb.GetConfig(baseDir)
+		require.NoError(t, err)
+		return cfg.Fingerprint()
+	}
+
+	baseDir := t.TempDir()
+	base := fingerprint(t, baseDir, &config.PlainConfig{})
+	require.Equal(t, base, fingerprint(t, baseDir, &config.PlainConfig{}), "same config")
+
+	for name, pcfg := range map[string]*config.PlainConfig{
+		"options": {Options: &config.PlainRuleOptions{Values: map[string]any{"max-len/length": 100}}},
+		"enable":  {Enable: []string{"max-len"}},
+		"exclude": {Exclude: []string{"^foo/"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.NotEqual(t, base, fingerprint(t, baseDir, pcfg))
 		})
 	}
+
+	t.Run("cwd", func(t *testing.T) {
+		require.NotEqual(t, base, fingerprint(t, t.TempDir(), &config.PlainConfig{}))
+	})
+
+	t.Run("config file", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, ".godoc-lint.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("version: \"1.0\"\n"), 0o644))
+		before := fingerprint(t, dir, nil)
+		require.NoError(t, os.WriteFile(path, []byte("version: \"1.0\"\n# comment\n"), 0o644))
+		require.NotEqual(t, before, fingerprint(t, dir, nil))
+	})
 }
 
 func TestConfigResolution(t *testing.T) {
@@ -428,6 +513,79 @@ func TestConfigResolution(t *testing.T) {
 	}
 }
 
+func TestConfigErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		fs            map[string]string
+		wantFile      string
+		wantLine      int
+		wantErrSubstr string
+	}{
+		{
+			name: "malformed yaml",
+			fs: map[string]string{
+				".godoc-lint.yaml": "enable:\n  - max-len\ndisable: [\n",
+			},
+			wantFile:      ".godoc-lint.yaml",
+			wantLine:      3,
+			wantErrSubstr: "cannot parse config",
+		},
+		{
+			name: "invalid type",
+			fs: map[string]string{
+				".godoc-lint.yaml": "default: basic\nenable: max-len\n",
+			},
+			wantFile:      ".godoc-lint.yaml",
+			wantLine:      2,
+			wantErrSubstr: "cannot unmarshal",
+		},
+		{
+			name: "unsupported version",
+			fs: map[string]string{
+				".godoc-lint.yaml": "default: basic\nversion: \"2.0\"\n",
+			},
+			wantFile:      ".godoc-lint.yaml",
+			wantLine:      2,
+			wantErrSubstr: "unsupported config version",
+		},
+		{
+			name: "invalid rule",
+			fs: map[string]string{
+				".godoc-lint.yaml": "enable:\n  - foo\n",
+			},
+			wantFile:      ".godoc-lint.yaml",
+			wantErrSubstr: "invalid rule name(s) to enable",
+		},
+		{
+			name: "nested config file",
+			fs: map[string]string{
+				".godoc-lint.yaml":     "default: basic\n",
+				"foo/.godoc-lint.yaml": "default: foo\n",
+			},
+			wantFile:      "foo/.godoc-lint.yaml",
+			wantErrSubstr: "invalid default set",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			baseDir := t.TempDir()
+
+			err := setupFS(tt.fs, baseDir)
+			require.NoError(t, err)
+
+			cb := config.NewConfigBuilder(baseDir)
+			_, err = cb.GetConfig(filepath.Join(baseDir, "foo"))
+
+			var cerr *model.ConfigError
+			require.ErrorAs(t, err, &cerr)
+			assert.Equal(t, filepath.Join(baseDir, filepath.FromSlash(tt.wantFile)), cerr.File)
+			assert.Equal(t, tt.wantLine, cerr.Line)
+			assert.ErrorContains(t, err, tt.wantErrSubstr)
+		})
+	}
+}
+
 func setupFS(fs map[string]string, baseDir string) error {
 	for pathWithSlash, content := range fs {
 		fullPath := filepath.Join(baseDir, filepath.FromSlash(pathWithSlash))
@@ -1,8 +1,16 @@
 package config
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
 
 	"github.com/godoc-lint/godoc-lint/pkg/model"
 )
@@ -19,10 +27,15 @@ type config struct {
 	// be an empty string.
 	configFilePath string
 
+	// hermetic indicates whether the path patterns should be matched against
+	// package import paths, rather than file paths.
+	hermetic bool
+
 	includeAsRegexp []*regexp.Regexp
 	excludeAsRegexp []*regexp.Regexp
 	rulesToApply    model.RuleSet
-	options         *model.RuleOptions
+	checkerOptions  map[model.Checker]model.CheckerOptions
+	coverageMin     float64
 }
 
 // GetConfigFilePath implements the corresponding interface method.
@@ -49,8 +62,20 @@ func (c *config) IsPathApplicable(path string) bool {
 
 	// To ensure a consistent behavior on different platform (with the same
 	// configuration), we convert the path to a Unix-style path.
-	asUnixPath := filepath.ToSlash(p)
+	return c.matchPathPatterns(filepath.ToSlash(p))
+}
 
+// IsFileApplicable implements the corresponding interface method.
+func (c *config) IsFileApplicable(pkgPath, path string) bool {
+	if !c.hermetic {
+		return c.IsPathApplicable(path)
+	}
+	return c.matchPathPatterns(pkgPath + "/" + filepath.Base(path))
+}
+
+// matchPathPatterns determines if the given Unix-style path matches the
+// included path patterns, or does not match the excluded path patterns.
+func (c *config) matchPathPatterns(asUnixPath string) bool {
 	for _, re := range c.excludeAsRegexp {
 		if re.MatchString(asUnixPath) {
 			return false
@@ -67,7 +92,118 @@ func (c *config) IsPathApplicable(path string) bool {
 	return false
 }
 
-// GetRuleOptions implements the corresponding interface method.
+// GetCheckerOptions implements the corresponding interface method.
+func (c *config) GetCheckerOptions(checker model.Checker) model.CheckerOptions {
+	return c.checkerOptions[checker]
+}
+
+// ruleOptions holds the options of the built-in rules, with the option names
+// as "yaml" tags, so they can be collected from the checker options.
+type ruleOptions struct {
+	MaxLenLength                     uint     `yaml:"max-len/length"`
+	MaxLenIncludeTests               bool     `yaml:"max-len/include-tests"`
+	MaxLenIgnorePatterns             []string `yaml:"max-len/ignore-patterns"`
+	PkgDocIncludeTests               bool     `yaml:"pkg-doc/include-tests"`
+	SinglePkgDocIncludeTests         bool     `yaml:"single-pkg-doc/include-tests"`
+	RequirePkgDocIncludeTests        bool     `yaml:"require-pkg-doc/include-tests"`
+	RequireDocIncludeTests           bool     `yaml:"require-doc/include-tests"`
+	RequireDocIgnoreExported         bool     `yaml:"require-doc/ignore-exported"`
+	RequireDocIgnoreUnexported       bool     `yaml:"require-doc/ignore-unexported"`
+	StartWithNameIncludeTests        bool     `yaml:"start-with-name/include-tests"`
+	StartWithNameIncludeUnexported   bool     `yaml:"start-with-name/include-unexported"`
+	RequireStdlibDoclinkIncludeTests bool     `yaml:"require-stdlib-doclink/include-tests"`
+	NoUnusedLinkIncludeTests         bool     `yaml:"no-unused-link/include-tests"`
+}
+
+// GetRuleOptions implements the corresponding interface method. The options
+// are collected from the options of the checkers.
+//
+// Deprecated: Use GetCheckerOptions instead.
 func (c *config) GetRuleOptions() *model.RuleOptions {
-	return c.options
+	ro := &ruleOptions{}
+	for _, opts := range c.checkerOptions {
+		// Checker options are encoded with the option names, so the options
+		// of the built-in rules are picked up, and the rest are ignored.
+		if encoded, err := yaml.Marshal(opts); err == nil {
+			_ = yaml.Unmarshal(encoded, ro)
+		}
+	}
+
+	ignorePatterns := make([]*regexp.Regexp, 0, len(ro.MaxLenIgnorePatterns))
+	for _, p := range ro.MaxLenIgnorePatterns {
+		// The patterns are already validated by the checker.
+		if re, err := regexp.Compile(p); err == nil {
+			ignorePatterns = append(ignorePatterns, re)
+		}
+	}
+
+	return &model.RuleOptions{
+		MaxLenLength:                     ro.MaxLenLength,
+		MaxLenIncludeTests:               ro.MaxLenIncludeTests,
+		MaxLenIgnorePatterns:             ignorePatterns,
+		PkgDocIncludeTests:               ro.PkgDocIncludeTests,
+		SinglePkgDocIncludeTests:         ro.SinglePkgDocIncludeTests,
+		RequirePkgDocIncludeTests:        ro.RequirePkgDocIncludeTests,
+		RequireDocIncludeTests:           ro.RequireDocIncludeTests,
+		RequireDocIgnoreExported:         ro.RequireDocIgnoreExported,
+		RequireDocIgnoreUnexported:       ro.RequireDocIgnoreUnexported,
+		StartWithNameIncludeTests:        ro.StartWithNameIncludeTests,
+		StartWithNameIncludeUnexported:   ro.StartWithNameIncludeUnexported,
+		RequireStdlibDoclinkIncludeTests: ro.RequireStdlibDoclinkIncludeTests,
+		NoUnusedLinkIncludeTests:         ro.NoUnusedLinkIncludeTests,
+	}
+}
+
+// GetCoverageMin implements the corresponding interface method.
+func (c *config) GetCoverageMin() float64 {
+	return c.coverageMin
+}
+
+// Fingerprint implements the corresponding interface method.
+func (c *config) Fingerprint() string {
+	h := sha256.New()
+	write := func(values ...string) {
+		for _, v := range values {
+			h.Write([]byte(v))
+			h.Write([]byte{0})
+		}
+	}
+
+	write("cwd", c.cwd, "config", c.configFilePath, "hermetic", strconv.FormatBool(c.hermetic))
+	if c.configFilePath != "" {
+		// The resolved values should already reflect the file content, but
+		// hashing the file too is cheap, and it is robust against options that
+		// are not (yet) decoded eagerly.
+		if content, err := os.ReadFile(c.configFilePath); err == nil {
+			h.Write(content)
+		}
+		write("")
+	}
+
+	for _, re := range c.includeAsRegexp {
+		write("include", re.String())
+	}
+	for _, re := range c.excludeAsRegexp {
+		write("exclude", re.String())
+	}
+	for _, rule := range c.rulesToApply.List() {
+		write("rule", string(rule))
+	}
+
+	// Checkers are identified by the rules they cover, since the map order is
+	// not deterministic.
+	options := make([]string, 0, len(c.checkerOptions))
+	for checker, opts := range c.checkerOptions {
+		var rules []string
+		for _, rule := range checker.GetCoveredRules().List() {
+			rules = append(rules, string(rule))
+		}
+		encoded, _ := yaml.Marshal(opts)
+		options = append(options, strings.Join(rules, ",")+"\x00"+string(encoded))
+	}
+	slices.Sort(options)
+	write(options...)
+
+	write("coverage", strconv.FormatFloat(c.coverageMin, 'g', -1, 64))
+	return hex.EncodeToString(h.Sum(nil))
 }
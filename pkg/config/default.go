@@ -2,6 +2,7 @@ package config
 
 import (
 	_ "embed"
+	"slices"
 	"sync"
 )
 
@@ -15,6 +16,13 @@ var defaultConfigFiles = []string{
 	".godoclint.json",
 }
 
+// DefaultConfigFiles returns the names of the configuration files that are
+// looked up in the package directories and their parents, in order of
+// precedence.
+func DefaultConfigFiles() []string {
+	return slices.Clone(defaultConfigFiles)
+}
+
 // defaultConfigYAML is the default configuration (as YAML).
 //
 //go:embed default.yaml
@@ -5,7 +5,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v3"
 
+	"github.com/godoc-lint/godoc-lint/pkg/check"
 	"github.com/godoc-lint/godoc-lint/pkg/config"
 )
 
@@ -17,13 +19,11 @@ func TestDefaultConfigYAMLIsValid(t *testing.T) {
 
 	require.NoError(def.Validate())
 
-	// The default rule options must be non-nil for the default.
-	require.NotNil(def.Options, "default rule options must be non-nil")
+	// The default options are owned by the checkers (See
+	// [model.ConfigurableChecker.NewOptions]).
+	require.Nil(def.Options, "default config must not set rule options")
 
-	visitedOptions := map[string]struct{}{}
-
-	v := reflect.ValueOf(*def.Options)
-	vt := reflect.TypeFor[config.PlainRuleOptions]()
+	vt := reflect.TypeFor[config.PlainConfig]()
 	for i := range vt.NumField() {
 		ft := vt.Field(i)
 
@@ -33,13 +33,6 @@ func TestDefaultConfigYAMLIsValid(t *testing.T) {
 		require.NotEmpty(tagMapstructure, `"mapstructure" tag is required for field %q`, ft.Name)
 
 		require.Equal(tagMapstructure, tagYAML, `"mapstructure" and "yaml" tag values must be equal`)
-
-		require.NotContains(visitedOptions, tagMapstructure, "duplicate option tag values: %q", tagMapstructure)
-		visitedOptions[tagMapstructure] = struct{}{}
-
-		// All fields must be assigned by not nil.
-		f := v.Field(i)
-		require.False(f.IsNil(), "value of %q must be non-nil", ft.Name)
 	}
 }
 
@@ -54,3 +47,45 @@ func TestDefaultConfigYAMLEqualsTheExample(t *testing.T) {
 
 	require.Equal(def, example, "default config does not match the example file")
 }
+
+func TestCheckerOptionsMatchRuleMetadata(t *testing.T) {
+	require := require.New(t)
+
+	baseDir := t.TempDir()
+	registry := check.NewPopulatedRegistry()
+	cfg, err := config.NewConfigBuilder(baseDir).
+		WithBaseDirPlainConfig(&config.PlainConfig{}).
+		WithRegistry(registry).
+		GetConfig(baseDir)
+	require.NoError(err)
+
+	// The default options of the checkers (i.e., when there is no options in
+	// the config) must be the same as the documented ones.
+	defaults := map[string]any{}
+	for _, checker := range registry.List() {
+		options := cfg.GetCheckerOptions(checker)
+		if options == nil {
+			continue
+		}
+		raw, err := yaml.Marshal(options)
+		require.NoError(err)
+		require.NoError(yaml.Unmarshal(raw, &defaults))
+	}
+
+	documented := map[string]struct{}{}
+	for _, m := range registry.ListRuleMetadata() {
+		for _, o := range m.Options {
+			documented[o.Name] = struct{}{}
+
+			require.Contains(defaults, o.Name, "documented option is not a checker option")
+
+			var value any
+			require.NoError(yaml.Unmarshal([]byte(o.Default), &value))
+			require.Equal(defaults[o.Name], value, "documented default of %q does not match checker default", o.Name)
+		}
+	}
+
+	for name := range defaults {
+		require.Contains(documented, name, "option is not documented in rule metadata")
+	}
+}
@@ -3,7 +3,6 @@ package config
 type Config = config
 
 var (
-	DefaultConfigYAML        = defaultConfigYAML
-	GetDefaultPlainConfig    = getDefaultPlainConfig
-	TransferPrimitiveOptions = transferPrimitiveOptions
+	DefaultConfigYAML     = defaultConfigYAML
+	GetDefaultPlainConfig = getDefaultPlainConfig
 )
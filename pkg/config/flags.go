@@ -0,0 +1,119 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/godoc-lint/godoc-lint/pkg/model"
+)
+
+// RegisterConfigFileFlag registers the -config command-line flag on the given
+// flag set, to set the path to the config file in the given override.
+func RegisterConfigFileFlag(fs *flag.FlagSet, override *model.ConfigOverride) {
+	fs.Func("config", "path to config file", func(s string) error {
+		if override.ConfigFilePath != nil {
+			return errors.New("config file is set multiple times")
+		}
+		if strings.TrimSpace(s) == "" {
+			return errors.New("empty path")
+		}
+		override.ConfigFilePath = &s
+		return nil
+	})
+}
+
+// RegisterOverrideFlags registers the command-line flags to override the
+// configuration (i.e., -default, -include, -exclude, -enable, and -disable) on
+// the given flag set. The parsed values are stored in the given override, where
+// rule names are checked against the given set of known rules.
+func RegisterOverrideFlags(fs *flag.FlagSet, override *model.ConfigOverride, knownRules model.RuleSet) {
+	fs.Func("default", "default set of rules to enable", func(s string) error {
+		if override.Default != nil {
+			return errors.New("default set is set multiple times")
+		}
+		if !slices.Contains(model.DefaultSetValues, model.DefaultSet(s)) {
+			return fmt.Errorf("unknown default set %q, must be one of %q", s, model.DefaultSetValues)
+		}
+		v := model.DefaultSet(s)
+		override.Default = &v
+		return nil
+	})
+
+	walkNonEmptyCSV := func(f func(string) error) func(string) error {
+		return func(value string) error {
+			for v := range strings.SplitSeq(strings.TrimSpace(value), ",") {
+				if strings.TrimSpace(v) == "" {
+					return errors.New("empty element")
+				}
+				if err := f(v); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+
+	walkNonEmpty := func(f func(string) error) func(string) error {
+		return func(value string) error {
+			if strings.TrimSpace(value) == "" {
+				return errors.New("empty value")
+			}
+			if err := f(value); err != nil {
+				return err
+			}
+			return nil
+		}
+	}
+
+	fs.Func("include", "regexp path (Unix style) to include (can be used multiple times)", walkNonEmpty(func(s string) error {
+		re, err := regexp.Compile(s)
+		if err != nil {
+			return fmt.Errorf("invalid inclusion regexp pattern %q: %w", s, err)
+		}
+		override.Include = append(override.Include, re)
+		return nil
+	}))
+
+	fs.Func("exclude", "regexp path (Unix style) to exclude (can be used multiple times)", walkNonEmpty(func(s string) error {
+		re, err := regexp.Compile(s)
+		if err != nil {
+			return fmt.Errorf("invalid exclusion regexp pattern %q: %w", s, err)
+		}
+		override.Exclude = append(override.Exclude, re)
+		return nil
+	}))
+
+	fs.Func("enable", "comma-separated rule names to enable", walkNonEmptyCSV(func(s string) error {
+		if !knownRules.Has(model.Rule(s)) {
+			return fmt.Errorf("unknown rule name to enable %q", s)
+		}
+		if override.Disable != nil && override.Disable.Has(model.Rule(s)) {
+			return fmt.Errorf("cannot enable and disable rule at the same time %q", s)
+		}
+		if override.Enable == nil {
+			override.Enable = &model.RuleSet{}
+		}
+		updated := override.Enable.Add(model.Rule(s))
+		override.Enable = &updated
+		return nil
+	}))
+
+	fs.Func("disable", "comma-separated rule names to disable", walkNonEmptyCSV(func(s string) error {
+		if !knownRules.Has(model.Rule(s)) {
+			return fmt.Errorf("unknown rule name to disable %q", s)
+		}
+		if override.Enable != nil && override.Enable.Has(model.Rule(s)) {
+			return fmt.Errorf("cannot enable and disable rule at the same time %q", s)
+		}
+		if override.Disable == nil {
+			override.Disable = &model.RuleSet{}
+		}
+		updated := override.Disable.Add(model.Rule(s))
+		override.Disable = &updated
+		return nil
+	}))
+}
@@ -0,0 +1,87 @@
+package config_test
+
+import (
+	"flag"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/godoc-lint/godoc-lint/pkg/config"
+	"github.com/godoc-lint/godoc-lint/pkg/model"
+)
+
+func TestRegisterOverrideFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		check   func(*testing.T, *model.ConfigOverride)
+		wantErr string
+	}{
+		{
+			name: "empty",
+			check: func(t *testing.T, o *model.ConfigOverride) {
+				require.Equal(t, model.NewConfigOverride(), o)
+			},
+		},
+		{
+			name: "all flags",
+			args: []string{
+				"-config", "foo.yaml",
+				"-default", "none",
+				"-include", "^foo/",
+				"-exclude", "_test\\.go$",
+				"-enable", "max-len,require-doc",
+				"-disable", "deprecated",
+			},
+			check: func(t *testing.T, o *model.ConfigOverride) {
+				require.Equal(t, "foo.yaml", *o.ConfigFilePath)
+				require.Equal(t, model.DefaultSetNone, *o.Default)
+				require.Len(t, o.Include, 1)
+				require.Equal(t, "^foo/", o.Include[0].String())
+				require.Len(t, o.Exclude, 1)
+				require.Equal(t, "_test\\.go$", o.Exclude[0].String())
+				require.Equal(t, []model.Rule{model.MaxLenRule, model.RequireDocRule}, o.Enable.List())
+				require.Equal(t, []model.Rule{model.DeprecatedRule}, o.Disable.List())
+			},
+		},
+		{
+			name:    "unknown rule",
+			args:    []string{"-enable", "foo"},
+			wantErr: `unknown rule name to enable "foo"`,
+		},
+		{
+			name:    "enabled and disabled",
+			args:    []string{"-enable", "max-len", "-disable", "max-len"},
+			wantErr: `cannot enable and disable rule at the same time "max-len"`,
+		},
+		{
+			name:    "unknown default set",
+			args:    []string{"-default", "foo"},
+			wantErr: `unknown default set "foo"`,
+		},
+		{
+			name:    "config file set multiple times",
+			args:    []string{"-config", "foo.yaml", "-config", "bar.yaml"},
+			wantErr: "config file is set multiple times",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			override := model.NewConfigOverride()
+			config.RegisterConfigFileFlag(fs, override)
+			config.RegisterOverrideFlags(fs, override, model.AllRules)
+
+			err := fs.Parse(tt.args)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			tt.check(t, override)
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/godoc-lint/godoc-lint/pkg/model"
+)
+
+// coveragePrefix is the prefix of the options that are not related to any rule,
+// but to the documentation coverage.
+const coveragePrefix = "coverage"
+
+// coverageOptions holds the documentation coverage options.
+type coverageOptions struct {
+	Min float64 `yaml:"coverage/min"`
+}
+
+// decodeCoverageOptions decodes and validates the coverage options.
+func decodeCoverageOptions(options map[string]any) (*coverageOptions, error) {
+	result := &coverageOptions{}
+	if err := decodeOptions(result, options, []string{coveragePrefix}); err != nil {
+		return nil, fmt.Errorf("invalid coverage option(s): %w", err)
+	}
+	if result.Min < 0 || result.Min > 100 {
+		return nil, fmt.Errorf("invalid coverage minimum %v; must be between 0 and 100", result.Min)
+	}
+	return result, nil
+}
+
+// decodeCheckerOptions decodes the options of the given checker, on top of its
+// default options, and validates them.
+func decodeCheckerOptions(checker model.ConfigurableChecker, options map[string]any) (model.CheckerOptions, error) {
+	rules := checker.GetCoveredRules().List()
+	prefixes := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		prefixes = append(prefixes, string(rule))
+	}
+
+	result := checker.NewOptions()
+	if err := decodeOptions(result, options, prefixes); err != nil {
+		return nil, fmt.Errorf("invalid option(s) for %q: %w", rules, err)
+	}
+	if err := result.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid option(s) for %q: %w", rules, err)
+	}
+	return result, nil
+}
+
+var yamlErrorLinePattern = regexp.MustCompile(`^line \d+: `)
+
+// decodeOptions decodes the options, whose names are prefixed with any of the
+// given prefixes, into the given target, which is a pointer to a struct with
+// "yaml" tags. Fields without a corresponding option keep their values.
+//
+// An error is returned if an option does not match any of the target fields.
+func decodeOptions(target any, options map[string]any, prefixes []string) error {
+	selected := make(map[string]any, len(options))
+	for name, value := range options {
+		prefix, _, _ := strings.Cut(name, "/")
+		for _, p := range prefixes {
+			if prefix == p {
+				selected[name] = value
+				break
+			}
+		}
+	}
+	if len(selected) == 0 {
+		return nil
+	}
+
+	raw, err := yaml.Marshal(selected)
+	if err != nil {
+		return err
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(raw))
+	dec.KnownFields(true)
+	if err := dec.Decode(target); err != nil {
+		var typeErr *yaml.TypeError
+		if errors.As(err, &typeErr) {
+			// Line numbers refer to the re-encoded options, so they are dropped.
+			msgs := make([]string, 0, len(typeErr.Errors))
+			for _, msg := range typeErr.Errors {
+				msgs = append(msgs, yamlErrorLinePattern.ReplaceAllString(msg, ""))
+			}
+			return errors.New(strings.Join(msgs, "; "))
+		}
+		return err
+	}
+	return nil
+}
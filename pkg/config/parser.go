@@ -1,42 +1,102 @@
 package config
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"regexp"
+	"strconv"
 	"strings"
 
 	"go.yaml.in/yaml/v3"
+
+	"github.com/godoc-lint/godoc-lint/pkg/model"
 )
 
 // FromYAML parses configuration from given YAML content.
+//
+// The returned error, if any, is a [model.ConfigError].
 func FromYAML(in []byte) (*PlainConfig, error) {
 	raw := PlainConfig{}
 	if err := yaml.Unmarshal(in, &raw); err != nil {
-		return nil, fmt.Errorf("cannot parse config from YAML file: %w", err)
+		return nil, &model.ConfigError{
+			Line: getYAMLErrorLine(err),
+			Err:  fmt.Errorf("cannot parse config from YAML file: %w", err),
+		}
 	}
 
 	if raw.Version != nil && !strings.HasPrefix(*raw.Version, "1.") {
-		return nil, fmt.Errorf("unsupported config version: %s", *raw.Version)
+		var node struct {
+			Version yaml.Node `yaml:"version"`
+		}
+		_ = yaml.Unmarshal(in, &node)
+		return nil, &model.ConfigError{
+			Line: node.Version.Line,
+			Err:  fmt.Errorf("unsupported config version: %s", *raw.Version),
+		}
 	}
 
 	return &raw, nil
 }
 
 // FromYAMLFile parses configuration from given file path.
+//
+// The returned error, if any, is a [model.ConfigError].
 func FromYAMLFile(path string) (*PlainConfig, error) {
 	in, err := os.ReadFile(path)
 	if err != nil {
-		return nil, fmt.Errorf("cannot read file (%s): %w", path, err)
+		return nil, &model.ConfigError{
+			File: path,
+			Err:  fmt.Errorf("cannot read file: %w", err),
+		}
 	}
 
+	pcfg, err := FromYAML(in)
+	if err != nil {
+		var cerr *model.ConfigError
+		if errors.As(err, &cerr) {
+			cerr.File = path
+		}
+		return nil, err
+	}
+	return pcfg, nil
+}
+
+// FromJSON parses configuration from given JSON content, which is a serialized
+// plain config object, with the same keys as the YAML config file (e.g.,
+// {"default":"none","enable":["max-len"]}). Unknown keys are not allowed.
+//
+// The returned error, if any, is a [model.ConfigError].
+func FromJSON(in []byte) (*PlainConfig, error) {
 	raw := PlainConfig{}
-	if err := yaml.Unmarshal(in, &raw); err != nil {
-		return nil, fmt.Errorf("cannot parse config from YAML file: %w", err)
+	dec := json.NewDecoder(bytes.NewReader(in))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&raw); err != nil {
+		return nil, &model.ConfigError{
+			Err: fmt.Errorf("cannot parse config from JSON: %w", err),
+		}
 	}
 
 	if raw.Version != nil && !strings.HasPrefix(*raw.Version, "1.") {
-		return nil, fmt.Errorf("unsupported config version: %s", *raw.Version)
+		return nil, &model.ConfigError{
+			Err: fmt.Errorf("unsupported config version: %s", *raw.Version),
+		}
 	}
 
 	return &raw, nil
 }
+
+var yamlErrorLineNumberPattern = regexp.MustCompile(`\bline (\d+):`)
+
+// getYAMLErrorLine returns the line number mentioned in the given YAML
+// decoding error, or zero if there is none.
+func getYAMLErrorLine(err error) int {
+	m := yamlErrorLineNumberPattern.FindStringSubmatch(err.Error())
+	if m == nil {
+		return 0
+	}
+	line, _ := strconv.Atoi(m[1])
+	return line
+}
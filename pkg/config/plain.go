@@ -1,10 +1,12 @@
 package config
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"regexp"
 	"slices"
+	"strings"
 
 	"github.com/godoc-lint/godoc-lint/pkg/model"
 )
@@ -23,35 +25,129 @@ type PlainConfig struct {
 
 // PlainRuleOptions represents the plain rule options as users would provide via
 // a config file (e.g., a YAML file).
+//
+// The options are held by [PlainRuleOptions.Values], and are decoded by the
+// checkers that cover the rule prefixing the option names (See
+// [model.ConfigurableChecker]). The other fields are only kept for
+// compatibility with the earlier versions, where options were fixed, and are
+// never set when the options are parsed from a config file.
 type PlainRuleOptions struct {
-	MaxLenLength                     *uint    `yaml:"max-len/length" mapstructure:"max-len/length"`
-	MaxLenIncludeTests               *bool    `yaml:"max-len/include-tests" mapstructure:"max-len/include-tests"`
-	MaxLenIgnorePatterns             []string `yaml:"max-len/ignore-patterns" mapstructure:"max-len/ignore-patterns"`
-	PkgDocIncludeTests               *bool    `yaml:"pkg-doc/include-tests" mapstructure:"pkg-doc/include-tests"`
-	SinglePkgDocIncludeTests         *bool    `yaml:"single-pkg-doc/include-tests" mapstructure:"single-pkg-doc/include-tests"`
-	RequirePkgDocIncludeTests        *bool    `yaml:"require-pkg-doc/include-tests" mapstructure:"require-pkg-doc/include-tests"`
-	RequireDocIncludeTests           *bool    `yaml:"require-doc/include-tests" mapstructure:"require-doc/include-tests"`
-	RequireDocIgnoreExported         *bool    `yaml:"require-doc/ignore-exported" mapstructure:"require-doc/ignore-exported"`
-	RequireDocIgnoreUnexported       *bool    `yaml:"require-doc/ignore-unexported" mapstructure:"require-doc/ignore-unexported"`
-	StartWithNameIncludeTests        *bool    `yaml:"start-with-name/include-tests" mapstructure:"start-with-name/include-tests"`
-	StartWithNameIncludeUnexported   *bool    `yaml:"start-with-name/include-unexported" mapstructure:"start-with-name/include-unexported"`
-	RequireStdlibDoclinkIncludeTests *bool    `yaml:"require-stdlib-doclink/include-tests" mapstructure:"require-stdlib-doclink/include-tests"`
-	NoUnusedLinkIncludeTests         *bool    `yaml:"no-unused-link/include-tests" mapstructure:"no-unused-link/include-tests"`
+	// Values maps option names (e.g., "max-len/length") to their values.
+	Values map[string]any `yaml:",inline" mapstructure:",remain"`
+
+	// Deprecated: Use the "max-len/length" option in Values instead.
+	MaxLenLength *uint `yaml:"-" mapstructure:"-"`
+	// Deprecated: Use the "max-len/include-tests" option in Values instead.
+	MaxLenIncludeTests *bool `yaml:"-" mapstructure:"-"`
+	// Deprecated: Use the "max-len/ignore-patterns" option in Values instead.
+	MaxLenIgnorePatterns []string `yaml:"-" mapstructure:"-"`
+	// Deprecated: Use the "pkg-doc/include-tests" option in Values instead.
+	PkgDocIncludeTests *bool `yaml:"-" mapstructure:"-"`
+	// Deprecated: Use the "single-pkg-doc/include-tests" option in Values
+	// instead.
+	SinglePkgDocIncludeTests *bool `yaml:"-" mapstructure:"-"`
+	// Deprecated: Use the "require-pkg-doc/include-tests" option in Values
+	// instead.
+	RequirePkgDocIncludeTests *bool `yaml:"-" mapstructure:"-"`
+	// Deprecated: Use the "require-doc/include-tests" option in Values
+	// instead.
+	RequireDocIncludeTests *bool `yaml:"-" mapstructure:"-"`
+	// Deprecated: Use the "require-doc/ignore-exported" option in Values
+	// instead.
+	RequireDocIgnoreExported *bool `yaml:"-" mapstructure:"-"`
+	// Deprecated: Use the "require-doc/ignore-unexported" option in Values
+	// instead.
+	RequireDocIgnoreUnexported *bool `yaml:"-" mapstructure:"-"`
+	// Deprecated: Use the "start-with-name/include-tests" option in Values
+	// instead.
+	StartWithNameIncludeTests *bool `yaml:"-" mapstructure:"-"`
+	// Deprecated: Use the "start-with-name/include-unexported" option in
+	// Values instead.
+	StartWithNameIncludeUnexported *bool `yaml:"-" mapstructure:"-"`
+	// Deprecated: Use the "require-stdlib-doclink/include-tests" option in
+	// Values instead.
+	RequireStdlibDoclinkIncludeTests *bool `yaml:"-" mapstructure:"-"`
+	// Deprecated: Use the "no-unused-link/include-tests" option in Values
+	// instead.
+	NoUnusedLinkIncludeTests *bool `yaml:"-" mapstructure:"-"`
+}
+
+// Map returns all the options, mapped by name. The deprecated fields that are
+// set take precedence over the same options in [PlainRuleOptions.Values].
+//
+// It is safe to call on a nil receiver, which has no options.
+func (o *PlainRuleOptions) Map() map[string]any {
+	if o == nil {
+		return nil
+	}
+
+	result := make(map[string]any, len(o.Values))
+	for name, value := range o.Values {
+		result[name] = value
+	}
+	set := func(name string, value any, ok bool) {
+		if ok {
+			result[name] = value
+		}
+	}
+	set("max-len/length", deref(o.MaxLenLength), o.MaxLenLength != nil)
+	set("max-len/include-tests", deref(o.MaxLenIncludeTests), o.MaxLenIncludeTests != nil)
+	set("max-len/ignore-patterns", o.MaxLenIgnorePatterns, o.MaxLenIgnorePatterns != nil)
+	set("pkg-doc/include-tests", deref(o.PkgDocIncludeTests), o.PkgDocIncludeTests != nil)
+	set("single-pkg-doc/include-tests", deref(o.SinglePkgDocIncludeTests), o.SinglePkgDocIncludeTests != nil)
+	set("require-pkg-doc/include-tests", deref(o.RequirePkgDocIncludeTests), o.RequirePkgDocIncludeTests != nil)
+	set("require-doc/include-tests", deref(o.RequireDocIncludeTests), o.RequireDocIncludeTests != nil)
+	set("require-doc/ignore-exported", deref(o.RequireDocIgnoreExported), o.RequireDocIgnoreExported != nil)
+	set("require-doc/ignore-unexported", deref(o.RequireDocIgnoreUnexported), o.RequireDocIgnoreUnexported != nil)
+	set("start-with-name/include-tests", deref(o.StartWithNameIncludeTests), o.StartWithNameIncludeTests != nil)
+	set("start-with-name/include-unexported", deref(o.StartWithNameIncludeUnexported), o.StartWithNameIncludeUnexported != nil)
+	set("require-stdlib-doclink/include-tests", deref(o.RequireStdlibDoclinkIncludeTests), o.RequireStdlibDoclinkIncludeTests != nil)
+	set("no-unused-link/include-tests", deref(o.NoUnusedLinkIncludeTests), o.NoUnusedLinkIncludeTests != nil)
+	return result
+}
+
+// MarshalJSON implements the [json.Marshaler] interface, where the options are
+// written as a JSON object, just like in config files.
+func (o *PlainRuleOptions) MarshalJSON() ([]byte, error) {
+	return json.Marshal(o.Map())
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler] interface, where the options
+// are read from a JSON object, just like in config files.
+func (o *PlainRuleOptions) UnmarshalJSON(in []byte) error {
+	return json.Unmarshal(in, &o.Values)
 }
 
-// Validate validates the plain configuration.
+// deref returns the value of the given pointer, or the zero value if it is nil.
+func deref[T any](v *T) T {
+	if v == nil {
+		var zero T
+		return zero
+	}
+	return *v
+}
+
+// Validate validates the plain configuration, where rule names are checked
+// against the built-in rules. To also allow custom rules, use
+// [PlainConfig.ValidateWithRules].
 func (pcfg *PlainConfig) Validate() error {
+	return pcfg.ValidateWithRules(model.AllRules)
+}
+
+// ValidateWithRules validates the plain configuration, where rule names are
+// checked against the given set of rules.
+func (pcfg *PlainConfig) ValidateWithRules(rules model.RuleSet) error {
 	var errs []error
 
 	if pcfg.Default != nil && !slices.Contains(model.DefaultSetValues, model.DefaultSet(*pcfg.Default)) {
 		errs = append(errs, fmt.Errorf("invalid default set %q; must be one of %q", *pcfg.Default, model.DefaultSetValues))
 	}
 
-	if invalids := getInvalidRules(pcfg.Enable); len(invalids) > 0 {
+	if invalids := getInvalidRules(pcfg.Enable, rules); len(invalids) > 0 {
 		errs = append(errs, fmt.Errorf("invalid rule name(s) to enable: %q", invalids))
 	}
 
-	if invalids := getInvalidRules(pcfg.Disable); len(invalids) > 0 {
+	if invalids := getInvalidRules(pcfg.Disable, rules); len(invalids) > 0 {
 		errs = append(errs, fmt.Errorf("invalid rule name(s) to disable: %q", invalids))
 	}
 
@@ -65,10 +161,12 @@ func (pcfg *PlainConfig) Validate() error {
 		errs = append(errs, fmt.Errorf("invalid exclusion pattern(s): %q", invalids))
 	}
 
-	if pcfg.Options != nil {
-		if invalids := getInvalidRegexps(pcfg.Options.MaxLenIgnorePatterns); len(invalids) > 0 {
-			errs = append(errs, fmt.Errorf("invalid max-len ignore pattern(s): %q", invalids))
-		}
+	if invalids := getUnknownOptionNames(pcfg.Options.Map(), rules); len(invalids) > 0 {
+		errs = append(errs, fmt.Errorf("unknown option(s): %q", invalids))
+	}
+
+	if _, err := decodeCoverageOptions(pcfg.Options.Map()); err != nil {
+		errs = append(errs, err)
 	}
 
 	if len(errs) > 0 {
@@ -77,16 +175,31 @@ func (pcfg *PlainConfig) Validate() error {
 	return nil
 }
 
-func getInvalidRules(names []string) []string {
+func getInvalidRules(names []string, rules model.RuleSet) []string {
 	invalids := make([]string, 0, len(names))
 	for _, element := range names {
-		if !model.AllRules.Has(model.Rule(element)) {
+		if !rules.Has(model.Rule(element)) {
 			invalids = append(invalids, element)
 		}
 	}
 	return invalids
 }
 
+// getUnknownOptionNames returns the names of the options, which are not
+// prefixed with any of the given rules, or the coverage prefix.
+func getUnknownOptionNames(options map[string]any, knownRules model.RuleSet) []string {
+	var unknowns []string
+	for name := range options {
+		prefix, _, _ := strings.Cut(name, "/")
+		if prefix == coveragePrefix || knownRules.Has(model.Rule(prefix)) {
+			continue
+		}
+		unknowns = append(unknowns, name)
+	}
+	slices.Sort(unknowns)
+	return unknowns
+}
+
 func getInvalidRegexps(values []string) []string {
 	invalids := make([]string, 0, len(values))
 	for _, element := range values {
@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/godoc-lint/godoc-lint/pkg/config"
+	"github.com/godoc-lint/godoc-lint/pkg/model"
 )
 
 func TestValidate(t *testing.T) {
@@ -32,7 +33,10 @@ func TestValidate(t *testing.T) {
 				Include: []string{"(", ")"},
 				Exclude: []string{"(", ")"},
 				Options: &config.PlainRuleOptions{
-					MaxLenIgnorePatterns: []string{"(", "^foo$", ")"},
+					Values: map[string]any{
+						"foo/bar":      0,
+						"coverage/min": 100.5,
+					},
 				},
 			},
 			wantErr: []string{
@@ -41,7 +45,8 @@ func TestValidate(t *testing.T) {
 				`invalid rule name(s) to disable: ["foo" "bar" "baz"]`,
 				`invalid inclusion pattern(s): ["(" ")"]`,
 				`invalid exclusion pattern(s): ["(" ")"]`,
-				`invalid max-len ignore pattern(s): ["(" ")"]`,
+				`unknown option(s): ["foo/bar"]`,
+				`invalid coverage minimum 100.5; must be between 0 and 100`,
 			},
 		},
 	}
@@ -58,6 +63,50 @@ func TestValidate(t *testing.T) {
 	}
 }
 
+func TestValidateWithRules(t *testing.T) {
+	pcfg := &config.PlainConfig{
+		Enable:  []string{"max-len", "foo"},
+		Disable: []string{"bar"},
+	}
+
+	require.ErrorContains(t, pcfg.Validate(), `invalid rule name(s) to enable: ["foo"]`)
+
+	rules := model.AllRules.Add("foo", "bar")
+	require.NoError(t, pcfg.ValidateWithRules(rules))
+}
+
+func TestPlainRuleOptions(t *testing.T) {
+	require := require.New(t)
+
+	fromYAML, err := config.FromYAML([]byte("options:\n  max-len/length: 100\n  foo/bar: baz\n"))
+	require.NoError(err)
+	fromJSON, err := config.FromJSON([]byte(`{"options":{"max-len/length":100,"foo/bar":"baz"}}`))
+	require.NoError(err)
+	require.Equal(map[string]any{"max-len/length": 100, "foo/bar": "baz"}, fromYAML.Options.Values)
+	require.Equal(map[string]any{"max-len/length": float64(100), "foo/bar": "baz"}, fromJSON.Options.Values)
+
+	// The deprecated fields take precedence over the same options in Values.
+	options := &config.PlainRuleOptions{
+		Values: map[string]any{
+			"max-len/length":        100,
+			"max-len/include-tests": true,
+			"coverage/min":          50.0,
+		},
+		MaxLenLength:               ptr[uint](120),
+		RequireDocIgnoreUnexported: ptr(false),
+	}
+	require.Equal(map[string]any{
+		"max-len/length":                uint(120),
+		"max-len/include-tests":         true,
+		"require-doc/ignore-unexported": false,
+		"coverage/min":                  50.0,
+	}, options.Map())
+
+	var nilOptions *config.PlainRuleOptions
+	require.Nil(nilOptions.Map())
+	require.NoError((&config.PlainConfig{Options: options}).Validate())
+}
+
 func ptr[T any](v T) *T {
 	return &v
 }
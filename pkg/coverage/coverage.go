@@ -0,0 +1,191 @@
+// Package coverage provides computation of the documentation coverage, based
+// on the symbols found by the inspector.
+package coverage
+
+import (
+	"cmp"
+	"encoding/json"
+	"go/ast"
+	"slices"
+
+	"github.com/godoc-lint/godoc-lint/pkg/model"
+)
+
+// Kind is the enum type for the symbol kinds, as reported in the coverage.
+type Kind string
+
+const (
+	// KindFunc represents functions.
+	KindFunc Kind = "func"
+	// KindMethod represents methods.
+	KindMethod Kind = "method"
+	// KindType represents types.
+	KindType Kind = "type"
+	// KindConst represents constants.
+	KindConst Kind = "const"
+	// KindVar represents variables.
+	KindVar Kind = "var"
+)
+
+// KindValues holds the valid values for Kind, in the reporting order.
+var KindValues = []Kind{KindFunc, KindMethod, KindType, KindConst, KindVar}
+
+// Counts holds the number of documented symbols among a number of symbols.
+type Counts struct {
+	// Documented is the number of documented symbols.
+	Documented int
+
+	// Total is the number of all symbols.
+	Total int
+}
+
+// Percent returns the percentage of documented symbols. If there is no symbol,
+// it returns 100.
+func (c Counts) Percent() float64 {
+	if c.Total == 0 {
+		return 100
+	}
+	return 100 * float64(c.Documented) / float64(c.Total)
+}
+
+// MarshalJSON implements the [json.Marshaler] interface.
+func (c Counts) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Documented int     `json:"documented"`
+		Total      int     `json:"total"`
+		Percent    float64 `json:"percent"`
+	}{c.Documented, c.Total, c.Percent()})
+}
+
+func (c Counts) add(o Counts) Counts {
+	return Counts{Documented: c.Documented + o.Documented, Total: c.Total + o.Total}
+}
+
+// Coverage holds the documentation coverage of a set of symbols.
+type Coverage struct {
+	// Exported holds the counts of exported symbols.
+	Exported Counts `json:"exported"`
+
+	// Unexported holds the counts of unexported symbols.
+	Unexported Counts `json:"unexported"`
+
+	// All holds the counts of all symbols.
+	All Counts `json:"all"`
+
+	// Kinds holds the counts of all symbols, by their kind. Kinds without any
+	// symbol are omitted.
+	Kinds map[Kind]Counts `json:"kinds"`
+}
+
+func (c *Coverage) add(kind Kind, exported bool, n Counts) {
+	if exported {
+		c.Exported = c.Exported.add(n)
+	} else {
+		c.Unexported = c.Unexported.add(n)
+	}
+	c.All = c.All.add(n)
+	if c.Kinds == nil {
+		c.Kinds = map[Kind]Counts{}
+	}
+	c.Kinds[kind] = c.Kinds[kind].add(n)
+}
+
+func (c *Coverage) merge(o Coverage) {
+	c.Exported = c.Exported.add(o.Exported)
+	c.Unexported = c.Unexported.add(o.Unexported)
+	c.All = c.All.add(o.All)
+	for k, n := range o.Kinds {
+		if c.Kinds == nil {
+			c.Kinds = map[Kind]Counts{}
+		}
+		c.Kinds[k] = c.Kinds[k].add(n)
+	}
+}
+
+// Package holds the documentation coverage of a package.
+type Package struct {
+	// Path is the package path.
+	Path string `json:"path"`
+
+	Coverage
+}
+
+// Report holds the documentation coverage of a number of packages.
+type Report struct {
+	// Packages holds the coverage of packages, sorted by their path.
+	Packages []Package `json:"packages"`
+
+	// Total is the overall coverage of all packages.
+	Total Coverage `json:"total"`
+}
+
+// Add adds the symbols declared in the given inspected files to the coverage
+// of the given package.
+func (r *Report) Add(pkgPath string, files []*model.FileInspection) {
+	var c Coverage
+	for _, fi := range files {
+		if fi == nil {
+			continue
+		}
+		for _, decl := range fi.SymbolDecl {
+			kind, ok := kindOf(decl)
+			if !ok || decl.Name == "_" {
+				continue
+			}
+			n := Counts{Total: 1}
+			if IsDocumented(decl) {
+				n.Documented = 1
+			}
+			c.add(kind, IsExported(decl), n)
+		}
+	}
+
+	r.Total.merge(c)
+
+	ix, found := slices.BinarySearchFunc(r.Packages, pkgPath, func(p Package, path string) int {
+		return cmp.Compare(p.Path, path)
+	})
+	if found {
+		r.Packages[ix].merge(c)
+		return
+	}
+	r.Packages = slices.Insert(r.Packages, ix, Package{Path: pkgPath, Coverage: c})
+}
+
+// IsDocumented determines whether the given symbol has a (non-empty) godoc,
+// either directly, as a trailing comment, or via its parent declaration.
+func IsDocumented(decl model.SymbolDecl) bool {
+	for _, cg := range []*model.CommentGroup{decl.Doc, decl.TrailingDoc, decl.ParentDoc} {
+		if cg != nil && cg.Text != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// IsExported determines whether the given symbol is exported. A method is
+// considered exported (in terms of godoc visibility) only if both the method
+// name and the base type name are exported.
+func IsExported(decl model.SymbolDecl) bool {
+	if decl.IsMethod && decl.MethodRecvBaseTypeName != "" {
+		return ast.IsExported(decl.Name) && ast.IsExported(decl.MethodRecvBaseTypeName)
+	}
+	return ast.IsExported(decl.Name)
+}
+
+func kindOf(decl model.SymbolDecl) (Kind, bool) {
+	switch decl.Kind {
+	case model.SymbolDeclKindFunc:
+		if decl.IsMethod {
+			return KindMethod, true
+		}
+		return KindFunc, true
+	case model.SymbolDeclKindType:
+		return KindType, true
+	case model.SymbolDeclKindConst:
+		return KindConst, true
+	case model.SymbolDeclKindVar:
+		return KindVar, true
+	}
+	return "", false
+}
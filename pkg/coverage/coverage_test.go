@@ -0,0 +1,112 @@
+package coverage_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/godoc-lint/godoc-lint/pkg/coverage"
+	"github.com/godoc-lint/godoc-lint/pkg/model"
+)
+
+func TestReport(t *testing.T) {
+	doc := &model.CommentGroup{Text: "godoc\n"}
+	empty := &model.CommentGroup{Text: ""}
+
+	files := []*model.FileInspection{{
+		SymbolDecl: []model.SymbolDecl{
+			{Kind: model.SymbolDeclKindFunc, Name: "Foo", Doc: doc},
+			{Kind: model.SymbolDeclKindFunc, Name: "foo"},
+			{Kind: model.SymbolDeclKindFunc, Name: "Bar", IsMethod: true, MethodRecvBaseTypeName: "T", Doc: doc},
+			{Kind: model.SymbolDeclKindFunc, Name: "Bar", IsMethod: true, MethodRecvBaseTypeName: "t", Doc: empty},
+			{Kind: model.SymbolDeclKindType, Name: "T", TrailingDoc: doc},
+			{Kind: model.SymbolDeclKindConst, Name: "A", ParentDoc: doc, MultiSpecDecl: true},
+			{Kind: model.SymbolDeclKindConst, Name: "B", MultiSpecDecl: true, MultiSpecIndex: 1},
+			{Kind: model.SymbolDeclKindVar, Name: "_"},
+			{Kind: model.SymbolDeclKindBad},
+		},
+	}, nil}
+
+	r := &coverage.Report{}
+	r.Add("example.com/b", files)
+	r.Add("example.com/a", []*model.FileInspection{{
+		SymbolDecl: []model.SymbolDecl{
+			{Kind: model.SymbolDeclKindVar, Name: "V"},
+		},
+	}})
+	r.Add("example.com/a", []*model.FileInspection{{}})
+
+	require.Equal(t, &coverage.Report{
+		Packages: []coverage.Package{{
+			Path: "example.com/a",
+			Coverage: coverage.Coverage{
+				Exported: coverage.Counts{Documented: 0, Total: 1},
+				All:      coverage.Counts{Documented: 0, Total: 1},
+				Kinds: map[coverage.Kind]coverage.Counts{
+					coverage.KindVar: {Documented: 0, Total: 1},
+				},
+			},
+		}, {
+			Path: "example.com/b",
+			Coverage: coverage.Coverage{
+				Exported:   coverage.Counts{Documented: 4, Total: 5},
+				Unexported: coverage.Counts{Documented: 0, Total: 2},
+				All:        coverage.Counts{Documented: 4, Total: 7},
+				Kinds: map[coverage.Kind]coverage.Counts{
+					coverage.KindFunc:   {Documented: 1, Total: 2},
+					coverage.KindMethod: {Documented: 1, Total: 2},
+					coverage.KindType:   {Documented: 1, Total: 1},
+					coverage.KindConst:  {Documented: 1, Total: 2},
+				},
+			},
+		}},
+		Total: coverage.Coverage{
+			Exported:   coverage.Counts{Documented: 4, Total: 6},
+			Unexported: coverage.Counts{Documented: 0, Total: 2},
+			All:        coverage.Counts{Documented: 4, Total: 8},
+			Kinds: map[coverage.Kind]coverage.Counts{
+				coverage.KindFunc:   {Documented: 1, Total: 2},
+				coverage.KindMethod: {Documented: 1, Total: 2},
+				coverage.KindType:   {Documented: 1, Total: 1},
+				coverage.KindConst:  {Documented: 1, Total: 2},
+				coverage.KindVar:    {Documented: 0, Total: 1},
+			},
+		},
+	}, r)
+
+	var text bytes.Buffer
+	require.NoError(t, r.WriteText(&text))
+	require.Equal(t, `PACKAGE        EXPORTED      UNEXPORTED    ALL
+example.com/a    0.0% (0/1)  -               0.0% (0/1)
+example.com/b   80.0% (4/5)    0.0% (0/2)   57.1% (4/7)
+total           66.7% (4/6)    0.0% (0/2)   50.0% (4/8)
+
+KIND    ALL
+func     50.0% (1/2)
+method   50.0% (1/2)
+type    100.0% (1/1)
+const    50.0% (1/2)
+var       0.0% (0/1)
+`, text.String())
+}
+
+func TestCountsJSON(t *testing.T) {
+	tests := []struct {
+		counts coverage.Counts
+		want   string
+	}{{
+		counts: coverage.Counts{},
+		want:   `{"documented":0,"total":0,"percent":100}`,
+	}, {
+		counts: coverage.Counts{Documented: 1, Total: 4},
+		want:   `{"documented":1,"total":4,"percent":25}`,
+	}}
+
+	for _, tt := range tests {
+		b, err := json.Marshal(tt.counts)
+		require.NoError(t, err)
+		require.JSONEq(t, tt.want, string(b))
+	}
+}
@@ -0,0 +1,36 @@
+package coverage
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+// WriteText writes the report as human-readable text tables; one for the
+// packages, and another for the symbol kinds.
+func (r *Report) WriteText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "PACKAGE\tEXPORTED\tUNEXPORTED\tALL")
+	for _, p := range r.Packages {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", p.Path, formatCounts(p.Exported), formatCounts(p.Unexported), formatCounts(p.All))
+	}
+	fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", "total", formatCounts(r.Total.Exported), formatCounts(r.Total.Unexported), formatCounts(r.Total.All))
+
+	fmt.Fprintln(tw)
+	fmt.Fprintln(tw, "KIND\tALL")
+	for _, k := range KindValues {
+		if n, ok := r.Total.Kinds[k]; ok {
+			fmt.Fprintf(tw, "%s\t%s\n", k, formatCounts(n))
+		}
+	}
+
+	return tw.Flush()
+}
+
+func formatCounts(c Counts) string {
+	if c.Total == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%5.1f%% (%d/%d)", c.Percent(), c.Documented, c.Total)
+}
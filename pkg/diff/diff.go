@@ -0,0 +1,281 @@
+// Package diff provides utilities to find out the changed lines of files, either
+// from a unified diff (patch) or from a local Git repository.
+package diff
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// LineRange represents a range of lines, where both ends are inclusive and
+// 1-based.
+type LineRange struct {
+	// Start is the first line of the range.
+	Start int
+
+	// End is the last line of the range.
+	End int
+}
+
+// wholeFile is the line range representing all lines of a file.
+var wholeFile = LineRange{Start: 1, End: math.MaxInt}
+
+// Changes holds the added or modified lines of files, keyed by their absolute
+// path.
+type Changes struct {
+	files map[string][]LineRange
+}
+
+// Files returns the absolute paths of the changed files, sorted.
+func (c *Changes) Files() []string {
+	files := make([]string, 0, len(c.files))
+	for f := range c.files {
+		files = append(files, f)
+	}
+	slices.Sort(files)
+	return files
+}
+
+// Lines returns the changed line ranges of the given file (absolute path), or
+// nil if the file is not changed.
+func (c *Changes) Lines(path string) []LineRange {
+	return c.files[filepath.Clean(path)]
+}
+
+// Intersects determines whether the given line range (both ends inclusive) of
+// the given file (absolute path) intersects any changed lines.
+func (c *Changes) Intersects(path string, start, end int) bool {
+	if end < start {
+		end = start
+	}
+	lines, ok := c.files[filepath.Clean(path)]
+	if !ok {
+		if resolved, err := filepath.EvalSymlinks(path); err == nil {
+			lines = c.files[resolved]
+		}
+	}
+	for _, r := range lines {
+		if r.Start <= end && start <= r.End {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Changes) add(path string, r LineRange) {
+	if c.files == nil {
+		c.files = map[string][]LineRange{}
+	}
+	lines := c.files[path]
+	if n := len(lines); n > 0 && lines[n-1].End+1 >= r.Start {
+		lines[n-1].End = max(lines[n-1].End, r.End)
+	} else {
+		lines = append(lines, r)
+	}
+	c.files[path] = lines
+}
+
+// hunkHeader matches hunk headers, like "@@ -1,2 +3,4 @@".
+var hunkHeader = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@`)
+
+// Parse parses the given unified diff and returns the added or modified lines
+// (i.e., on the new side). Relative file paths in the diff are resolved against
+// the given base directory. The "b/" prefix of the new file paths is trimmed
+// for Git diffs (i.e., with a "diff --git" header), or when the old file path
+// has the "a/" prefix, as in diffs with the same prefixes as Git.
+func Parse(r io.Reader, baseDir string) (*Changes, error) {
+	c := &Changes{}
+
+	var path string // Empty when the new side of the file is missing.
+	var oldLeft, newLeft, newLine int
+
+	// Whether the "b/" prefix of the next new file path should be trimmed.
+	var gitHeader, oldPrefixed bool
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 16*1024*1024)
+	for n := 1; scanner.Scan(); n++ {
+		line := scanner.Text()
+
+		if oldLeft > 0 || newLeft > 0 {
+			switch {
+			case strings.HasPrefix(line, "+"):
+				if newLeft == 0 {
+					return nil, fmt.Errorf("line %d: unexpected added line", n)
+				}
+				if path != "" {
+					c.add(path, LineRange{Start: newLine, End: newLine})
+				}
+				newLine++
+				newLeft--
+			case strings.HasPrefix(line, "-"):
+				if oldLeft == 0 {
+					return nil, fmt.Errorf("line %d: unexpected removed line", n)
+				}
+				oldLeft--
+			case strings.HasPrefix(line, " "), line == "":
+				if oldLeft == 0 || newLeft == 0 {
+					return nil, fmt.Errorf("line %d: unexpected context line", n)
+				}
+				newLine++
+				oldLeft--
+				newLeft--
+			case strings.HasPrefix(line, `\`):
+				// "\ No newline at end of file"
+			default:
+				return nil, fmt.Errorf("line %d: malformed hunk line", n)
+			}
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, "diff "):
+			gitHeader = strings.HasPrefix(line, "diff --git ")
+		case strings.HasPrefix(line, "--- "):
+			p, err := parseFileName(line[len("--- "):])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", n, err)
+			}
+			oldPrefixed = strings.HasPrefix(p, "a/")
+		case strings.HasPrefix(line, "+++ "):
+			p, err := parseFileName(line[len("+++ "):])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", n, err)
+			}
+			trim := gitHeader || oldPrefixed
+			gitHeader, oldPrefixed = false, false
+			path = ""
+			if p != "/dev/null" {
+				if trim {
+					p = strings.TrimPrefix(p, "b/")
+				}
+				if !filepath.IsAbs(p) {
+					p = filepath.Join(baseDir, filepath.FromSlash(p))
+				}
+				path = filepath.Clean(p)
+			}
+		case strings.HasPrefix(line, "@@ "):
+			m := hunkHeader.FindStringSubmatch(line)
+			if m == nil {
+				return nil, fmt.Errorf("line %d: malformed hunk header", n)
+			}
+			oldLeft = atoiOr(m[2], 1)
+			newLine = atoiOr(m[3], 0)
+			newLeft = atoiOr(m[4], 1)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if oldLeft > 0 || newLeft > 0 {
+		return nil, errors.New("unexpected end of diff")
+	}
+	return c, nil
+}
+
+// ParseFile parses the given unified diff (patch) file. Relative file paths in
+// the diff are resolved against the given base directory.
+func ParseFile(path, baseDir string) (*Changes, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open patch file: %w", err)
+	}
+	defer f.Close()
+
+	c, err := Parse(f, baseDir)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse patch file %q: %w", path, err)
+	}
+	return c, nil
+}
+
+// FromGitRev returns the lines added or modified in the working tree of the
+// Git repository containing the given directory, relative to the given
+// revision. Untracked (but not ignored) files are considered as entirely
+// changed.
+func FromGitRev(dir, rev string) (*Changes, error) {
+	if strings.HasPrefix(rev, "-") {
+		return nil, fmt.Errorf("invalid revision %q", rev)
+	}
+
+	top, err := git(dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, err
+	}
+	root := strings.TrimSpace(string(top))
+
+	out, err := git(root, "diff", "-U0", "--no-color", "--no-ext-diff", "--no-renames", "--src-prefix=a/", "--dst-prefix=b/", rev, "--")
+	if err != nil {
+		return nil, err
+	}
+	c, err := Parse(bytes.NewReader(out), root)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse git diff output: %w", err)
+	}
+
+	untracked, err := git(root, "ls-files", "-z", "--others", "--exclude-standard")
+	if err != nil {
+		return nil, err
+	}
+	for p := range strings.SplitSeq(string(untracked), "\x00") {
+		if p != "" {
+			c.add(filepath.Join(root, filepath.FromSlash(p)), wholeFile)
+		}
+	}
+	return c, nil
+}
+
+// git runs the git command with the given arguments in the given directory and
+// returns its standard output.
+func git(dir string, args ...string) ([]byte, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("git %s: %w: %s", args[0], err, msg)
+		}
+		return nil, fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return out, nil
+}
+
+// parseFileName parses the file name in "---" or "+++" lines, which may be
+// quoted (by Git) or followed by a tab and a timestamp.
+func parseFileName(s string) (string, error) {
+	if strings.HasPrefix(s, `"`) {
+		q, err := strconv.QuotedPrefix(s)
+		if err != nil {
+			return "", fmt.Errorf("malformed quoted file name: %w", err)
+		}
+		return strconv.Unquote(q)
+	}
+	if before, _, ok := strings.Cut(s, "\t"); ok {
+		s = before
+	}
+	return strings.TrimRight(s, " "), nil
+}
+
+func atoiOr(s string, fallback int) int {
+	if s == "" {
+		return fallback
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return fallback
+	}
+	return v
+}
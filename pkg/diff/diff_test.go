@@ -0,0 +1,226 @@
+package diff_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/godoc-lint/godoc-lint/pkg/diff"
+)
+
+func TestParse(t *testing.T) {
+	baseDir := filepath.FromSlash("/base")
+
+	tests := []struct {
+		name    string
+		diff    string
+		want    map[string][]diff.LineRange
+		wantErr bool
+	}{{
+		name: "empty",
+		diff: "",
+		want: map[string][]diff.LineRange{},
+	}, {
+		name: "zero context",
+		diff: `diff --git a/foo.go b/foo.go
+index 1111111..2222222 100644
+--- a/foo.go
++++ b/foo.go
+@@ -3 +3,2 @@ package foo
+-// old
++// new
++// newer
+@@ -10,2 +11,0 @@ func foo() {
+-	a()
+-	b()
+@@ -20,0 +20 @@ func bar() {
++	c()
+`,
+		want: map[string][]diff.LineRange{
+			"foo.go": {{Start: 3, End: 4}, {Start: 20, End: 20}},
+		},
+	}, {
+		name: "with context",
+		diff: `--- a/foo.go
++++ b/foo.go
+@@ -1,5 +1,6 @@
+ package foo
+
+-// old
++// new
++// newer
+ func foo() {
+ }
+`,
+		want: map[string][]diff.LineRange{
+			"foo.go": {{Start: 3, End: 4}},
+		},
+	}, {
+		name: "added line looking like a file header",
+		diff: `--- a/foo.go
++++ b/foo.go
+@@ -1,0 +2,2 @@
++++ x
++--- y
+`,
+		want: map[string][]diff.LineRange{
+			"foo.go": {{Start: 2, End: 3}},
+		},
+	}, {
+		name: "new, deleted and quoted files",
+		diff: `diff --git a/sub/new.go b/sub/new.go
+new file mode 100644
+--- /dev/null
++++ b/sub/new.go	2025-01-01 00:00:00
+@@ -0,0 +1,2 @@
++package sub
++// Doc.
+\ No newline at end of file
+--- a/old.go
++++ /dev/null
+@@ -1 +0,0 @@
+-package old
+--- "a/sp\303\251cial.go"
++++ "b/sp\303\251cial.go"
+@@ -1 +1 @@
+-package a
++package b
+`,
+		want: map[string][]diff.LineRange{
+			"sub/new.go": {{Start: 1, End: 2}},
+			"spécial.go": {{Start: 1, End: 1}},
+		},
+	}, {
+		name: "non-Git prefixes",
+		diff: `--- foo.go.orig
++++ b/foo.go
+@@ -1 +1 @@
+-package a
++package b
+--- /dev/null
++++ b/bar.go
+@@ -0,0 +1 @@
++package b
+--- a/baz.go
++++ b/baz.go
+@@ -1 +1 @@
+-package a
++package b
+`,
+		want: map[string][]diff.LineRange{
+			"b/foo.go": {{Start: 1, End: 1}},
+			"b/bar.go": {{Start: 1, End: 1}},
+			"baz.go":   {{Start: 1, End: 1}},
+		},
+	}, {
+		name:    "malformed hunk header",
+		diff:    "+++ b/foo.go\n@@ -1 +x @@\n",
+		wantErr: true,
+	}, {
+		name:    "truncated hunk",
+		diff:    "+++ b/foo.go\n@@ -1 +1,2 @@\n+a\n",
+		wantErr: true,
+	}, {
+		name:    "malformed hunk line",
+		diff:    "+++ b/foo.go\n@@ -1 +1 @@\n*a\n",
+		wantErr: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := diff.Parse(strings.NewReader(tt.diff), baseDir)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			got := map[string][]diff.LineRange{}
+			for _, f := range c.Files() {
+				rel, err := filepath.Rel(baseDir, f)
+				require.NoError(t, err)
+				got[filepath.ToSlash(rel)] = c.Lines(f)
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestChangesIntersects(t *testing.T) {
+	baseDir := filepath.FromSlash("/base")
+	c, err := diff.Parse(strings.NewReader(`--- a/foo.go
++++ b/foo.go
+@@ -5,0 +5,2 @@
++a
++b
+`), baseDir)
+	require.NoError(t, err)
+
+	path := filepath.Join(baseDir, "foo.go")
+	require.True(t, c.Intersects(path, 5, 5))
+	require.True(t, c.Intersects(path, 6, 0))
+	require.True(t, c.Intersects(path, 1, 5))
+	require.True(t, c.Intersects(path, 6, 10))
+	require.False(t, c.Intersects(path, 4, 4))
+	require.False(t, c.Intersects(path, 7, 10))
+	require.False(t, c.Intersects(filepath.Join(baseDir, "bar.go"), 5, 5))
+}
+
+func TestFromGitRev(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not available")
+	}
+
+	dir := t.TempDir()
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_CONFIG_GLOBAL="+os.DevNull,
+			"GIT_CONFIG_NOSYSTEM=1",
+			"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+		)
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	write := func(name, content string) {
+		t.Helper()
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+
+	run("init", "-q")
+	write("a.go", "package a\n\nfunc A() {}\n")
+	write(".gitignore", "ignored.go\n")
+	run("add", ".")
+	run("commit", "-q", "-m", "initial")
+
+	write("a.go", "package a\n\n// A is a function.\nfunc A() {}\n")
+	write("sub/b.go", "package sub\n")
+	write("ignored.go", "package a\n")
+
+	c, err := diff.FromGitRev(dir, "HEAD")
+	require.NoError(t, err)
+
+	root, err := filepath.EvalSymlinks(dir)
+	require.NoError(t, err)
+
+	require.Equal(t, []string{
+		filepath.Join(root, "a.go"),
+		filepath.Join(root, "sub", "b.go"),
+	}, c.Files())
+	require.Equal(t, []diff.LineRange{{Start: 3, End: 3}}, c.Lines(filepath.Join(root, "a.go")))
+	require.True(t, c.Intersects(filepath.Join(root, "sub", "b.go"), 1, 1))
+
+	_, err = diff.FromGitRev(dir, "no-such-rev")
+	require.Error(t, err)
+
+	_, err = diff.FromGitRev(dir, "--output=x")
+	require.Error(t, err)
+}
@@ -0,0 +1,407 @@
+// Package dump provides a stable, versioned JSON representation of the
+// inspector model (i.e., package godocs, symbol declarations and disable
+// directives), to be consumed by external tools.
+package dump
+
+import (
+	"go/ast"
+	"go/doc/comment"
+	"go/token"
+	"path/filepath"
+	"strings"
+
+	"github.com/godoc-lint/godoc-lint/pkg/model"
+	"github.com/godoc-lint/godoc-lint/pkg/util"
+)
+
+// Version is the current version of the dump format. It is incremented on
+// backward incompatible changes.
+const Version = "1"
+
+// Document is the root of the dump.
+type Document struct {
+	// Version is the dump format version.
+	Version string `json:"version"`
+
+	// Packages holds the dumped packages.
+	Packages []Package `json:"packages"`
+
+	baseDir string
+}
+
+// Package represents a dumped package.
+type Package struct {
+	// Path is the package path.
+	Path string `json:"path"`
+
+	// Name is the package name.
+	Name string `json:"name"`
+
+	// Files holds the inspected files of the package.
+	Files []File `json:"files"`
+}
+
+// File represents an inspected file.
+type File struct {
+	// Path is the file path, relative to the base directory (Unix style), or
+	// absolute if the file is not under the base directory.
+	Path string `json:"path"`
+
+	// Disabled holds the rules disabled via top-level directives.
+	Disabled Directives `json:"disabled"`
+
+	// PackageDoc is the package godoc, if any.
+	PackageDoc *CommentGroup `json:"package_doc,omitempty"`
+
+	// Symbols holds the top-level symbol declarations.
+	Symbols []Symbol `json:"symbols"`
+}
+
+// Symbol represents a top-level symbol declaration.
+type Symbol struct {
+	// Name is the symbol name.
+	Name string `json:"name"`
+
+	// Kind is the declaration kind (i.e., "func", "const", "type", "var" or
+	// "bad").
+	Kind model.SymbolDeclKind `json:"kind"`
+
+	// Exported indicates whether the symbol is exported. Methods are exported
+	// only if their receiver base type is also exported.
+	Exported bool `json:"exported"`
+
+	// IsTypeAlias indicates whether the symbol is a type alias.
+	IsTypeAlias bool `json:"is_type_alias,omitempty"`
+
+	// IsMethod indicates whether the symbol is a method.
+	IsMethod bool `json:"is_method,omitempty"`
+
+	// Receiver is the base type name of the method receiver, if any.
+	Receiver string `json:"receiver,omitempty"`
+
+	// MultiNameDecl indicates whether the symbol is declared in a multi-name
+	// spec (e.g., "const a, b = 0, 0").
+	MultiNameDecl bool `json:"multi_name_decl,omitempty"`
+
+	// MultiNameIndex is the index of the symbol name within its spec.
+	MultiNameIndex int `json:"multi_name_index,omitempty"`
+
+	// MultiSpecDecl indicates whether the symbol is declared in a grouped
+	// declaration (i.e., with parentheses).
+	MultiSpecDecl bool `json:"multi_spec_decl,omitempty"`
+
+	// MultiSpecIndex is the index of the spec within the grouped declaration.
+	MultiSpecIndex int `json:"multi_spec_index,omitempty"`
+
+	// Range is the range of the symbol identifier.
+	Range Range `json:"range"`
+
+	// DeclRange is the range of the whole declaration (excluding the godoc).
+	DeclRange Range `json:"decl_range"`
+
+	// Doc is the symbol godoc, if any.
+	Doc *CommentGroup `json:"doc,omitempty"`
+
+	// TrailingDoc is the trailing comment group of the symbol, if any.
+	TrailingDoc *CommentGroup `json:"trailing_doc,omitempty"`
+
+	// ParentDoc is the godoc of the enclosing grouped declaration, if any.
+	ParentDoc *CommentGroup `json:"parent_doc,omitempty"`
+}
+
+// Position represents a position in a file.
+type Position struct {
+	// Line is the line number, starting at 1.
+	Line int `json:"line"`
+
+	// Column is the column number (in bytes), starting at 1.
+	Column int `json:"column"`
+
+	// Offset is the byte offset, starting at 0.
+	Offset int `json:"offset"`
+}
+
+// Range represents a range in a file.
+type Range struct {
+	// Start is the start position.
+	Start Position `json:"start"`
+
+	// End is the end position (exclusive).
+	End Position `json:"end"`
+}
+
+// CommentGroup represents a comment group (e.g., a godoc).
+type CommentGroup struct {
+	// Range is the range of the comment group.
+	Range Range `json:"range"`
+
+	// Raw is the raw comment group, including comment markers.
+	Raw string `json:"raw"`
+
+	// Text is the comment group text, without comment markers and directives.
+	Text string `json:"text"`
+
+	// Blocks holds the parsed godoc blocks.
+	Blocks []Block `json:"blocks"`
+
+	// LinkDefs holds the link definitions (e.g., "[text]: url").
+	LinkDefs []LinkDef `json:"link_defs,omitempty"`
+
+	// Disabled holds the rules disabled via directives in the comment group.
+	Disabled Directives `json:"disabled"`
+}
+
+// Directives represents the rules disabled via "//godoclint:disable"
+// directives.
+type Directives struct {
+	// All indicates whether all rules are disabled.
+	All bool `json:"all"`
+
+	// Rules holds the disabled rules, sorted.
+	Rules []model.Rule `json:"rules"`
+}
+
+// BlockKind is the enum type for the godoc block kinds.
+type BlockKind string
+
+const (
+	// BlockKindParagraph represents a paragraph.
+	BlockKindParagraph BlockKind = "paragraph"
+	// BlockKindHeading represents a heading.
+	BlockKindHeading BlockKind = "heading"
+	// BlockKindCode represents a code block.
+	BlockKindCode BlockKind = "code"
+	// BlockKindList represents a list.
+	BlockKindList BlockKind = "list"
+)
+
+// Block represents a parsed godoc block.
+type Block struct {
+	// Kind is the block kind.
+	Kind BlockKind `json:"kind"`
+
+	// Text is the plain text of paragraphs and headings, or the content of
+	// code blocks.
+	Text string `json:"text,omitempty"`
+
+	// Links holds the links in the text of paragraphs and headings.
+	Links []Link `json:"links,omitempty"`
+
+	// Items holds the items of lists.
+	Items []ListItem `json:"items,omitempty"`
+
+	// BlankBetween indicates whether list items are separated by blank lines.
+	BlankBetween bool `json:"blank_between,omitempty"`
+}
+
+// ListItem represents an item of a list block.
+type ListItem struct {
+	// Number is the item number for numbered lists, or empty for bullet lists.
+	Number string `json:"number,omitempty"`
+
+	// Blocks holds the item content.
+	Blocks []Block `json:"blocks"`
+}
+
+// Link represents a link within a text.
+type Link struct {
+	// Text is the link text.
+	Text string `json:"text"`
+
+	// URL is the link URL, for URL links (e.g., "[text]" with a link
+	// definition, or auto-linked URLs).
+	URL string `json:"url,omitempty"`
+
+	// ImportPath is the import path of doc links (e.g., "[fmt.Println]").
+	ImportPath string `json:"import_path,omitempty"`
+
+	// Recv is the receiver type of doc links to methods.
+	Recv string `json:"recv,omitempty"`
+
+	// Name is the symbol name of doc links.
+	Name string `json:"name,omitempty"`
+
+	// DocLink indicates whether the link is a doc link.
+	DocLink bool `json:"doc_link,omitempty"`
+}
+
+// LinkDef represents a link definition.
+type LinkDef struct {
+	// Text is the link text.
+	Text string `json:"text"`
+
+	// URL is the link URL.
+	URL string `json:"url"`
+
+	// Used indicates whether the link definition is used in the godoc.
+	Used bool `json:"used"`
+}
+
+// New returns a new, empty document. File paths are written relative to the
+// given base directory.
+func New(baseDir string) *Document {
+	return &Document{
+		Version:  Version,
+		Packages: []Package{},
+		baseDir:  baseDir,
+	}
+}
+
+// AddPackage adds the given package to the document. Files should be given in
+// the order they appear in the package. Files without inspection results
+// (e.g., excluded by the configuration) are skipped.
+func (d *Document) AddPackage(fset *token.FileSet, path, name string, files []*ast.File, ir *model.InspectorResult) {
+	p := Package{
+		Path:  path,
+		Name:  name,
+		Files: []File{},
+	}
+
+	for _, f := range files {
+		fi, ok := ir.Files[f]
+		if !ok || fi == nil {
+			continue
+		}
+
+		df := File{
+			Path:     filePath(d.baseDir, fset.Position(f.Pos()).Filename),
+			Disabled: newDirectives(fi.DisabledRules),
+			Symbols:  make([]Symbol, 0, len(fi.SymbolDecl)),
+		}
+		df.PackageDoc = newCommentGroup(fset, fi.PackageDoc)
+
+		for _, sd := range fi.SymbolDecl {
+			s := Symbol{
+				Name:           sd.Name,
+				Kind:           sd.Kind,
+				IsTypeAlias:    sd.IsTypeAlias,
+				IsMethod:       sd.IsMethod,
+				Receiver:       sd.MethodRecvBaseTypeName,
+				MultiNameDecl:  sd.MultiNameDecl,
+				MultiNameIndex: sd.MultiNameIndex,
+				MultiSpecDecl:  sd.MultiSpecDecl,
+				MultiSpecIndex: sd.MultiSpecIndex,
+				DeclRange:      newRange(fset, sd.Decl.Pos(), sd.Decl.End()),
+				Doc:            newCommentGroup(fset, sd.Doc),
+				TrailingDoc:    newCommentGroup(fset, sd.TrailingDoc),
+				ParentDoc:      newCommentGroup(fset, sd.ParentDoc),
+			}
+			if sd.Ident != nil {
+				s.Range = newRange(fset, sd.Ident.Pos(), sd.Ident.End())
+				s.Exported = ast.IsExported(sd.Name) && (!sd.IsMethod || sd.MethodRecvBaseTypeName == "" || ast.IsExported(sd.MethodRecvBaseTypeName))
+			}
+			df.Symbols = append(df.Symbols, s)
+		}
+
+		p.Files = append(p.Files, df)
+	}
+
+	d.Packages = append(d.Packages, p)
+}
+
+func filePath(baseDir, path string) string {
+	if baseDir == "" || !util.IsPathUnderBaseDir(baseDir, path) {
+		return filepath.ToSlash(path)
+	}
+	rel, err := filepath.Rel(baseDir, path)
+	if err != nil {
+		return filepath.ToSlash(path)
+	}
+	return filepath.ToSlash(rel)
+}
+
+func newPosition(p token.Position) Position {
+	return Position{Line: p.Line, Column: p.Column, Offset: p.Offset}
+}
+
+func newRange(fset *token.FileSet, start, end token.Pos) Range {
+	return Range{
+		Start: newPosition(fset.Position(start)),
+		End:   newPosition(fset.Position(end)),
+	}
+}
+
+func newDirectives(dr model.InspectorResultDisableRules) Directives {
+	return Directives{All: dr.All, Rules: dr.Rules.List()}
+}
+
+func newCommentGroup(fset *token.FileSet, cg *model.CommentGroup) *CommentGroup {
+	if cg == nil {
+		return nil
+	}
+
+	lines := make([]string, 0, len(cg.CG.List))
+	for _, c := range cg.CG.List {
+		lines = append(lines, c.Text)
+	}
+
+	result := &CommentGroup{
+		Range:    newRange(fset, cg.CG.Pos(), cg.CG.End()),
+		Raw:      strings.Join(lines, "\n"),
+		Text:     cg.Text,
+		Blocks:   newBlocks(cg.Parsed().Content),
+		Disabled: newDirectives(cg.DisabledRules),
+	}
+	for _, l := range cg.Parsed().Links {
+		result.LinkDefs = append(result.LinkDefs, LinkDef{Text: l.Text, URL: l.URL, Used: l.Used})
+	}
+	return result
+}
+
+func newBlocks(blocks []comment.Block) []Block {
+	result := make([]Block, 0, len(blocks))
+	for _, b := range blocks {
+		switch bt := b.(type) {
+		case *comment.Paragraph:
+			text, links := newText(bt.Text)
+			result = append(result, Block{Kind: BlockKindParagraph, Text: text, Links: links})
+		case *comment.Heading:
+			text, links := newText(bt.Text)
+			result = append(result, Block{Kind: BlockKindHeading, Text: text, Links: links})
+		case *comment.Code:
+			result = append(result, Block{Kind: BlockKindCode, Text: bt.Text})
+		case *comment.List:
+			items := make([]ListItem, 0, len(bt.Items))
+			for _, item := range bt.Items {
+				items = append(items, ListItem{Number: item.Number, Blocks: newBlocks(item.Content)})
+			}
+			result = append(result, Block{Kind: BlockKindList, Items: items, BlankBetween: bt.BlankBetween()})
+		}
+	}
+	return result
+}
+
+// newText returns the plain text of the given inline text, together with the
+// links in it.
+func newText(text []comment.Text) (string, []Link) {
+	var sb strings.Builder
+	var links []Link
+
+	var walk func([]comment.Text)
+	walk = func(text []comment.Text) {
+		for _, t := range text {
+			switch tt := t.(type) {
+			case comment.Plain:
+				sb.WriteString(string(tt))
+			case comment.Italic:
+				sb.WriteString(string(tt))
+			case *comment.Link:
+				start := sb.Len()
+				walk(tt.Text)
+				links = append(links, Link{Text: sb.String()[start:], URL: tt.URL})
+			case *comment.DocLink:
+				start := sb.Len()
+				walk(tt.Text)
+				links = append(links, Link{
+					Text:       sb.String()[start:],
+					ImportPath: tt.ImportPath,
+					Recv:       tt.Recv,
+					Name:       tt.Name,
+					DocLink:    true,
+				})
+			}
+		}
+	}
+	walk(text)
+	return sb.String(), links
+}
@@ -0,0 +1,41 @@
+package dump_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/godoc-lint/godoc-lint/pkg/config"
+	"github.com/godoc-lint/godoc-lint/pkg/dump"
+	"github.com/godoc-lint/godoc-lint/pkg/inspect"
+	"github.com/godoc-lint/godoc-lint/pkg/model"
+)
+
+func TestDocument(t *testing.T) {
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	testdir := filepath.Join(wd, "../../testdata/dump")
+
+	inspector := inspect.NewInspector(config.NewOnceConfigBuilder(config.NewConfigBuilder(testdir)))
+	ars := analysistest.Run(t, testdir, inspector.GetAnalyzer(), "./basic")
+	require.Len(t, ars, 1)
+
+	ar := ars[0]
+	result, ok := ar.Result.(*model.InspectorResult)
+	require.True(t, ok, "unknown result type")
+
+	doc := dump.New(testdir)
+	doc.AddPackage(ar.Pass.Fset, "example.com/basic", ar.Pass.Pkg.Name(), ar.Pass.Files, result)
+
+	got, err := json.MarshalIndent(doc, "", "  ")
+	require.NoError(t, err)
+
+	refFile := filepath.Join(testdir, "basic", "basic.json")
+	want, err := os.ReadFile(refFile)
+	require.NoError(t, err)
+	require.JSONEq(t, string(want), string(got))
+}
@@ -3,9 +3,7 @@ package inspect
 
 import (
 	"errors"
-	"fmt"
 	"go/ast"
-	gdc "go/doc/comment"
 	"go/token"
 	"path/filepath"
 	"reflect"
@@ -27,17 +25,15 @@ const (
 // Inspector implements the godoc-lint pre-run inspector.
 type Inspector struct {
 	cb       model.ConfigBuilder
-	exitFunc func(int, error)
+	registry model.Registry
 
 	analyzer *analysis.Analyzer
-	parser   gdc.Parser
 }
 
 // NewInspector returns a new instance of the inspector.
-func NewInspector(cb model.ConfigBuilder, exitFunc func(int, error)) *Inspector {
+func NewInspector(cb model.ConfigBuilder) *Inspector {
 	result := &Inspector{
-		cb:       cb,
-		exitFunc: exitFunc,
+		cb: cb,
 		analyzer: &analysis.Analyzer{
 			Name:       metaName,
 			Doc:        metaDoc,
@@ -49,15 +45,23 @@ func NewInspector(cb model.ConfigBuilder, exitFunc func(int, error)) *Inspector
 	return result
 }
 
+// WithRegistry sets the registry that provides the known rules, including any
+// custom rules, which can be named in disable directives. Without a registry,
+// only built-in rules are recognized.
+func (i *Inspector) WithRegistry(registry model.Registry) *Inspector {
+	i.registry = registry
+	return i
+}
+
 // GetAnalyzer returns the underlying analyzer.
 func (i *Inspector) GetAnalyzer() *analysis.Analyzer {
 	return i.analyzer
 }
 
-var (
-	topLevelOrphanCommentGroupPattern = regexp.MustCompile(`(?m)(?:^//.*\r?\n)+(?:\r?\n|\z)`)
-	disableDirectivePattern           = regexp.MustCompile(`(?m)//godoclint:disable(?: *([^\r\n]+))?\r?$`)
-)
+// disableDirectivePrefix is the prefix of the disable directives.
+const disableDirectivePrefix = "//godoclint:disable"
+
+var disableDirectivePattern = regexp.MustCompile(`(?m)//godoclint:disable(?: *([^\r\n]+))?\r?$`)
 
 func (i *Inspector) run(pass *analysis.Pass) (any, error) {
 	if len(pass.Files) == 0 {
@@ -66,40 +70,25 @@ func (i *Inspector) run(pass *analysis.Pass) (any, error) {
 
 	ft := util.GetPassFileToken(pass.Files[0], pass)
 	if ft == nil {
-		err := errors.New("cannot prepare config")
-		if i.exitFunc != nil {
-			i.exitFunc(2, err)
-		}
-		return nil, err
+		return nil, &model.InternalError{Err: errors.New("cannot prepare config: no file token for package file")}
 	}
 
 	pkgDir := filepath.Dir(ft.Name())
 	cfg, err := i.cb.GetConfig(pkgDir)
 	if err != nil {
-		if i.exitFunc != nil {
-			i.exitFunc(2, err)
-		}
-		return nil, err
+		return &model.InspectorResult{ConfigErr: err}, nil
 	}
 
-	inspect := func(f *ast.File) (*model.FileInspection, error) {
-		ft := util.GetPassFileToken(f, pass)
-		if ft == nil {
-			return nil, nil
-		}
-
-		raw, err := pass.ReadFile(ft.Name())
-		if err != nil {
-			return nil, fmt.Errorf("cannot read file %q: %v", ft.Name(), err)
-		}
+	knownRules := i.getRules()
 
+	inspect := func(f *ast.File, ft *token.File) *model.FileInspection {
 		// Extract package godoc, if any.
-		packageDoc := i.extractCommentGroup(f.Doc)
+		packageDoc := i.extractCommentGroup(f.Doc, knownRules)
 
 		// Extract top-level //godoclint:disable directives.
 		disabledRules := model.InspectorResultDisableRules{}
-		for _, match := range topLevelOrphanCommentGroupPattern.FindAll(raw, -1) {
-			d := extractDisableDirectivesInComment(string(match))
+		for _, cg := range findTopLevelOrphanCommentGroups(f, ft) {
+			d := extractDisableDirectivesInComment(cg, knownRules)
 			disabledRules.All = disabledRules.All || d.All
 			disabledRules.Rules = disabledRules.Rules.Merge(d.Rules)
 		}
@@ -115,7 +104,7 @@ func (i *Inspector) run(pass *analysis.Pass) (any, error) {
 				if dt.Recv != nil {
 					isMethod = true
 					if len(dt.Recv.List) > 0 {
-						recvBaseTypeName = extractMethodRecvBaseTypeName(dt.Recv.List[0].Type)
+						recvBaseTypeName = util.MethodRecvBaseTypeName(dt.Recv.List[0].Type)
 					}
 				}
 
@@ -126,7 +115,7 @@ func (i *Inspector) run(pass *analysis.Pass) (any, error) {
 					Ident:                  dt.Name,
 					IsMethod:               isMethod,
 					MethodRecvBaseTypeName: recvBaseTypeName,
-					Doc:                    i.extractCommentGroup(dt.Doc),
+					Doc:                    i.extractCommentGroup(dt.Doc, knownRules),
 				})
 			case *ast.BadDecl:
 				decls = append(decls, model.SymbolDecl{
@@ -155,15 +144,15 @@ func (i *Inspector) run(pass *analysis.Pass) (any, error) {
 								Kind:        kind,
 								Name:        spec.Names[0].Name,
 								Ident:       spec.Names[0],
-								Doc:         i.extractCommentGroup(dt.Doc),
-								TrailingDoc: i.extractCommentGroup(spec.Comment),
+								Doc:         i.extractCommentGroup(dt.Doc, knownRules),
+								TrailingDoc: i.extractCommentGroup(spec.Comment, knownRules),
 							})
 						} else {
 							// cases:
 							// const foo, bar = 0, 0
 							// var foo, bar = 0, 0
-							doc := i.extractCommentGroup(dt.Doc)
-							trailingDoc := i.extractCommentGroup(spec.Comment)
+							doc := i.extractCommentGroup(dt.Doc, knownRules)
+							trailingDoc := i.extractCommentGroup(spec.Comment, knownRules)
 							for ix, n := range spec.Names {
 								decls = append(decls, model.SymbolDecl{
 									Decl:           d,
@@ -192,11 +181,11 @@ func (i *Inspector) run(pass *analysis.Pass) (any, error) {
 						//     foo, bar = 0, 0
 						// )
 
-						parentDoc := i.extractCommentGroup(dt.Doc)
+						parentDoc := i.extractCommentGroup(dt.Doc, knownRules)
 						for spix, s := range dt.Specs {
 							spec := s.(*ast.ValueSpec)
-							doc := i.extractCommentGroup(spec.Doc)
-							trailingDoc := i.extractCommentGroup(spec.Comment)
+							doc := i.extractCommentGroup(spec.Doc, knownRules)
+							trailingDoc := i.extractCommentGroup(spec.Comment, knownRules)
 							for ix, n := range spec.Names {
 								decls = append(decls, model.SymbolDecl{
 									Decl:           d,
@@ -226,8 +215,8 @@ func (i *Inspector) run(pass *analysis.Pass) (any, error) {
 							IsTypeAlias: spec.Assign != token.NoPos,
 							Name:        spec.Name.Name,
 							Ident:       spec.Name,
-							Doc:         i.extractCommentGroup(dt.Doc),
-							TrailingDoc: i.extractCommentGroup(spec.Comment),
+							Doc:         i.extractCommentGroup(dt.Doc, knownRules),
+							TrailingDoc: i.extractCommentGroup(spec.Comment, knownRules),
 						})
 					} else {
 						// case:
@@ -235,7 +224,7 @@ func (i *Inspector) run(pass *analysis.Pass) (any, error) {
 						//     foo int
 						// )
 
-						parentDoc := i.extractCommentGroup(dt.Doc)
+						parentDoc := i.extractCommentGroup(dt.Doc, knownRules)
 						for spix, s := range dt.Specs {
 							spec := s.(*ast.TypeSpec)
 							decls = append(decls, model.SymbolDecl{
@@ -244,8 +233,8 @@ func (i *Inspector) run(pass *analysis.Pass) (any, error) {
 								IsTypeAlias:    spec.Assign != token.NoPos,
 								Name:           spec.Name.Name,
 								Ident:          spec.Name,
-								Doc:            i.extractCommentGroup(spec.Doc),
-								TrailingDoc:    i.extractCommentGroup(spec.Comment),
+								Doc:            i.extractCommentGroup(spec.Doc, knownRules),
+								TrailingDoc:    i.extractCommentGroup(spec.Comment, knownRules),
 								ParentDoc:      parentDoc,
 								MultiSpecDecl:  true,
 								MultiSpecIndex: spix,
@@ -262,7 +251,7 @@ func (i *Inspector) run(pass *analysis.Pass) (any, error) {
 			DisabledRules: disabledRules,
 			PackageDoc:    packageDoc,
 			SymbolDecl:    decls,
-		}, nil
+		}
 	}
 
 	result := &model.InspectorResult{
@@ -274,67 +263,117 @@ func (i *Inspector) run(pass *analysis.Pass) (any, error) {
 		if ft == nil {
 			continue
 		}
-		if !cfg.IsPathApplicable(ft.Name()) {
+		if !cfg.IsFileApplicable(pass.Pkg.Path(), ft.Name()) {
 			continue
 		}
 
-		if fi, err := inspect(f); err != nil {
-			return nil, fmt.Errorf("inspector failed: %w", err)
-		} else {
-			result.Files[f] = fi
-		}
+		result.Files[f] = inspect(f, ft)
 	}
 	return result, nil
 }
 
-func (i *Inspector) extractCommentGroup(cg *ast.CommentGroup) *model.CommentGroup {
+func (i *Inspector) extractCommentGroup(cg *ast.CommentGroup, knownRules model.RuleSet) *model.CommentGroup {
 	if cg == nil {
 		return nil
 	}
 
-	lines := make([]string, 0, len(cg.List))
-	for _, l := range cg.List {
-		lines = append(lines, l.Text)
-	}
-	rawText := strings.Join(lines, "\n")
-
-	text := cg.Text()
+	// The godoc is parsed lazily (See [model.CommentGroup.Parsed]), since it
+	// is only needed by some of the rules.
 	return &model.CommentGroup{
 		CG:            *cg,
-		Parsed:        *i.parser.Parse(text),
-		Text:          text,
-		DisabledRules: extractDisableDirectivesInComment(rawText),
+		Text:          cg.Text(),
+		DisabledRules: extractDisableDirectivesInComment(cg, knownRules),
 	}
 }
 
-func extractDisableDirectivesInComment(s string) model.InspectorResultDisableRules {
-	result := model.InspectorResultDisableRules{}
-	for _, directive := range disableDirectivePattern.FindAllStringSubmatch(s, -1) {
-		args := directive[1]
-		if args == "" {
-			result.All = true
+// findTopLevelOrphanCommentGroups returns the comment groups of the given file
+// that are not attached to any declaration (or the package clause), and are
+// made of line comments starting at the beginning of lines. For example:
+//
+//	//godoclint:disable max-len
+//
+//	package foo
+//
+// Orphan comment groups are followed by an empty line, or the end of file.
+// Comment groups inside declarations (e.g., function bodies) are not
+// top-level, and are skipped.
+func findTopLevelOrphanCommentGroups(f *ast.File, ft *token.File) []*ast.CommentGroup {
+	var result []*ast.CommentGroup
+	declIndex := 0
+	for ci, cg := range f.Comments {
+		for declIndex < len(f.Decls) && f.Decls[declIndex].End() < cg.Pos() {
+			declIndex++
+		}
+		if declIndex < len(f.Decls) && f.Decls[declIndex].Pos() <= cg.Pos() {
+			// Inside a declaration.
 			continue
 		}
 
-		for name := range strings.SplitSeq(strings.TrimSpace(args), " ") {
-			if model.AllRules.Has(model.Rule(name)) {
-				result.Rules = result.Rules.Add(model.Rule(name))
-			}
+		if !isMadeOfTopLevelLineComments(cg, ft) {
+			continue
+		}
+
+		// The next element, if any, should not start on the line right after
+		// the comment group.
+		next := token.NoPos
+		if ci+1 < len(f.Comments) {
+			next = f.Comments[ci+1].Pos()
 		}
+		if f.Package > cg.End() && (next == token.NoPos || f.Package < next) {
+			next = f.Package
+		}
+		if declIndex < len(f.Decls) && (next == token.NoPos || f.Decls[declIndex].Pos() < next) {
+			next = f.Decls[declIndex].Pos()
+		}
+		if next != token.NoPos && ft.Line(next) <= ft.Line(cg.End())+1 {
+			continue
+		}
+		result = append(result, cg)
 	}
 	return result
 }
 
-func extractMethodRecvBaseTypeName(expr ast.Expr) string {
-	switch tt := expr.(type) {
-	case *ast.Ident:
-		return tt.Name
-	case *ast.StarExpr:
-		return extractMethodRecvBaseTypeName(tt.X)
-	case *ast.IndexExpr:
-		return extractMethodRecvBaseTypeName(tt.X)
-	case *ast.IndexListExpr:
-		return extractMethodRecvBaseTypeName(tt.X)
+// isMadeOfTopLevelLineComments determines whether the given comment group is
+// made of line comments (i.e., "//"), all starting at the beginning of lines.
+func isMadeOfTopLevelLineComments(cg *ast.CommentGroup, ft *token.File) bool {
+	for _, c := range cg.List {
+		if !strings.HasPrefix(c.Text, "//") || ft.Position(c.Slash).Column != 1 {
+			return false
+		}
+	}
+	return true
+}
+
+// getRules returns the set of known rules.
+func (i *Inspector) getRules() model.RuleSet {
+	if i.registry == nil {
+		return model.AllRules
+	}
+	return i.registry.GetCoveredRules()
+}
+
+// extractDisableDirectivesInComment returns the rules disabled via directives
+// in the given comment group. Unknown rules are ignored.
+func extractDisableDirectivesInComment(cg *ast.CommentGroup, knownRules model.RuleSet) model.InspectorResultDisableRules {
+	result := model.InspectorResultDisableRules{}
+	for _, c := range cg.List {
+		// Most comments have no directives, so the cheap check goes first.
+		if !strings.Contains(c.Text, disableDirectivePrefix) {
+			continue
+		}
+		for _, directive := range disableDirectivePattern.FindAllStringSubmatch(c.Text, -1) {
+			args := directive[1]
+			if args == "" {
+				result.All = true
+				continue
+			}
+
+			for name := range strings.SplitSeq(strings.TrimSpace(args), " ") {
+				if knownRules.Has(model.Rule(name)) {
+					result.Rules = result.Rules.Add(model.Rule(name))
+				}
+			}
+		}
 	}
-	return ""
+	return result
 }
@@ -2,7 +2,6 @@ package inspect_test
 
 import (
 	"bytes"
-	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -28,15 +27,11 @@ func TestInspector(t *testing.T) {
 	wd, err := os.Getwd()
 	require.NoError(err, "failed to get wd")
 
-	exitFunc := func(code int, err error) {
-		panic(fmt.Sprintf("exit code %d: %v", code, err))
-	}
-
 	testdir := filepath.Join(wd, "../../testdata/inspector")
 
 	cb := config.NewConfigBuilder(testdir)
 	ocb := config.NewOnceConfigBuilder(cb)
-	inspector := inspect.NewInspector(ocb, exitFunc)
+	inspector := inspect.NewInspector(ocb)
 
 	ars := analysistest.Run(t, testdir, inspector.GetAnalyzer(), "./...")
 
@@ -0,0 +1,160 @@
+package lint_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/godoc-lint/godoc-lint/pkg/compose"
+	"github.com/godoc-lint/godoc-lint/pkg/config"
+	"github.com/godoc-lint/godoc-lint/pkg/lint"
+	"github.com/godoc-lint/godoc-lint/pkg/model"
+)
+
+// Synthetic module dimensions. The total number of declarations should be in
+// the same order as that of a large real-world module.
+const (
+	benchPackages     = 50
+	benchFilesPerPkg  = 10
+	benchDeclsPerFile = 20
+)
+
+// writeSyntheticModule writes a synthetic module into the given directory, and
+// returns the paths of its files, along with their total size in bytes.
+//
+// Declarations have a mix of godocs that trigger, or do not trigger, each of
+// the rules (e.g., doc links, code blocks, deprecation notes, long lines, and
+// disable directives). Files do not import any package, so that loading the
+// packages does not depend on the standard library export data.
+func writeSyntheticModule(tb testing.TB, dir string) ([]string, int64) {
+	tb.Helper()
+
+	write := func(path, content string) {
+		require.NoError(tb, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(tb, os.WriteFile(path, []byte(content), 0o644))
+	}
+	write(filepath.Join(dir, "go.mod"), "module example.com/synthetic\n\ngo 1.24\n")
+
+	var files []string
+	var size int64
+	for p := range benchPackages {
+		pkgName := fmt.Sprintf("pkg%03d", p)
+		for f := range benchFilesPerPkg {
+			var sb strings.Builder
+			if f == 0 {
+				fmt.Fprintf(&sb, "// Package %s is a synthetic package, which refers to [Type000x000] and\n// [strings.Builder].\n", pkgName)
+			} else if f%7 == 0 {
+				sb.WriteString("//godoclint:disable max-len\n\n")
+			}
+			fmt.Fprintf(&sb, "package %s\n\n", pkgName)
+			for d := range benchDeclsPerFile {
+				writeSyntheticDecl(&sb, fmt.Sprintf("%03dx%03d", f, d), d)
+			}
+
+			path := filepath.Join(dir, pkgName, fmt.Sprintf("file%03d.go", f))
+			write(path, sb.String())
+			files = append(files, path)
+			size += int64(sb.Len())
+		}
+	}
+	return files, size
+}
+
+// writeSyntheticDecl writes a declaration, whose kind and godoc depend on the
+// given index.
+func writeSyntheticDecl(sb *strings.Builder, suffix string, index int) {
+	switch index % 10 {
+	case 0:
+		fmt.Fprintf(sb, "// Type%s is a type.\n//\n// It is used like this:\n//\n//\tvar t Type%s\n//\t_ = t\ntype Type%s struct {\n\tField int\n}\n\n", suffix, suffix, suffix)
+		fmt.Fprintf(sb, "// Method returns the field of [Type%s].\nfunc (t *Type%s) Method() int {\n\t// Not a godoc.\n\treturn t.Field\n}\n\n", suffix, suffix)
+	case 1:
+		fmt.Fprintf(sb, "// returns nothing, which does not start with the symbol name.\nfunc Func%s() {}\n\n", suffix)
+	case 2:
+		fmt.Fprintf(sb, "// Func%s has a godoc line that is way too long to fit in the default maximum length of godoc lines.\nfunc Func%s() {}\n\n", suffix, suffix)
+	case 3:
+		fmt.Fprintf(sb, "// Func%s is deprecated.\n//\n// Deprecated: Use [Func%s] instead.\nfunc Func%s() {}\n\n", suffix, suffix, suffix)
+	case 4:
+		fmt.Fprintf(sb, "// Group of constants.\nconst (\n\t// Const%sA is a constant.\n\tConst%sA = 1\n\tConst%sB, Const%sC = 2, 3 // Trailing comment.\n)\n\n", suffix, suffix, suffix, suffix)
+	case 5:
+		fmt.Fprintf(sb, "// Var%s refers to the [example] link, and encoding/json.\n//\n// [example]: https://example.com\nvar Var%s = 0\n\n", suffix, suffix)
+	case 6:
+		fmt.Fprintf(sb, "// Func%s has an unused link.\n//\n// [unused]: https://example.com\nfunc Func%s() {}\n\n", suffix, suffix)
+	case 7:
+		fmt.Fprintf(sb, "//godoclint:disable start-with-name\n//\n// undocumented properly.\nfunc Func%s() {}\n\n", suffix)
+	case 8:
+		fmt.Fprintf(sb, "func Func%s() {}\n\n", suffix)
+	default:
+		fmt.Fprintf(sb, "type (\n\t// Alias%s is an alias.\n\tAlias%s = int\n\n\t// Iface%s is an interface.\n\tIface%s interface {\n\t\tMethod() int\n\t}\n)\n\n", suffix, suffix, suffix, suffix)
+	}
+}
+
+// benchConfigs holds the configurations to benchmark, from no rules (i.e.,
+// the inspector overhead) to all rules.
+var benchConfigs = []struct {
+	name   string
+	config *config.PlainConfig
+}{
+	{"none", &config.PlainConfig{Default: ptr(string(model.DefaultSetNone))}},
+	{"basic", &config.PlainConfig{Default: ptr(string(model.DefaultSetBasic))}},
+	{"all", &config.PlainConfig{Default: ptr(string(model.DefaultSetAll))}},
+}
+
+func BenchmarkAnalyzeFiles(b *testing.B) {
+	dir := b.TempDir()
+	paths, size := writeSyntheticModule(b, dir)
+
+	// Reading files is not the subject of the benchmark.
+	files := make([]lint.File, 0, len(paths))
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		require.NoError(b, err)
+		files = append(files, lint.File{Path: path, Content: content})
+	}
+
+	for _, bc := range benchConfigs {
+		b.Run(bc.name, func(b *testing.B) {
+			composition := compose.Compose(compose.CompositionConfig{
+				BaseDir:            dir,
+				BaseDirPlainConfig: bc.config,
+			})
+
+			b.SetBytes(size)
+			b.ReportAllocs()
+			for b.Loop() {
+				issues, err := lint.AnalyzeFiles(context.Background(), composition, dir, files)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if bc.name != "none" && len(issues) == 0 {
+					b.Fatal("no issues reported on the synthetic module")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkRun(b *testing.B) {
+	dir := b.TempDir()
+	_, size := writeSyntheticModule(b, dir)
+
+	for _, bc := range benchConfigs {
+		b.Run(bc.name, func(b *testing.B) {
+			b.SetBytes(size)
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, err := lint.Run(context.Background(), lint.Options{
+					Dir:      dir,
+					Patterns: []string{"./..."},
+					Config:   bc.config,
+				}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,261 @@
+package lint
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+
+	"github.com/godoc-lint/godoc-lint/pkg/cache"
+	"github.com/godoc-lint/godoc-lint/pkg/compose"
+	"github.com/godoc-lint/godoc-lint/pkg/model"
+)
+
+// AnalyzePackages loads the packages matching the given patterns and lints them
+// with the given composition. It returns the issues, sorted by position, along
+// with the sorted paths of the linted packages. Synthesized test main packages
+// (i.e., "foo.test") are excluded from the paths.
+//
+// If a cache is given, packages are looked up in it first, by the content of
+// their files, their resolved configuration, the registered checkers and the
+// linter build (i.e., not only its version). Only the packages that are not
+// cached are loaded and analyzed, and their findings are stored in the cache.
+// The findings are the same either way. Failures to write to the cache are
+// ignored, as they only affect the next runs.
+//
+// Unlike [Run], the configuration given as a plain config is not validated
+// upfront, which is up to the caller.
+func AnalyzePackages(ctx context.Context, composition *compose.Composition, dir string, patterns []string, tests bool, c *cache.Cache) ([]Issue, []string, error) {
+	if c == nil {
+		return analyzePackages(ctx, composition, dir, patterns, tests)
+	}
+
+	units, ok, err := listCacheUnits(ctx, composition, dir, patterns, tests)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !ok {
+		// Packages that cannot be cached (e.g., with load errors) are analyzed
+		// as usual, which also reports the errors in the usual way.
+		return analyzePackages(ctx, composition, dir, patterns, tests)
+	}
+
+	var issues []Issue
+	var paths []string
+	var misses []*cacheUnit
+	for _, u := range units {
+		paths = append(paths, u.packages...)
+		if e, ok := c.Get(u.key); ok {
+			issues = append(issues, e.Issues...)
+			continue
+		}
+		misses = append(misses, u)
+	}
+
+	if len(misses) > 0 {
+		missPatterns := make([]string, 0, len(misses))
+		unitByPkgPath := make(map[string]*cacheUnit)
+		for _, u := range misses {
+			missPatterns = append(missPatterns, u.pattern)
+			for _, path := range u.packages {
+				unitByPkgPath[path] = u
+			}
+		}
+
+		analyzed, _, err := analyzePackages(ctx, composition, dir, missPatterns, tests)
+		if err != nil {
+			return nil, nil, err
+		}
+		issues = append(issues, analyzed...)
+
+		cacheable := true
+		unitIssues := make(map[*cacheUnit][]Issue, len(misses))
+		for _, issue := range analyzed {
+			u, ok := unitByPkgPath[issue.PkgPath]
+			if !ok {
+				// This should not happen, but if it does, we cannot tell which
+				// entry the issue belongs to.
+				cacheable = false
+				break
+			}
+			unitIssues[u] = append(unitIssues[u], issue)
+		}
+		if cacheable {
+			for _, u := range misses {
+				_ = c.Put(u.key, &cache.Entry{
+					Packages: u.packages,
+					Issues:   unitIssues[u],
+				})
+			}
+		}
+	}
+
+	sortIssues(issues)
+	slices.Sort(paths)
+	return issues, slices.Compact(paths), nil
+}
+
+// analyzePackages is the same as [AnalyzePackages], without a cache.
+func analyzePackages(ctx context.Context, composition *compose.Composition, dir string, patterns []string, tests bool) ([]Issue, []string, error) {
+	pkgs, err := LoadPackages(ctx, dir, patterns, tests)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	graph, err := checker.Analyze([]*analysis.Analyzer{composition.Analyzer.GetAnalyzer()}, pkgs, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	issues, err := CollectIssues(graph)
+	if err != nil {
+		return nil, nil, err
+	}
+	return issues, rootPackagePaths(graph), nil
+}
+
+// rootPackagePaths returns the sorted, unique paths of the analyzed packages.
+//
+// Synthesized test main packages (i.e., "foo.test") are excluded.
+func rootPackagePaths(graph *checker.Graph) []string {
+	paths := make([]string, 0, len(graph.Roots))
+	for _, act := range graph.Roots {
+		if isTestMain(act.Package) {
+			continue
+		}
+		paths = append(paths, act.Package.PkgPath)
+	}
+	slices.Sort(paths)
+	return slices.Compact(paths)
+}
+
+// isTestMain determines whether the given package is a synthesized test main
+// package (i.e., "foo.test").
+func isTestMain(pkg *packages.Package) bool {
+	return strings.HasSuffix(pkg.ID, ".test")
+}
+
+// cacheUnit represents the packages in the same directory (i.e., a package and
+// its test variants), which are cached as one entry.
+type cacheUnit struct {
+	// pattern is the package pattern to load the packages (i.e., the path of
+	// the non-test package).
+	pattern string
+
+	// packages holds the sorted, unique paths of the packages.
+	packages []string
+
+	// files holds the sorted, unique paths of the package files.
+	files []string
+
+	// key is the cache key of the unit.
+	key cache.Key
+}
+
+// listCacheUnits lists the packages matching the given patterns, without
+// parsing them, and groups them into cache units. It returns false if any of
+// the packages cannot be cached (e.g., it has errors).
+func listCacheUnits(ctx context.Context, composition *compose.Composition, dir string, patterns []string, tests bool) ([]*cacheUnit, bool, error) {
+	pkgs, err := packages.Load(&packages.Config{
+		Context: ctx,
+		Mode:    packages.NeedName | packages.NeedFiles,
+		Dir:     dir,
+		Tests:   tests,
+	}, patterns...)
+	if err != nil {
+		return nil, false, err
+	}
+
+	byDir := make(map[string]*cacheUnit)
+	var dirs []string
+	for _, pkg := range pkgs {
+		if len(pkg.Errors) > 0 || pkg.PkgPath == "command-line-arguments" {
+			return nil, false, nil
+		}
+		if isTestMain(pkg) {
+			continue
+		}
+
+		pkgDir := pkg.Dir
+		if pkgDir == "" && len(pkg.GoFiles) > 0 {
+			pkgDir = filepath.Dir(pkg.GoFiles[0])
+		}
+		if pkgDir == "" {
+			return nil, false, nil
+		}
+
+		u, ok := byDir[pkgDir]
+		if !ok {
+			u = &cacheUnit{}
+			byDir[pkgDir] = u
+			dirs = append(dirs, pkgDir)
+		}
+		if pkg.ID == pkg.PkgPath {
+			u.pattern = pkg.PkgPath
+		}
+		u.packages = append(u.packages, pkg.PkgPath)
+		u.files = append(u.files, pkg.GoFiles...)
+	}
+
+	rules := composition.Registry.GetCoveredRules().List()
+	checkers := make([]string, 0, len(composition.Registry.List()))
+	for _, checker := range composition.Registry.List() {
+		checkers = append(checkers, checkerID(checker))
+	}
+	slices.Sort(checkers)
+	units := make([]*cacheUnit, 0, len(dirs))
+	for _, pkgDir := range dirs {
+		u := byDir[pkgDir]
+		if u.pattern == "" {
+			return nil, false, nil
+		}
+		slices.Sort(u.packages)
+		u.packages = slices.Compact(u.packages)
+		slices.Sort(u.files)
+		u.files = slices.Compact(u.files)
+
+		cfg, err := composition.ConfigBuilder.GetConfig(pkgDir)
+		if err != nil {
+			// The error is reported by the analysis.
+			return nil, false, nil
+		}
+
+		kb := cache.NewKeyBuilder().String("dir", pkgDir, "tests", strconv.FormatBool(tests), "config", cfg.Fingerprint())
+		for _, rule := range rules {
+			kb.String("rule", string(rule))
+		}
+		for _, id := range checkers {
+			kb.String("checker", id)
+		}
+		for _, f := range u.files {
+			if err := kb.File(f); err != nil {
+				return nil, false, nil
+			}
+		}
+		u.key = kb.Key()
+		units = append(units, u)
+	}
+	return units, true, nil
+}
+
+// checkerID returns the identity of the given checker, which is the full name
+// of its type (e.g., "github.com/foo/bar.MyChecker").
+func checkerID(checker model.Checker) string {
+	t := reflect.TypeOf(checker)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.PkgPath() == "" {
+		return t.String()
+	}
+	return t.PkgPath() + "." + t.Name()
+}
@@ -0,0 +1,185 @@
+package lint_test
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/godoc-lint/godoc-lint/pkg/cache"
+	"github.com/godoc-lint/godoc-lint/pkg/lint"
+	"github.com/godoc-lint/godoc-lint/pkg/model"
+	"github.com/godoc-lint/godoc-lint/pkg/util"
+)
+
+func TestRunWithCache(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	write := func(name, content string) {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		require.NoError(os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(os.WriteFile(path, []byte(content), 0o644))
+	}
+	write("go.mod", "module example.com/m\n\ngo 1.24\n")
+	write("a/a.go", "// Package a is a package.\npackage a\n\n// foo is a function.\nfunc Foo() {}\n")
+	write("b/b.go", "// Package b is a package.\npackage b\n\n// Bar is a function.\nfunc Bar() {}\n")
+
+	c, err := cache.Open(filepath.Join(t.TempDir(), "cache"))
+	require.NoError(err)
+
+	run := func(c *cache.Cache) []lint.Issue {
+		t.Helper()
+		issues, err := lint.Run(context.Background(), lint.Options{
+			Dir:      dir,
+			Patterns: []string{"./..."},
+			Cache:    c,
+		})
+		require.NoError(err)
+		return issues
+	}
+	entries := func() []string {
+		t.Helper()
+		var paths []string
+		require.NoError(filepath.WalkDir(c.Dir(), func(path string, d fs.DirEntry, err error) error {
+			if err == nil && !d.IsDir() {
+				paths = append(paths, path)
+			}
+			return err
+		}))
+		return paths
+	}
+
+	uncached := run(nil)
+	require.Len(uncached, 1)
+
+	require.Equal(uncached, run(c), "cache miss")
+	require.Len(entries(), 2, "one entry per package directory")
+	require.Equal(uncached, run(c), "cache hit")
+	require.Len(entries(), 2)
+
+	t.Run("entries are used", func(t *testing.T) {
+		for _, path := range entries() {
+			content, err := os.ReadFile(path)
+			require.NoError(err)
+			if strings.Contains(string(content), `"example.com/m/a"`) {
+				require.NoError(os.WriteFile(path, []byte(`{"packages":["example.com/m/a"],"issues":[]}`), 0o644))
+			}
+		}
+		require.Empty(run(c))
+	})
+
+	t.Run("file change", func(t *testing.T) {
+		write("a/a.go", "// Package a is a package.\npackage a\n\n// Foo is a function.\nfunc Foo() {}\n")
+		write("b/b.go", "// Package b is a package.\npackage b\n\n// bar is a function.\nfunc Bar() {}\n")
+		want := run(nil)
+		require.Len(want, 1)
+		require.Equal("example.com/m/b", want[0].PkgPath)
+		require.Equal(want, run(c))
+	})
+
+	t.Run("config change", func(t *testing.T) {
+		write(".godoc-lint.yaml", "version: \"1.0\"\ndisable:\n  - start-with-name\n")
+		require.Empty(run(nil))
+		require.Empty(run(c))
+	})
+
+	t.Run("clean", func(t *testing.T) {
+		require.NoError(c.Clean())
+		require.Empty(entries())
+		require.Empty(run(c))
+		require.NotEmpty(entries())
+	})
+}
+
+// customRule is the rule covered by the custom checkers of the cache tests.
+const customRule = model.Rule("custom")
+
+// reportingChecker is a custom checker that reports all symbol godocs.
+type reportingChecker struct{}
+
+func (c *reportingChecker) GetCoveredRules() model.RuleSet {
+	return model.RuleSet{}.Add(customRule)
+}
+
+func (c *reportingChecker) Apply(actx *model.AnalysisContext) error {
+	for _, ir := range util.AnalysisApplicableFiles(actx, false, c.GetCoveredRules()) {
+		for _, sd := range ir.SymbolDecl {
+			if sd.Doc != nil {
+				util.Reportf(actx.Pass, customRule, sd.Doc.CG.Pos(), "custom finding")
+			}
+		}
+	}
+	return nil
+}
+
+// silentChecker is a custom checker that covers the same rule as
+// [reportingChecker], without reporting anything.
+type silentChecker struct{}
+
+func (c *silentChecker) GetCoveredRules() model.RuleSet {
+	return model.RuleSet{}.Add(customRule)
+}
+
+func (c *silentChecker) Apply(actx *model.AnalysisContext) error {
+	return nil
+}
+
+func TestRunWithCacheCustomCheckers(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	require.NoError(os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/m\n\ngo 1.24\n"), 0o644))
+	require.NoError(os.WriteFile(filepath.Join(dir, "a.go"), []byte("// Package m is a package.\npackage m\n\n// Foo is a function.\nfunc Foo() {}\n"), 0o644))
+
+	c, err := cache.Open(filepath.Join(t.TempDir(), "cache"))
+	require.NoError(err)
+
+	run := func(checker model.Checker) []lint.Issue {
+		t.Helper()
+		issues, err := lint.Run(context.Background(), lint.Options{
+			Dir:      dir,
+			Patterns: []string{"./..."},
+			Override: &model.ConfigOverride{Enable: ptr(model.RuleSet{}.Add(customRule))},
+			Checkers: []model.Checker{checker},
+			Cache:    c,
+		})
+		require.NoError(err)
+		return issues
+	}
+
+	require.Len(run(&reportingChecker{}), 1)
+	require.Empty(run(&silentChecker{}), "entries of other checkers of the same rules should not be used")
+	require.Len(run(&reportingChecker{}), 1)
+}
+
+func TestRunWithCacheErrors(t *testing.T) {
+	wd, err := os.Getwd()
+	require.NoError(t, err, "failed to get wd")
+
+	testdir := filepath.Join(wd, "../../testdata/lint")
+
+	c, err := cache.Open(t.TempDir())
+	require.NoError(t, err)
+
+	_, err = lint.Run(context.Background(), lint.Options{
+		Dir:      testdir,
+		Patterns: []string{"./nonexistent"},
+		Cache:    c,
+	})
+	require.ErrorContains(t, err, "nonexistent")
+
+	for range 2 {
+		_, err = lint.Run(context.Background(), lint.Options{
+			Dir:      testdir,
+			Patterns: []string{"./badconfig/..."},
+			Cache:    c,
+		})
+		require.ErrorContains(t, err, ".godoc-lint.yaml", "config errors are never cached")
+	}
+}
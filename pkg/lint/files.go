@@ -0,0 +1,161 @@
+package lint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/godoc-lint/godoc-lint/pkg/compose"
+	"github.com/godoc-lint/godoc-lint/pkg/model"
+)
+
+// File represents an individual Go file to lint, without loading its package
+// (e.g., an unsaved editor buffer, or a file staged in a pre-commit hook).
+//
+// Since the package is not loaded, rules that need all files of a package (See
+// [model.RuleMetadata.PackageWide]) are skipped. Files of the same package
+// (i.e., with the same directory and package name) are linted together.
+type File struct {
+	// Path is the path to the file. Relative paths are resolved against the
+	// base directory (See [Options.Dir]). The configuration is resolved for the
+	// directory of the file, just like for packages.
+	Path string
+
+	// Content is the file content. If nil, the file is read from the path.
+	Content []byte
+}
+
+// AnalyzeFiles lints the given files with the given composition, relative to
+// the given base directory. Files of the same package are analyzed together, as
+// a package with only those files. The returned issues are sorted by position.
+//
+// Unlike [Run], the configuration given as a plain config is not validated
+// upfront, which is up to the caller.
+func AnalyzeFiles(ctx context.Context, composition *compose.Composition, dir string, files []File) ([]Issue, error) {
+	type pkgKey struct {
+		dir  string
+		name string
+	}
+
+	fset := token.NewFileSet()
+	contents := make(map[string][]byte, len(files))
+	pkgFiles := make(map[pkgKey][]*ast.File)
+	var pkgKeys []pkgKey
+
+	for _, file := range files {
+		filePath := file.Path
+		if !filepath.IsAbs(filePath) {
+			filePath = filepath.Join(dir, filePath)
+		}
+
+		content := file.Content
+		if content == nil {
+			var err error
+			if content, err = os.ReadFile(filePath); err != nil {
+				return nil, fmt.Errorf("cannot read file: %w", err)
+			}
+		}
+
+		f, err := parser.ParseFile(fset, filePath, content, parser.ParseComments|parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		contents[filePath] = content
+
+		k := pkgKey{dir: filepath.Dir(filePath), name: f.Name.Name}
+		if _, ok := pkgFiles[k]; !ok {
+			pkgKeys = append(pkgKeys, k)
+		}
+		pkgFiles[k] = append(pkgFiles[k], f)
+	}
+
+	inspector := composition.Inspector.GetAnalyzer()
+	analyzer := composition.Analyzer.GetAnalyzer()
+
+	var issues []Issue
+	for _, k := range pkgKeys {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		pkgPath := getPackagePath(k.dir, k.name)
+		syntax := pkgFiles[k]
+		pass := &analysis.Pass{
+			Analyzer: inspector,
+			Fset:     fset,
+			Files:    syntax,
+			Pkg:      types.NewPackage(pkgPath, k.name),
+			ResultOf: make(map[*analysis.Analyzer]any),
+			ReadFile: func(filename string) ([]byte, error) {
+				if content, ok := contents[filename]; ok {
+					return content, nil
+				}
+				return nil, fmt.Errorf("file %q is not among the linted files", filename)
+			},
+			Report: func(analysis.Diagnostic) {},
+		}
+
+		result, err := inspector.Run(pass)
+		if err != nil {
+			return nil, wrapPackageError(pkgPath, err)
+		}
+		if ir, ok := result.(*model.InspectorResult); ok && ir != nil && ir.ConfigErr != nil {
+			return nil, ir.ConfigErr
+		}
+
+		pass.Analyzer = analyzer
+		pass.ResultOf[inspector] = result
+		pass.Report = func(diag analysis.Diagnostic) {
+			if m, ok := composition.Registry.GetRuleMetadata(model.Rule(diag.Category)); ok && m.PackageWide {
+				return
+			}
+			issues = append(issues, newIssue(fset, pkgPath, syntax, diag))
+		}
+		if _, err := analyzer.Run(pass); err != nil {
+			return nil, wrapPackageError(pkgPath, err)
+		}
+	}
+
+	sortIssues(issues)
+	return issues, nil
+}
+
+// getPackagePath returns the import path of the package with the given name in
+// the given directory, based on the path of the module it belongs to (i.e., of
+// the nearest go.mod file). External test packages get the "_test" suffix, as
+// with go/packages. If the module cannot be found, the package name is returned.
+func getPackagePath(dir, name string) string {
+	result := name
+	for d := dir; ; d = filepath.Dir(d) {
+		data, err := os.ReadFile(filepath.Join(d, "go.mod"))
+		if err == nil {
+			if modPath := modfile.ModulePath(data); modPath != "" {
+				if rel, err := filepath.Rel(d, dir); err == nil {
+					result = path.Join(modPath, filepath.ToSlash(rel))
+				}
+			}
+			break
+		} else if !errors.Is(err, os.ErrNotExist) {
+			break
+		}
+		if filepath.Dir(d) == d {
+			break
+		}
+	}
+
+	if strings.HasSuffix(name, "_test") && !strings.HasSuffix(result, "_test") {
+		result += "_test"
+	}
+	return result
+}
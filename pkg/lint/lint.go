@@ -0,0 +1,294 @@
+// Package lint provides a library API to run the linter on Go packages, without
+// going through a go/analysis driver.
+package lint
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"os"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+
+	"github.com/godoc-lint/godoc-lint/pkg/cache"
+	"github.com/godoc-lint/godoc-lint/pkg/compose"
+	"github.com/godoc-lint/godoc-lint/pkg/config"
+	"github.com/godoc-lint/godoc-lint/pkg/model"
+	"github.com/godoc-lint/godoc-lint/pkg/util"
+)
+
+// LoadMode is the minimum load mode required to run the linter.
+const LoadMode = packages.LoadSyntax | packages.NeedModule
+
+// Issue represents a finding reported by the linter.
+type Issue = model.Issue
+
+// Options holds the options to run the linter.
+type Options struct {
+	// Dir is the base directory to load the packages from. Relative package
+	// patterns and include/exclude path patterns are resolved against it. If
+	// empty, the current working directory is used.
+	Dir string
+
+	// Patterns holds the package patterns to lint (e.g., "./...").
+	Patterns []string
+
+	// Tests indicates whether test packages should be linted, too.
+	Tests bool
+
+	// Files holds the individual files to lint, instead of packages (See
+	// [File]). It cannot be used together with Patterns.
+	Files []File
+
+	// Config holds the configuration for the base directory. It is used when
+	// there is no configuration file in the base directory, just like
+	// [compose.CompositionConfig.BaseDirPlainConfig]. Configuration files in
+	// sub-directories still apply to their packages.
+	//
+	// If nil, the configuration is resolved from the files, or the defaults.
+	Config *config.PlainConfig
+
+	// Override holds the configuration override (e.g., enabled or disabled
+	// rules), which takes precedence over the configuration. It can be nil.
+	Override *model.ConfigOverride
+
+	// Checkers holds the custom checkers to run, in addition to the built-in
+	// ones (See [compose.CompositionConfig.Checkers]).
+	Checkers []model.Checker
+
+	// Cache holds the cache of findings, to skip the analysis of packages
+	// that have not changed since the last run (See [AnalyzePackages]). It can
+	// be nil. It does not apply to individual files.
+	Cache *cache.Cache
+}
+
+// Run loads the packages matching the given patterns (or the given files) and
+// lints them. The returned issues are sorted by position.
+//
+// Unlike the go/analysis drivers, errors (e.g., an invalid configuration, or a
+// package that fails to load) are returned rather than terminating the process.
+func Run(ctx context.Context, opts Options) ([]Issue, error) {
+	if len(opts.Patterns) == 0 && len(opts.Files) == 0 {
+		return nil, errors.New("no package patterns or files given")
+	}
+	if len(opts.Patterns) > 0 && len(opts.Files) > 0 {
+		return nil, errors.New("cannot lint package patterns and files at the same time")
+	}
+
+	dir := opts.Dir
+	if dir == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return nil, fmt.Errorf("cannot get current working directory: %w", err)
+		}
+		dir = wd
+	}
+
+	composition := compose.Compose(compose.CompositionConfig{
+		BaseDir:            dir,
+		BaseDirPlainConfig: opts.Config,
+		Checkers:           opts.Checkers,
+	})
+	if opts.Override != nil {
+		composition.ConfigBuilder.SetOverride(opts.Override)
+	}
+
+	if opts.Config != nil {
+		if err := opts.Config.ValidateWithRules(composition.Registry.GetCoveredRules()); err != nil {
+			return nil, fmt.Errorf("invalid config: %w", err)
+		}
+	}
+
+	if len(opts.Files) > 0 {
+		return AnalyzeFiles(ctx, composition, dir, opts.Files)
+	}
+
+	issues, _, err := AnalyzePackages(ctx, composition, dir, opts.Patterns, opts.Tests, opts.Cache)
+	return issues, err
+}
+
+// LoadPackages loads the packages matching the given patterns, with the load
+// mode required by the linter. Errors of the loaded packages (e.g., syntax or
+// type errors) are joined into the returned error.
+func LoadPackages(ctx context.Context, dir string, patterns []string, tests bool) ([]*packages.Package, error) {
+	pkgs, err := packages.Load(&packages.Config{
+		Context: ctx,
+		Mode:    LoadMode,
+		Dir:     dir,
+		Tests:   tests,
+	}, patterns...)
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+		for _, err := range pkg.Errors {
+			errs = append(errs, err)
+		}
+	})
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return pkgs, nil
+}
+
+// CollectIssues extracts the issues reported by the root actions of the given
+// graph. Errors of the actions are returned as by [CollectErrors].
+//
+// Since source files may belong to more than one package (e.g., foo and
+// foo.test), issues are de-duplicated by their positions. The result is sorted
+// by position.
+func CollectIssues(graph *checker.Graph) ([]Issue, error) {
+	type key struct {
+		pos     string
+		end     string
+		rule    model.Rule
+		message string
+	}
+	seen := make(map[key]struct{}, 100)
+
+	if err := CollectErrors(graph); err != nil {
+		return nil, err
+	}
+
+	var issues []Issue
+	for _, act := range graph.Roots {
+		for _, diag := range act.Diagnostics {
+			issue := newIssue(act.Package.Fset, act.Package.PkgPath, act.Package.Syntax, diag)
+			k := key{issue.Pos.String(), issue.End.String(), issue.Rule, issue.Message}
+			if _, ok := seen[k]; ok {
+				continue
+			}
+			seen[k] = struct{}{}
+			issues = append(issues, issue)
+		}
+	}
+
+	sortIssues(issues)
+	return issues, nil
+}
+
+// newIssue converts the given diagnostic, reported on the package with the
+// given path and files, to an issue.
+func newIssue(fset *token.FileSet, pkgPath string, files []*ast.File, diag analysis.Diagnostic) Issue {
+	issue := Issue{
+		Rule:    model.Rule(diag.Category),
+		PkgPath: pkgPath,
+		Pos:     fset.Position(diag.Pos),
+		Message: diag.Message,
+		URL:     diag.URL,
+	}
+	if diag.End.IsValid() {
+		issue.End = fset.Position(diag.End)
+	}
+	for _, f := range files {
+		if f.FileStart <= diag.Pos && diag.Pos <= f.FileEnd {
+			issue.Symbol = util.FindSymbolAt(f, diag.Pos)
+			break
+		}
+	}
+
+	for _, fix := range diag.SuggestedFixes {
+		edits := make([]model.IssueTextEdit, 0, len(fix.TextEdits))
+		for _, edit := range fix.TextEdits {
+			edits = append(edits, model.IssueTextEdit{
+				Pos:     fset.Position(edit.Pos),
+				End:     fset.Position(edit.End),
+				NewText: string(edit.NewText),
+			})
+		}
+		issue.Fixes = append(issue.Fixes, model.IssueFix{
+			Message: fix.Message,
+			Edits:   edits,
+		})
+	}
+
+	for _, rel := range diag.Related {
+		related := model.IssueRelated{
+			Pos:     fset.Position(rel.Pos),
+			Message: rel.Message,
+		}
+		if rel.End.IsValid() {
+			related.End = fset.Position(rel.End)
+		}
+		issue.Related = append(issue.Related, related)
+	}
+	return issue
+}
+
+// sortIssues sorts the given issues by position.
+func sortIssues(issues []Issue) {
+	slices.SortStableFunc(issues, func(a, b Issue) int {
+		return cmp.Or(
+			cmp.Compare(a.Pos.Filename, b.Pos.Filename),
+			cmp.Compare(a.Pos.Offset, b.Pos.Offset),
+			cmp.Compare(a.Rule, b.Rule),
+			cmp.Compare(a.Message, b.Message),
+		)
+	})
+}
+
+// CollectErrors returns the errors of the failed root actions of the given
+// graph, joined into one error, or nil if there is none. Configuration errors
+// can be detected via [model.ConfigError].
+func CollectErrors(graph *checker.Graph) error {
+	var errs []error
+	seen := make(map[string]struct{})
+	for _, act := range graph.Roots {
+		for _, err := range getActionErrors(act) {
+			// The same config error is reported by all packages under the same
+			// config file.
+			if _, ok := seen[err.Error()]; ok {
+				continue
+			}
+			seen[err.Error()] = struct{}{}
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// getActionErrors returns the root causes of the failure of the given action.
+//
+// Since actions with failed prerequisites are not run, but marked with an
+// opaque error, the errors of the failed prerequisites are returned instead.
+// Configuration errors recorded in inspector results (See
+// [model.InspectorResult.ConfigErr]) are also returned. Errors other than
+// [model.ConfigError] are prefixed with the package path.
+func getActionErrors(act *checker.Action) []error {
+	var errs []error
+	for _, dep := range act.Deps {
+		if dep.Err != nil {
+			errs = append(errs, getActionErrors(dep)...)
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+
+	err := act.Err
+	if ir, ok := act.Result.(*model.InspectorResult); ok && ir != nil && err == nil {
+		err = ir.ConfigErr
+	}
+	if err == nil {
+		return nil
+	}
+	return []error{wrapPackageError(act.Package.PkgPath, err)}
+}
+
+// wrapPackageError prefixes the given error with the package path, unless it is
+// a [model.ConfigError], which already points to the config file.
+func wrapPackageError(pkgPath string, err error) error {
+	var cerr *model.ConfigError
+	if errors.As(err, &cerr) {
+		return err
+	}
+	return fmt.Errorf("%s: %w", pkgPath, err)
+}
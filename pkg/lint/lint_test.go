@@ -0,0 +1,285 @@
+package lint_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/godoc-lint/godoc-lint/pkg/config"
+	"github.com/godoc-lint/godoc-lint/pkg/lint"
+	"github.com/godoc-lint/godoc-lint/pkg/model"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestRun(t *testing.T) {
+	wd, err := os.Getwd()
+	require.NoError(t, err, "failed to get wd")
+
+	testdir := filepath.Join(wd, "../../testdata/lint")
+
+	type issue struct {
+		rule   model.Rule
+		file   string
+		line   int
+		symbol string
+	}
+
+	tests := []struct {
+		name     string
+		patterns []string
+		config   *config.PlainConfig
+		override *model.ConfigOverride
+		want     []issue
+		wantErr  string
+	}{{
+		name:     "default config",
+		patterns: []string{"./basic", "./nested/..."},
+		want: []issue{
+			{model.StartWithNameRule, "basic/basic.go", 4, "Foo"},
+		},
+	}, {
+		name:     "plain config",
+		patterns: []string{"./basic"},
+		config: &config.PlainConfig{
+			Default: ptr(string(model.DefaultSetNone)),
+			Enable:  []string{string(model.MaxLenRule), string(model.StartWithNameRule)},
+			Options: &config.PlainRuleOptions{Values: map[string]any{"max-len/length": 40}},
+		},
+		want: []issue{
+			{model.MaxLenRule, "basic/basic.go", 1, ""},
+			{model.MaxLenRule, "basic/basic.go", 4, "Foo"},
+			{model.StartWithNameRule, "basic/basic.go", 4, "Foo"},
+		},
+	}, {
+		name:     "override",
+		patterns: []string{"./basic"},
+		override: &model.ConfigOverride{
+			Disable: ptr(model.RuleSet{}.Add(model.StartWithNameRule)),
+		},
+	}, {
+		name:     "config file in sub-directory",
+		patterns: []string{"./nested/..."},
+		config: &config.PlainConfig{
+			Default: ptr(string(model.DefaultSetAll)),
+		},
+	}, {
+		name:     "invalid config",
+		patterns: []string{"./basic"},
+		config: &config.PlainConfig{
+			Enable: []string{"foo"},
+		},
+		wantErr: `invalid rule name(s) to enable: ["foo"]`,
+	}, {
+		name:    "no patterns",
+		wantErr: "no package patterns or files given",
+	}, {
+		name:     "invalid package",
+		patterns: []string{"./nonexistent"},
+		wantErr:  "nonexistent",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues, err := lint.Run(context.Background(), lint.Options{
+				Dir:      testdir,
+				Patterns: tt.patterns,
+				Config:   tt.config,
+				Override: tt.override,
+			})
+
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			got := make([]issue, 0, len(issues))
+			for _, i := range issues {
+				rel, err := filepath.Rel(testdir, i.Pos.Filename)
+				require.NoError(t, err)
+				got = append(got, issue{i.Rule, filepath.ToSlash(rel), i.Pos.Line, i.Symbol})
+				require.Equal(t, i.Rule.DocURL(), i.URL)
+				require.NotEmpty(t, i.Message)
+			}
+			if tt.want == nil {
+				tt.want = []issue{}
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRunFiles(t *testing.T) {
+	wd, err := os.Getwd()
+	require.NoError(t, err, "failed to get wd")
+
+	testdir := filepath.Join(wd, "../../testdata/lint")
+
+	type issue struct {
+		rule    model.Rule
+		file    string
+		line    int
+		pkgPath string
+	}
+
+	tests := []struct {
+		name     string
+		files    []lint.File
+		patterns []string
+		config   *config.PlainConfig
+		want     []issue
+		wantErr  string
+	}{{
+		name:  "file",
+		files: []lint.File{{Path: "basic/basic.go"}},
+		want: []issue{
+			{model.StartWithNameRule, "basic/basic.go", 4, "github.com/godoc-lint/godoc-lint/testdata/lint/basic"},
+		},
+	}, {
+		name:  "absolute path",
+		files: []lint.File{{Path: filepath.Join(testdir, "basic/basic.go")}},
+		want: []issue{
+			{model.StartWithNameRule, "basic/basic.go", 4, "github.com/godoc-lint/godoc-lint/testdata/lint/basic"},
+		},
+	}, {
+		name: "content",
+		files: []lint.File{{
+			Path:    "basic/basic.go",
+			Content: []byte("package basic\n\n// This godoc does not start with the symbol name.\nfunc Foo() {}\n"),
+		}},
+		config: &config.PlainConfig{
+			Default: ptr(string(model.DefaultSetAll)),
+		},
+		// Package-wide rules (e.g., require-pkg-doc) are skipped.
+		want: []issue{
+			{model.StartWithNameRule, "basic/basic.go", 3, "github.com/godoc-lint/godoc-lint/testdata/lint/basic"},
+		},
+	}, {
+		name:  "config file in sub-directory",
+		files: []lint.File{{Path: "basic/basic.go"}, {Path: "nested/sub/sub.go"}},
+		config: &config.PlainConfig{
+			Default: ptr(string(model.DefaultSetAll)),
+		},
+		want: []issue{
+			{model.StartWithNameRule, "basic/basic.go", 4, "github.com/godoc-lint/godoc-lint/testdata/lint/basic"},
+		},
+	}, {
+		name:    "invalid config file",
+		files:   []lint.File{{Path: "badconfig/a/a.go"}},
+		wantErr: "invalid config",
+	}, {
+		name:    "syntax error",
+		files:   []lint.File{{Path: "foo.go", Content: []byte("package")}},
+		wantErr: "expected 'IDENT'",
+	}, {
+		name:    "nonexistent file",
+		files:   []lint.File{{Path: "nonexistent.go"}},
+		wantErr: "cannot read file",
+	}, {
+		name:     "files and patterns",
+		files:    []lint.File{{Path: "basic/basic.go"}},
+		patterns: []string{"./basic"},
+		wantErr:  "cannot lint package patterns and files at the same time",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues, err := lint.Run(context.Background(), lint.Options{
+				Dir:      testdir,
+				Files:    tt.files,
+				Patterns: tt.patterns,
+				Config:   tt.config,
+			})
+
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			got := make([]issue, 0, len(issues))
+			for _, i := range issues {
+				rel, err := filepath.Rel(testdir, i.Pos.Filename)
+				require.NoError(t, err)
+				got = append(got, issue{i.Rule, filepath.ToSlash(rel), i.Pos.Line, i.PkgPath})
+			}
+			if tt.want == nil {
+				tt.want = []issue{}
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRunCanceled(t *testing.T) {
+	wd, err := os.Getwd()
+	require.NoError(t, err, "failed to get wd")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = lint.Run(ctx, lint.Options{
+		Dir:      filepath.Join(wd, "../../testdata/lint"),
+		Patterns: []string{"./basic"},
+	})
+	require.Error(t, err)
+}
+
+func TestRunConfigError(t *testing.T) {
+	wd, err := os.Getwd()
+	require.NoError(t, err, "failed to get wd")
+
+	testdir := filepath.Join(wd, "../../testdata/lint")
+
+	_, err = lint.Run(context.Background(), lint.Options{
+		Dir:      testdir,
+		Patterns: []string{"./badconfig/..."},
+	})
+
+	var cerr *model.ConfigError
+	require.ErrorAs(t, err, &cerr)
+	require.Equal(t, filepath.Join(testdir, "badconfig/.godoc-lint.yaml"), cerr.File)
+	require.Equal(t, cerr.Error(), err.Error(), "the same config error should be reported once")
+}
+
+func TestRunRangesAndRelated(t *testing.T) {
+	require := require.New(t)
+
+	wd, err := os.Getwd()
+	require.NoError(err, "failed to get wd")
+
+	issues, err := lint.Run(context.Background(), lint.Options{
+		Dir:      filepath.Join(wd, "../../testdata/lint"),
+		Patterns: []string{"./basic"},
+	})
+	require.NoError(err)
+	require.Len(issues, 1)
+
+	issue := issues[0]
+	require.Equal(model.StartWithNameRule, issue.Rule)
+	require.Equal([2]int{4, 4}, [2]int{issue.Pos.Line, issue.Pos.Column}, "range should start at the first word")
+	require.Equal([2]int{4, 8}, [2]int{issue.End.Line, issue.End.Column}, "range should end after the first word")
+
+	require.Empty(issue.Related, "the declaration right after the godoc should not be pointed out")
+
+	issues, err = lint.Run(context.Background(), lint.Options{
+		Dir:      filepath.Join(wd, "../../testdata/lint"),
+		Patterns: []string{"./related"},
+	})
+	require.NoError(err)
+	require.Len(issues, 1)
+
+	issue = issues[0]
+	require.Equal(model.StartWithNameRule, issue.Rule)
+	require.Len(issue.Related, 1)
+	related := issue.Related[0]
+	require.Equal(`symbol "Bar" is declared here`, related.Message)
+	require.Equal([2]int{10, 3}, [2]int{related.Pos.Line, related.Pos.Column})
+	require.Equal([2]int{10, 6}, [2]int{related.End.Line, related.End.Column})
+}
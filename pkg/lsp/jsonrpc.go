@@ -0,0 +1,163 @@
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// JSON-RPC error codes, as defined by the LSP specification.
+const (
+	codeParseError           = -32700
+	codeInvalidRequest       = -32600
+	codeMethodNotFound       = -32601
+	codeInvalidParams        = -32602
+	codeInternalError        = -32603
+	codeServerNotInitialized = -32002
+)
+
+// message represents a JSON-RPC 2.0 message, which is either a request (with
+// an ID), a notification (without an ID), or a response (with an ID, but no
+// method).
+type message struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id,omitempty"`
+	Method  string           `json:"method,omitempty"`
+	Params  json.RawMessage  `json:"params,omitempty"`
+	Result  json.RawMessage  `json:"result,omitempty"`
+	Error   *rpcError        `json:"error,omitempty"`
+}
+
+// isResponse determines whether the message is a response to a request sent
+// by the server.
+func (m *message) isResponse() bool {
+	return m.ID != nil && m.Method == ""
+}
+
+// rpcError represents a JSON-RPC error object.
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Error implements the error interface.
+func (e *rpcError) Error() string {
+	return e.Message
+}
+
+// conn reads and writes JSON-RPC messages, framed with the LSP base protocol
+// headers (i.e., "Content-Length").
+type conn struct {
+	r *bufio.Reader
+
+	// mu serializes writing the messages.
+	mu sync.Mutex
+	w  io.Writer
+
+	// nextID is the ID of the next request sent to the peer.
+	nextID int
+}
+
+func newConn(r io.Reader, w io.Writer) *conn {
+	return &conn{
+		r: bufio.NewReader(r),
+		w: w,
+	}
+}
+
+// read reads the next message. It returns [io.EOF] when the stream is closed
+// between messages.
+func (c *conn) read() (*message, error) {
+	header, err := textproto.NewReader(c.r).ReadMIMEHeader()
+	if err != nil {
+		if errors.Is(err, io.EOF) && len(header) == 0 {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("cannot read message header: %w", err)
+	}
+
+	length, err := strconv.Atoi(strings.TrimSpace(header.Get("Content-Length")))
+	if err != nil || length < 0 {
+		return nil, fmt.Errorf("invalid message content length %q", header.Get("Content-Length"))
+	}
+
+	body := make([]byte, length)
+	if _, err := io.ReadFull(c.r, body); err != nil {
+		return nil, fmt.Errorf("cannot read message content: %w", err)
+	}
+
+	m := &message{}
+	if err := json.Unmarshal(body, m); err != nil {
+		return nil, &rpcError{Code: codeParseError, Message: fmt.Sprintf("cannot parse message: %v", err)}
+	}
+	return m, nil
+}
+
+// write writes the given message.
+func (c *conn) write(m *message) error {
+	m.JSONRPC = "2.0"
+	body, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, err := fmt.Fprintf(c.w, "Content-Length: %d\r\n\r\n", len(body)); err != nil {
+		return err
+	}
+	_, err = c.w.Write(body)
+	return err
+}
+
+// reply writes the response to the request with the given ID. If err is not
+// nil, it is sent as the response error, and the result is ignored.
+func (c *conn) reply(id *json.RawMessage, result any, err error) error {
+	m := &message{ID: id}
+	if err != nil {
+		var rerr *rpcError
+		if !errors.As(err, &rerr) {
+			rerr = &rpcError{Code: codeInternalError, Message: err.Error()}
+		}
+		m.Error = rerr
+		return c.write(m)
+	}
+
+	raw, merr := json.Marshal(result)
+	if merr != nil {
+		return merr
+	}
+	m.Result = raw
+	return c.write(m)
+}
+
+// notify writes a notification with the given method and params.
+func (c *conn) notify(method string, params any) error {
+	raw, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	return c.write(&message{Method: method, Params: raw})
+}
+
+// call writes a request with the given method and params. The response is not
+// awaited, and is dropped when received.
+func (c *conn) call(method string, params any) error {
+	raw, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	c.nextID++
+	id := json.RawMessage(strconv.Itoa(c.nextID))
+	c.mu.Unlock()
+
+	return c.write(&message{ID: &id, Method: method, Params: raw})
+}
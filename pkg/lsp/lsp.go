@@ -0,0 +1,680 @@
+// Package lsp provides a language server for the linter, which speaks the
+// Language Server Protocol (LSP) over a stream (e.g., stdio).
+//
+// The server analyzes the package of a document when it is opened or saved,
+// and publishes the issues as diagnostics for all files of the package. Since
+// packages are loaded from the file system, unsaved changes are not analyzed.
+// Diagnostics are cleared when a document is closed, when a file is no longer
+// part of its package, or when the package cannot be analyzed anymore.
+// The server also provides:
+//
+//   - Code actions for the suggested fixes of the issues.
+//   - Hover previews of the rendered godocs of top-level symbols (when hovering
+//     over the symbol name or its godoc) and the package (when hovering over the
+//     package clause or its godoc).
+//
+// Configuration files are watched (if supported by the client), and the open
+// documents are re-analyzed when they change.
+package lsp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+
+	"github.com/godoc-lint/godoc-lint/pkg/compose"
+	"github.com/godoc-lint/godoc-lint/pkg/config"
+	"github.com/godoc-lint/godoc-lint/pkg/lint"
+	"github.com/godoc-lint/godoc-lint/pkg/model"
+	"github.com/godoc-lint/godoc-lint/pkg/render"
+	"github.com/godoc-lint/godoc-lint/pkg/version"
+)
+
+// serverName is the server name, which is also the source of the diagnostics.
+const serverName = "godoclint"
+
+// Options holds the options of the language server.
+type Options struct {
+	// Dir is the base directory, against which the include/exclude path
+	// patterns are resolved. If empty, the workspace root given by the client
+	// is used, or the current working directory if there is none.
+	Dir string
+
+	// Tests indicates whether test files should be analyzed, too.
+	Tests bool
+
+	// Override holds the configuration override (e.g., enabled or disabled
+	// rules), which takes precedence over the configuration files. It can be
+	// nil.
+	Override *model.ConfigOverride
+
+	// Checkers holds the custom checkers to run, in addition to the built-in
+	// ones (See [compose.CompositionConfig.Checkers]).
+	Checkers []model.Checker
+}
+
+// Server is a language server. Messages are handled one at a time, in the
+// order they are received.
+type Server struct {
+	opts Options
+	conn *conn
+
+	// baseDir is set when the server is initialized.
+	baseDir string
+
+	// watchConfig indicates whether the client supports watching the config
+	// files (via dynamic registration).
+	watchConfig bool
+
+	shutdown bool
+
+	// open holds the paths of the open documents.
+	open map[string]bool
+
+	// files holds the analyzed files, keyed by path.
+	files map[string]*file
+}
+
+// file holds the last analysis result of a file.
+type file struct {
+	// content is the file content at the time of analysis, to convert the
+	// positions.
+	content []byte
+
+	fset *token.FileSet
+	ast  *ast.File
+
+	// inspection is the inspection result of the file, or nil if the file is
+	// not applicable (e.g., excluded by the configuration).
+	inspection *model.FileInspection
+
+	// pkg is the rendering model of the package that the file belongs to.
+	pkg *render.Package
+
+	// variantSize is the number of files of the package variant that the file
+	// was taken from. Test variants of packages include more files.
+	variantSize int
+
+	issues []model.Issue
+}
+
+// NewServer returns a new language server with the given options.
+func NewServer(opts Options) *Server {
+	return &Server{
+		opts:  opts,
+		open:  make(map[string]bool),
+		files: make(map[string]*file),
+	}
+}
+
+// Serve reads the client messages from r and writes the server messages to w,
+// until the client sends the exit notification, or r is closed.
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	s.conn = newConn(r, w)
+	for {
+		m, err := s.conn.read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		var rerr *rpcError
+		if errors.As(err, &rerr) {
+			if err := s.conn.reply(nil, nil, rerr); err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if m.isResponse() {
+			// Responses to the server requests (e.g., registerCapability) are
+			// not needed.
+			continue
+		}
+
+		if m.Method == "exit" {
+			if !s.shutdown {
+				return errors.New("exit notification received before shutdown")
+			}
+			return nil
+		}
+
+		result, err := s.handle(ctx, m)
+		if m.ID != nil {
+			if err := s.conn.reply(m.ID, result, err); err != nil {
+				return err
+			}
+		} else if err != nil {
+			s.logf(MessageTypeError, "cannot handle %s: %v", m.Method, err)
+		}
+	}
+}
+
+// handle handles the given request or notification, and returns the result to
+// respond with, if it is a request.
+func (s *Server) handle(ctx context.Context, m *message) (any, error) {
+	if s.baseDir == "" && m.Method != "initialize" {
+		if m.ID == nil {
+			return nil, nil
+		}
+		return nil, &rpcError{Code: codeServerNotInitialized, Message: "server is not initialized"}
+	}
+
+	switch m.Method {
+	case "initialize":
+		var params InitializeParams
+		if err := unmarshalParams(m.Params, &params); err != nil {
+			return nil, err
+		}
+		return s.initialize(params)
+	case "initialized":
+		if s.watchConfig {
+			return nil, s.registerConfigWatchers()
+		}
+		return nil, nil
+	case "shutdown":
+		s.shutdown = true
+		return nil, nil
+	case "textDocument/didOpen":
+		var params DidOpenTextDocumentParams
+		if err := unmarshalParams(m.Params, &params); err != nil {
+			return nil, err
+		}
+		if path := uriPath(params.TextDocument.URI); isGoFile(path) {
+			s.open[path] = true
+			s.analyze(ctx, filepath.Dir(path))
+		}
+		return nil, nil
+	case "textDocument/didSave":
+		var params DidSaveTextDocumentParams
+		if err := unmarshalParams(m.Params, &params); err != nil {
+			return nil, err
+		}
+		if path := uriPath(params.TextDocument.URI); isGoFile(path) {
+			s.analyze(ctx, filepath.Dir(path))
+		}
+		return nil, nil
+	case "textDocument/didClose":
+		var params DidCloseTextDocumentParams
+		if err := unmarshalParams(m.Params, &params); err != nil {
+			return nil, err
+		}
+		path := uriPath(params.TextDocument.URI)
+		delete(s.open, path)
+		s.close(path)
+		return nil, nil
+	case "workspace/didChangeWatchedFiles":
+		// Only config files are watched, so all open documents are re-analyzed.
+		s.analyzeOpen(ctx)
+		return nil, nil
+	case "textDocument/codeAction":
+		var params CodeActionParams
+		if err := unmarshalParams(m.Params, &params); err != nil {
+			return nil, err
+		}
+		return s.codeActions(params), nil
+	case "textDocument/hover":
+		var params HoverParams
+		if err := unmarshalParams(m.Params, &params); err != nil {
+			return nil, err
+		}
+		return s.hover(params)
+	}
+
+	if m.ID != nil {
+		return nil, &rpcError{Code: codeMethodNotFound, Message: fmt.Sprintf("method not found: %s", m.Method)}
+	}
+	// Unknown notifications (e.g., "$/cancelRequest") are ignored.
+	return nil, nil
+}
+
+func unmarshalParams(raw json.RawMessage, v any) error {
+	if err := json.Unmarshal(raw, v); err != nil {
+		return &rpcError{Code: codeInvalidParams, Message: fmt.Sprintf("invalid params: %v", err)}
+	}
+	return nil
+}
+
+func (s *Server) initialize(params InitializeParams) (*InitializeResult, error) {
+	if s.baseDir != "" {
+		return nil, &rpcError{Code: codeInvalidRequest, Message: "server is already initialized"}
+	}
+
+	baseDir := s.opts.Dir
+	if baseDir == "" {
+		baseDir = uriPath(params.RootURI)
+	}
+	if baseDir == "" && len(params.WorkspaceFolders) > 0 {
+		baseDir = uriPath(params.WorkspaceFolders[0].URI)
+	}
+	if baseDir == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return nil, fmt.Errorf("cannot get current working directory: %w", err)
+		}
+		baseDir = wd
+	}
+
+	s.baseDir = baseDir
+	s.watchConfig = params.Capabilities.Workspace.DidChangeWatchedFiles.DynamicRegistration
+
+	return &InitializeResult{
+		Capabilities: ServerCapabilities{
+			TextDocumentSync: TextDocumentSyncOptions{
+				OpenClose: true,
+				Change:    TextDocumentSyncKindNone,
+			},
+			HoverProvider: true,
+			CodeActionProvider: CodeActionOptions{
+				CodeActionKinds: []string{CodeActionKindQuickFix},
+			},
+		},
+		ServerInfo: ServerInfo{
+			Name:    serverName,
+			Version: version.Current.String(),
+		},
+	}, nil
+}
+
+// registerConfigWatchers asks the client to watch the config files, including
+// the one given via the override (if any).
+func (s *Server) registerConfigWatchers() error {
+	var watchers []FileSystemWatcher
+	for _, name := range config.DefaultConfigFiles() {
+		watchers = append(watchers, FileSystemWatcher{GlobPattern: "**/" + name})
+	}
+	if s.opts.Override != nil && s.opts.Override.ConfigFilePath != nil {
+		path := *s.opts.Override.ConfigFilePath
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(s.baseDir, path)
+		}
+		watchers = append(watchers, FileSystemWatcher{GlobPattern: filepath.ToSlash(path)})
+	}
+
+	return s.conn.call("client/registerCapability", &RegistrationParams{
+		Registrations: []Registration{{
+			ID:              "godoclint-config-files",
+			Method:          "workspace/didChangeWatchedFiles",
+			RegisterOptions: &DidChangeWatchedFilesRegistrationOptions{Watchers: watchers},
+		}},
+	})
+}
+
+// analyzeOpen analyzes the packages of the open documents.
+func (s *Server) analyzeOpen(ctx context.Context) {
+	var dirs []string
+	for path := range s.open {
+		dirs = append(dirs, filepath.Dir(path))
+	}
+	slices.Sort(dirs)
+	for _, dir := range slices.Compact(dirs) {
+		s.analyze(ctx, dir)
+	}
+}
+
+// analyze analyzes the package in the given directory, and publishes the
+// diagnostics of its files. Errors (e.g., an invalid config) are shown to the
+// user, and the previous diagnostics of the package are cleared, since they
+// may be stale.
+func (s *Server) analyze(ctx context.Context, dir string) {
+	files, err := s.load(ctx, dir)
+	if err != nil {
+		var cerr *model.ConfigError
+		if errors.As(err, &cerr) {
+			s.showf(MessageTypeError, "%v", err)
+		} else {
+			s.logf(MessageTypeWarning, "cannot analyze package at %q: %v", dir, err)
+		}
+		s.clear(s.filesIn(dir)...)
+		return
+	}
+
+	// Files that are no longer part of the package (e.g., deleted, or excluded
+	// by build constraints) are cleared.
+	var stale []string
+	for _, path := range s.filesIn(dir) {
+		if _, ok := files[path]; !ok {
+			stale = append(stale, path)
+		}
+	}
+	s.clear(stale...)
+
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	slices.Sort(paths)
+
+	for _, path := range paths {
+		s.files[path] = files[path]
+	}
+
+	for _, path := range paths {
+		f := files[path]
+
+		diags := make([]Diagnostic, 0, len(f.issues))
+		for _, issue := range f.issues {
+			diags = append(diags, s.toDiagnostic(f, issue))
+		}
+		s.publish(path, diags)
+	}
+}
+
+// close clears the diagnostics of the given closed document. If no other
+// document of the same package is open, the diagnostics of all files of the
+// package are cleared.
+func (s *Server) close(path string) {
+	dir := filepath.Dir(path)
+	for open := range s.open {
+		if filepath.Dir(open) == dir {
+			if _, ok := s.files[path]; ok {
+				s.clear(path)
+			}
+			return
+		}
+	}
+	s.clear(s.filesIn(dir)...)
+}
+
+// filesIn returns the sorted paths of the analyzed files in the given
+// directory.
+func (s *Server) filesIn(dir string) []string {
+	var paths []string
+	for path := range s.files {
+		if filepath.Dir(path) == dir {
+			paths = append(paths, path)
+		}
+	}
+	slices.Sort(paths)
+	return paths
+}
+
+// clear publishes empty diagnostics for the given files, which are no longer
+// considered analyzed.
+func (s *Server) clear(paths ...string) {
+	for _, path := range paths {
+		delete(s.files, path)
+		s.publish(path, []Diagnostic{})
+	}
+}
+
+// publish publishes the given diagnostics of the given file.
+func (s *Server) publish(path string, diags []Diagnostic) {
+	if err := s.conn.notify("textDocument/publishDiagnostics", &PublishDiagnosticsParams{
+		URI:         fileURI(path),
+		Diagnostics: diags,
+	}); err != nil {
+		s.logf(MessageTypeError, "cannot publish diagnostics: %v", err)
+	}
+}
+
+// load loads and analyzes the package in the given directory, and returns its
+// files, keyed by path. If test files are included, the files are taken from
+// the package variant with the most files.
+//
+// The linter components are composed on every call, since the composed config
+// builder caches the configs, which would otherwise not be reloaded.
+func (s *Server) load(ctx context.Context, dir string) (map[string]*file, error) {
+	pkgs, err := lint.LoadPackages(ctx, dir, []string{"."}, s.opts.Tests)
+	if err != nil {
+		return nil, err
+	}
+
+	composition := compose.Compose(compose.CompositionConfig{
+		BaseDir:  s.baseDir,
+		Checkers: s.opts.Checkers,
+	})
+	if s.opts.Override != nil {
+		composition.ConfigBuilder.SetOverride(s.opts.Override)
+	}
+
+	inspector := composition.Inspector.GetAnalyzer()
+	analyzers := []*analysis.Analyzer{inspector, composition.Analyzer.GetAnalyzer()}
+	graph, err := checker.Analyze(analyzers, pkgs, nil)
+	if err != nil {
+		return nil, err
+	}
+	issues, err := lint.CollectIssues(graph)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string]*file)
+	for _, act := range graph.Roots {
+		if act.Analyzer != inspector || strings.HasSuffix(act.Package.ID, ".test") {
+			continue
+		}
+		result, ok := act.Result.(*model.InspectorResult)
+		if !ok || result == nil {
+			continue
+		}
+
+		pkg := act.Package
+		var rfiles []render.File
+		for _, f := range pkg.Syntax {
+			if fi := result.Files[f]; fi != nil {
+				rfiles = append(rfiles, render.File{AST: f, Inspection: fi})
+			}
+		}
+		rpkg := render.NewPackage(pkg.Fset, pkg.PkgPath, pkg.Name, rfiles)
+
+		for _, f := range pkg.Syntax {
+			path := pkg.Fset.File(f.Pos()).Name()
+			if prev, ok := files[path]; ok && prev.variantSize >= len(pkg.Syntax) {
+				continue
+			}
+			files[path] = &file{
+				fset:        pkg.Fset,
+				ast:         f,
+				inspection:  result.Files[f],
+				pkg:         rpkg,
+				variantSize: len(pkg.Syntax),
+			}
+		}
+	}
+
+	for path, f := range files {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		f.content = content
+	}
+	for _, issue := range issues {
+		if f, ok := files[issue.Pos.Filename]; ok {
+			f.issues = append(f.issues, issue)
+		}
+	}
+	return files, nil
+}
+
+// toDiagnostic converts the given issue of the given file to a diagnostic.
+func (s *Server) toDiagnostic(f *file, issue model.Issue) Diagnostic {
+	start := toPosition(f.content, issue.Pos)
+	end := start
+	if issue.End.IsValid() {
+		end = toPosition(f.content, issue.End)
+	}
+
+	d := Diagnostic{
+		Range:    Range{Start: start, End: end},
+		Severity: DiagnosticSeverityWarning,
+		Code:     string(issue.Rule),
+		Source:   serverName,
+		Message:  issue.Message,
+	}
+	if issue.URL != "" {
+		d.CodeDescription = &CodeDescription{Href: issue.URL}
+	}
+
+	for _, rel := range issue.Related {
+		content := s.contentOf(rel.Pos.Filename, f)
+		relStart := toPosition(content, rel.Pos)
+		relEnd := relStart
+		if rel.End.IsValid() {
+			relEnd = toPosition(content, rel.End)
+		}
+		d.RelatedInformation = append(d.RelatedInformation, DiagnosticRelatedInformation{
+			Location: Location{
+				URI:   fileURI(rel.Pos.Filename),
+				Range: Range{Start: relStart, End: relEnd},
+			},
+			Message: rel.Message,
+		})
+	}
+	return d
+}
+
+// contentOf returns the content of the analyzed file at the given path, or the
+// content of the given fallback file if the path is not known.
+func (s *Server) contentOf(path string, fallback *file) []byte {
+	if f, ok := s.files[path]; ok {
+		return f.content
+	}
+	return fallback.content
+}
+
+// codeActions returns the quick fixes of the issues overlapping the given
+// range.
+func (s *Server) codeActions(params CodeActionParams) []CodeAction {
+	actions := []CodeAction{}
+	f := s.files[uriPath(params.TextDocument.URI)]
+	if f == nil {
+		return actions
+	}
+
+	for _, issue := range f.issues {
+		if len(issue.Fixes) == 0 {
+			continue
+		}
+		diag := s.toDiagnostic(f, issue)
+		if !diag.Range.overlaps(params.Range) {
+			continue
+		}
+
+		for _, fix := range issue.Fixes {
+			changes := make(map[DocumentURI][]TextEdit)
+			for _, edit := range fix.Edits {
+				content := s.contentOf(edit.Pos.Filename, f)
+				uri := fileURI(edit.Pos.Filename)
+				changes[uri] = append(changes[uri], TextEdit{
+					Range: Range{
+						Start: toPosition(content, edit.Pos),
+						End:   toPosition(content, edit.End),
+					},
+					NewText: edit.NewText,
+				})
+			}
+			actions = append(actions, CodeAction{
+				Title:       fix.Message,
+				Kind:        CodeActionKindQuickFix,
+				Diagnostics: []Diagnostic{diag},
+				Edit:        &WorkspaceEdit{Changes: changes},
+			})
+		}
+	}
+	return actions
+}
+
+// hover returns the rendered godoc of the symbol (or the package) at the given
+// position, or nil if there is none.
+func (s *Server) hover(params HoverParams) (*Hover, error) {
+	f := s.files[uriPath(params.TextDocument.URI)]
+	if f == nil || f.inspection == nil {
+		return nil, nil
+	}
+
+	tf := f.fset.File(f.ast.Pos())
+	pos := tf.Pos(min(toOffset(f.content, params.Position), tf.Size()))
+
+	var buf bytes.Buffer
+	var ident *ast.Ident
+	switch {
+	case within(pos, f.ast.Package, f.ast.Name.End()) || f.ast.Doc != nil && within(pos, f.ast.Doc.Pos(), f.ast.Doc.End()):
+		if err := render.WritePackageDocMarkdown(&buf, f.pkg); err != nil {
+			return nil, err
+		}
+		ident = f.ast.Name
+	default:
+		sd, d := f.findDecl(pos)
+		if d == nil {
+			return nil, nil
+		}
+		if err := render.WriteDeclMarkdown(&buf, d); err != nil {
+			return nil, err
+		}
+		ident = sd.Ident
+	}
+
+	return &Hover{
+		Contents: MarkupContent{Kind: "markdown", Value: buf.String()},
+		Range: &Range{
+			Start: toPosition(f.content, f.fset.Position(ident.Pos())),
+			End:   toPosition(f.content, f.fset.Position(ident.End())),
+		},
+	}, nil
+}
+
+// findDecl returns the symbol declaration whose name or godoc is at the given
+// position, together with its rendering model. It returns nil if there is
+// none.
+func (f *file) findDecl(pos token.Pos) (*model.SymbolDecl, *render.Decl) {
+	for i := range f.inspection.SymbolDecl {
+		sd := &f.inspection.SymbolDecl[i]
+		if sd.Kind == model.SymbolDeclKindBad || sd.Ident == nil {
+			continue
+		}
+
+		hit := within(pos, sd.Ident.Pos(), sd.Ident.End())
+		for _, doc := range []*model.CommentGroup{sd.Doc, sd.ParentDoc, sd.TrailingDoc} {
+			if doc != nil && within(pos, doc.CG.Pos(), doc.CG.End()) {
+				hit = true
+			}
+		}
+		if !hit {
+			continue
+		}
+
+		identPos := f.fset.Position(sd.Ident.Pos())
+		for j := range f.pkg.Decls {
+			d := &f.pkg.Decls[j]
+			if d.Range.Contains(identPos) && slices.Contains(d.Names, sd.Name) {
+				return sd, d
+			}
+		}
+	}
+	return nil, nil
+}
+
+// within determines whether the given position is within the given range,
+// including its end.
+func within(pos, start, end token.Pos) bool {
+	return start <= pos && pos <= end
+}
+
+func isGoFile(path string) bool {
+	return strings.HasSuffix(path, ".go")
+}
+
+// logf logs the given message in the client.
+func (s *Server) logf(typ MessageType, format string, args ...any) {
+	_ = s.conn.notify("window/logMessage", &ShowMessageParams{Type: typ, Message: fmt.Sprintf(format, args...)})
+}
+
+// showf shows the given message to the user.
+func (s *Server) showf(typ MessageType, format string, args ...any) {
+	_ = s.conn.notify("window/showMessage", &ShowMessageParams{Type: typ, Message: fmt.Sprintf(format, args...)})
+}
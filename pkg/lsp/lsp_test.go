@@ -0,0 +1,427 @@
+package lsp_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"io"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/godoc-lint/godoc-lint/pkg/lsp"
+	"github.com/godoc-lint/godoc-lint/pkg/model"
+	"github.com/godoc-lint/godoc-lint/pkg/util"
+)
+
+const noTodoRule model.Rule = "no-todo"
+
+// noTodoChecker is a custom checker that reports "TODO" notes in godocs, with a
+// suggested fix to remove them.
+type noTodoChecker struct{}
+
+func (c *noTodoChecker) GetCoveredRules() model.RuleSet {
+	return model.RuleSet{}.Add(noTodoRule)
+}
+
+func (c *noTodoChecker) ListRuleMetadata() []model.RuleMetadata {
+	return []model.RuleMetadata{{
+		Rule:        noTodoRule,
+		Category:    model.RuleCategoryExtra,
+		Summary:     "Godocs should not contain TODO notes.",
+		Description: "Godocs should not contain TODO notes.",
+	}}
+}
+
+func (c *noTodoChecker) Apply(actx *model.AnalysisContext) error {
+	for _, ir := range util.AnalysisApplicableFiles(actx, false, c.GetCoveredRules()) {
+		for _, sd := range ir.SymbolDecl {
+			if sd.Doc == nil {
+				continue
+			}
+			for _, line := range sd.Doc.CG.List {
+				i := strings.Index(line.Text, " TODO")
+				if i < 0 {
+					continue
+				}
+				pos := line.Pos() + token.Pos(i) + 1
+				end := line.End()
+				actx.Pass.Report(analysis.Diagnostic{
+					Pos:      pos,
+					End:      end,
+					Category: string(noTodoRule),
+					Message:  "godoc should not contain TODO",
+					SuggestedFixes: []analysis.SuggestedFix{{
+						Message:   "Remove TODO note",
+						TextEdits: []analysis.TextEdit{{Pos: pos - 1, End: end}},
+					}},
+				})
+			}
+		}
+	}
+	return nil
+}
+
+// message is a JSON-RPC message, as seen by the client.
+type message struct {
+	ID     *int            `json:"id,omitempty"`
+	Method string          `json:"method,omitempty"`
+	Params json.RawMessage `json:"params,omitempty"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// client is an in-process LSP client, connected to a server via pipes.
+type client struct {
+	t      *testing.T
+	w      io.Writer
+	nextID int
+
+	// messages holds the messages received from the server.
+	messages chan *message
+}
+
+func newClient(t *testing.T, server *lsp.Server) (*client, <-chan error) {
+	serverIn, clientOut := io.Pipe()
+	clientIn, serverOut := io.Pipe()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- server.Serve(context.Background(), serverIn, serverOut)
+		_ = serverOut.Close()
+	}()
+
+	c := &client{
+		t:        t,
+		w:        clientOut,
+		messages: make(chan *message, 100),
+	}
+	go func() {
+		defer close(c.messages)
+		r := bufio.NewReader(clientIn)
+		for {
+			header, err := textproto.NewReader(r).ReadMIMEHeader()
+			if err != nil {
+				return
+			}
+			length, _ := strconv.Atoi(header.Get("Content-Length"))
+			body := make([]byte, length)
+			if _, err := io.ReadFull(r, body); err != nil {
+				return
+			}
+			m := &message{}
+			if err := json.Unmarshal(body, m); err != nil {
+				return
+			}
+			c.messages <- m
+		}
+	}()
+
+	t.Cleanup(func() {
+		_ = clientOut.Close()
+		_ = clientIn.Close()
+	})
+	return c, done
+}
+
+func (c *client) write(m map[string]any) {
+	m["jsonrpc"] = "2.0"
+	body, err := json.Marshal(m)
+	require.NoError(c.t, err)
+	_, err = fmt.Fprintf(c.w, "Content-Length: %d\r\n\r\n%s", len(body), body)
+	require.NoError(c.t, err)
+}
+
+// notify sends a notification.
+func (c *client) notify(method string, params any) {
+	c.write(map[string]any{"method": method, "params": params})
+}
+
+// call sends a request and returns the response, unmarshalled into result.
+func (c *client) call(method string, params, result any) {
+	c.nextID++
+	c.write(map[string]any{"id": c.nextID, "method": method, "params": params})
+
+	m := c.next()
+	require.NotNil(c.t, m.ID, "expected response to %s, got %s", method, m.Method)
+	require.Equal(c.t, c.nextID, *m.ID)
+	require.Nil(c.t, m.Error)
+	if result != nil {
+		require.NoError(c.t, json.Unmarshal(m.Result, result))
+	}
+}
+
+// next returns the next message received from the server, other than log
+// messages.
+func (c *client) next() *message {
+	for {
+		select {
+		case m, ok := <-c.messages:
+			require.True(c.t, ok, "connection closed")
+			if m.Method == "window/logMessage" {
+				c.t.Logf("server log: %s", m.Params)
+				continue
+			}
+			return m
+		case <-time.After(time.Minute):
+			require.FailNow(c.t, "timed out waiting for server message")
+		}
+	}
+}
+
+// expectNotification returns the next message, which should be a notification
+// with the given method, unmarshalled into params.
+func (c *client) expectNotification(method string, params any) {
+	m := c.next()
+	require.Equal(c.t, method, m.Method)
+	require.NoError(c.t, json.Unmarshal(m.Params, params))
+}
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+}
+
+func TestServer(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"go.mod":           "module example.com/foo\n\ngo 1.24\n",
+		".godoc-lint.yaml": "enable: [no-todo]\n",
+		"foo.go": `// Package foo is a package to test the language server.
+package foo
+
+// This is a función. TODO: fix
+func Foo() {}
+`,
+	})
+	uri := "file://" + filepath.ToSlash(filepath.Join(dir, "foo.go"))
+
+	server := lsp.NewServer(lsp.Options{
+		Checkers: []model.Checker{&noTodoChecker{}},
+	})
+	c, done := newClient(t, server)
+
+	var initResult lsp.InitializeResult
+	c.call("initialize", map[string]any{
+		"rootUri": "file://" + filepath.ToSlash(dir),
+		"capabilities": map[string]any{
+			"workspace": map[string]any{
+				"didChangeWatchedFiles": map[string]any{"dynamicRegistration": true},
+			},
+		},
+	}, &initResult)
+	require.True(t, initResult.Capabilities.HoverProvider)
+	require.Equal(t, "godoclint", initResult.ServerInfo.Name)
+
+	c.notify("initialized", map[string]any{})
+	registration := c.next()
+	require.Equal(t, "client/registerCapability", registration.Method)
+	require.Contains(t, string(registration.Params), `"globPattern":"**/.godoc-lint.yaml"`)
+
+	c.notify("textDocument/didOpen", map[string]any{
+		"textDocument": map[string]any{"uri": uri, "languageId": "go", "version": 1, "text": ""},
+	})
+	var diags lsp.PublishDiagnosticsParams
+	c.expectNotification("textDocument/publishDiagnostics", &diags)
+	require.Equal(t, lsp.DocumentURI(uri), diags.URI)
+	require.Len(t, diags.Diagnostics, 2)
+
+	swn := diags.Diagnostics[0]
+	require.Equal(t, string(model.StartWithNameRule), swn.Code)
+	require.NotNil(t, swn.CodeDescription)
+	require.Equal(t, model.StartWithNameRule.DocURL(), swn.CodeDescription.Href)
+	require.Equal(t, lsp.Range{Start: lsp.Position{Line: 3, Character: 3}, End: lsp.Position{Line: 3, Character: 7}}, swn.Range)
+	require.Empty(t, swn.RelatedInformation, "the declaration right after the godoc should not be pointed out")
+
+	// The "TODO" note starts at byte 23, but character 22, since "ó" is encoded
+	// as two bytes in UTF-8, but one code unit in UTF-16.
+	todo := diags.Diagnostics[1]
+	require.Equal(t, string(noTodoRule), todo.Code)
+	require.Equal(t, lsp.Range{Start: lsp.Position{Line: 3, Character: 22}, End: lsp.Position{Line: 3, Character: 31}}, todo.Range)
+	require.Equal(t, "godoclint", todo.Source)
+
+	t.Run("code actions", func(t *testing.T) {
+		c.t = t
+		var actions []lsp.CodeAction
+		c.call("textDocument/codeAction", map[string]any{
+			"textDocument": map[string]any{"uri": uri},
+			"range":        lsp.Range{Start: lsp.Position{Line: 3, Character: 25}, End: lsp.Position{Line: 3, Character: 25}},
+		}, &actions)
+		require.Len(t, actions, 1)
+		require.Equal(t, "Remove TODO note", actions[0].Title)
+		require.Equal(t, "quickfix", actions[0].Kind)
+		require.Equal(t, []lsp.TextEdit{{
+			Range: lsp.Range{Start: lsp.Position{Line: 3, Character: 21}, End: lsp.Position{Line: 3, Character: 31}},
+		}}, actions[0].Edit.Changes[lsp.DocumentURI(uri)])
+
+		c.call("textDocument/codeAction", map[string]any{
+			"textDocument": map[string]any{"uri": uri},
+			"range":        lsp.Range{Start: lsp.Position{Line: 0, Character: 0}, End: lsp.Position{Line: 1, Character: 0}},
+		}, &actions)
+		require.Empty(t, actions)
+	})
+
+	t.Run("hover", func(t *testing.T) {
+		c.t = t
+		var hover *lsp.Hover
+		c.call("textDocument/hover", map[string]any{
+			"textDocument": map[string]any{"uri": uri},
+			"position":     lsp.Position{Line: 4, Character: 6},
+		}, &hover)
+		require.NotNil(t, hover)
+		require.Equal(t, "markdown", hover.Contents.Kind)
+		require.Equal(t, "```go\nfunc Foo()\n```\n\nThis is a función. TODO: fix\n", hover.Contents.Value)
+		require.Equal(t, &lsp.Range{Start: lsp.Position{Line: 4, Character: 5}, End: lsp.Position{Line: 4, Character: 8}}, hover.Range)
+
+		c.call("textDocument/hover", map[string]any{
+			"textDocument": map[string]any{"uri": uri},
+			"position":     lsp.Position{Line: 1, Character: 2},
+		}, &hover)
+		require.NotNil(t, hover)
+		require.Equal(t, "```go\npackage foo // import \"example.com/foo\"\n```\n\nPackage foo is a package to test the language server.\n", hover.Contents.Value)
+
+		hover = nil
+		c.call("textDocument/hover", map[string]any{
+			"textDocument": map[string]any{"uri": uri},
+			"position":     lsp.Position{Line: 2, Character: 0},
+		}, &hover)
+		require.Nil(t, hover)
+	})
+
+	t.Run("config reload", func(t *testing.T) {
+		c.t = t
+		writeFiles(t, dir, map[string]string{".godoc-lint.yaml": "default: none\nenable: [no-todo]\n"})
+		c.notify("workspace/didChangeWatchedFiles", map[string]any{
+			"changes": []map[string]any{{"uri": "file://" + filepath.ToSlash(filepath.Join(dir, ".godoc-lint.yaml")), "type": 2}},
+		})
+		c.expectNotification("textDocument/publishDiagnostics", &diags)
+		require.Len(t, diags.Diagnostics, 1)
+		require.Equal(t, string(noTodoRule), diags.Diagnostics[0].Code)
+
+		writeFiles(t, dir, map[string]string{".godoc-lint.yaml": "default: foo\n"})
+		c.notify("workspace/didChangeWatchedFiles", map[string]any{
+			"changes": []map[string]any{{"uri": "file://" + filepath.ToSlash(filepath.Join(dir, ".godoc-lint.yaml")), "type": 2}},
+		})
+		var shown lsp.ShowMessageParams
+		c.expectNotification("window/showMessage", &shown)
+		require.Equal(t, lsp.MessageTypeError, shown.Type)
+		require.Contains(t, shown.Message, "invalid config")
+
+		// The previous diagnostics are stale.
+		c.expectNotification("textDocument/publishDiagnostics", &diags)
+		require.Equal(t, lsp.DocumentURI(uri), diags.URI)
+		require.Empty(t, diags.Diagnostics)
+	})
+
+	t.Run("save", func(t *testing.T) {
+		c.t = t
+		writeFiles(t, dir, map[string]string{
+			".godoc-lint.yaml": "default: none\nenable: [start-with-name]\n",
+			"foo.go":           "package foo\n\n// Foo is a function.\nfunc Foo() {}\n",
+		})
+		c.notify("textDocument/didSave", map[string]any{
+			"textDocument": map[string]any{"uri": uri},
+		})
+		c.expectNotification("textDocument/publishDiagnostics", &diags)
+		require.Equal(t, lsp.DocumentURI(uri), diags.URI)
+		require.Empty(t, diags.Diagnostics)
+	})
+
+	barURI := "file://" + filepath.ToSlash(filepath.Join(dir, "bar.go"))
+
+	t.Run("removed file", func(t *testing.T) {
+		c.t = t
+		writeFiles(t, dir, map[string]string{"bar.go": "package foo\n\n// bar is a function.\nfunc Bar() {}\n"})
+		c.notify("textDocument/didSave", map[string]any{
+			"textDocument": map[string]any{"uri": uri},
+		})
+		c.expectNotification("textDocument/publishDiagnostics", &diags)
+		require.Equal(t, lsp.DocumentURI(barURI), diags.URI)
+		require.Len(t, diags.Diagnostics, 1)
+		c.expectNotification("textDocument/publishDiagnostics", &diags)
+		require.Equal(t, lsp.DocumentURI(uri), diags.URI)
+
+		require.NoError(t, os.Remove(filepath.Join(dir, "bar.go")))
+		c.notify("textDocument/didSave", map[string]any{
+			"textDocument": map[string]any{"uri": uri},
+		})
+		c.expectNotification("textDocument/publishDiagnostics", &diags)
+		require.Equal(t, lsp.DocumentURI(barURI), diags.URI)
+		require.Empty(t, diags.Diagnostics)
+		c.expectNotification("textDocument/publishDiagnostics", &diags)
+		require.Equal(t, lsp.DocumentURI(uri), diags.URI)
+	})
+
+	t.Run("load error", func(t *testing.T) {
+		c.t = t
+		writeFiles(t, dir, map[string]string{"foo.go": "package foo\n\n// foo is a function.\nfunc Foo() {}\n"})
+		c.notify("textDocument/didSave", map[string]any{
+			"textDocument": map[string]any{"uri": uri},
+		})
+		c.expectNotification("textDocument/publishDiagnostics", &diags)
+		require.Len(t, diags.Diagnostics, 1)
+
+		writeFiles(t, dir, map[string]string{"go.mod": "module\n"})
+		c.notify("textDocument/didSave", map[string]any{
+			"textDocument": map[string]any{"uri": uri},
+		})
+		c.expectNotification("textDocument/publishDiagnostics", &diags)
+		require.Equal(t, lsp.DocumentURI(uri), diags.URI)
+		require.Empty(t, diags.Diagnostics)
+
+		writeFiles(t, dir, map[string]string{"go.mod": "module example.com/foo\n\ngo 1.24\n"})
+		c.notify("textDocument/didSave", map[string]any{
+			"textDocument": map[string]any{"uri": uri},
+		})
+		c.expectNotification("textDocument/publishDiagnostics", &diags)
+		require.Len(t, diags.Diagnostics, 1)
+	})
+
+	t.Run("close", func(t *testing.T) {
+		c.t = t
+		c.notify("textDocument/didClose", map[string]any{
+			"textDocument": map[string]any{"uri": uri},
+		})
+		c.expectNotification("textDocument/publishDiagnostics", &diags)
+		require.Equal(t, lsp.DocumentURI(uri), diags.URI)
+		require.Empty(t, diags.Diagnostics)
+
+		var hover *lsp.Hover
+		c.call("textDocument/hover", map[string]any{
+			"textDocument": map[string]any{"uri": uri},
+			"position":     lsp.Position{Line: 3, Character: 6},
+		}, &hover)
+		require.Nil(t, hover)
+	})
+
+	c.t = t
+	c.call("shutdown", nil, nil)
+	c.notify("exit", nil)
+	require.NoError(t, <-done)
+}
+
+func TestServerNotInitialized(t *testing.T) {
+	c, done := newClient(t, lsp.NewServer(lsp.Options{}))
+
+	c.nextID++
+	c.write(map[string]any{"id": c.nextID, "method": "textDocument/hover", "params": map[string]any{}})
+	m := c.next()
+	require.NotNil(t, m.Error)
+	require.Equal(t, -32002, m.Error.Code)
+
+	c.notify("exit", nil)
+	require.ErrorContains(t, <-done, "exit notification received before shutdown")
+}
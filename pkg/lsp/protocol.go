@@ -0,0 +1,325 @@
+package lsp
+
+import (
+	"bytes"
+	"go/token"
+	"net/url"
+	"path/filepath"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/godoc-lint/godoc-lint/pkg/util"
+)
+
+// This file holds the subset of the LSP types used by the server. See the
+// specification for the details:
+//
+//	https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/
+
+// DocumentURI is the URI of a document (e.g., "file:///foo/bar.go").
+type DocumentURI string
+
+// Position is a zero-based position in a document, where the character offset
+// is in UTF-16 code units.
+type Position struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+// before determines whether the position is before the given one.
+func (p Position) before(other Position) bool {
+	return p.Line < other.Line || p.Line == other.Line && p.Character < other.Character
+}
+
+// Range is a range in a document, with an exclusive end.
+type Range struct {
+	Start Position `json:"start"`
+	End   Position `json:"end"`
+}
+
+// overlaps determines whether the range overlaps the given one, where empty
+// ranges touching each other are considered overlapping.
+func (r Range) overlaps(other Range) bool {
+	return !r.End.before(other.Start) && !other.End.before(r.Start)
+}
+
+// DiagnosticSeverity is the severity of a diagnostic.
+type DiagnosticSeverity int
+
+// DiagnosticSeverityWarning is the severity of the reported issues.
+const DiagnosticSeverityWarning DiagnosticSeverity = 2
+
+// MessageType is the type of a message shown or logged by the client.
+type MessageType int
+
+// Message types.
+const (
+	MessageTypeError   MessageType = 1
+	MessageTypeWarning MessageType = 2
+	MessageTypeInfo    MessageType = 3
+	MessageTypeLog     MessageType = 4
+)
+
+// CodeDescription holds the documentation URL of a diagnostic code.
+type CodeDescription struct {
+	Href string `json:"href"`
+}
+
+// Diagnostic represents an issue in a document.
+type Diagnostic struct {
+	Range              Range                          `json:"range"`
+	Severity           DiagnosticSeverity             `json:"severity,omitempty"`
+	Code               string                         `json:"code,omitempty"`
+	CodeDescription    *CodeDescription               `json:"codeDescription,omitempty"`
+	Source             string                         `json:"source,omitempty"`
+	Message            string                         `json:"message"`
+	RelatedInformation []DiagnosticRelatedInformation `json:"relatedInformation,omitempty"`
+}
+
+// Location represents a range in a document.
+type Location struct {
+	URI   DocumentURI `json:"uri"`
+	Range Range       `json:"range"`
+}
+
+// DiagnosticRelatedInformation represents a location related to a diagnostic.
+type DiagnosticRelatedInformation struct {
+	Location Location `json:"location"`
+	Message  string   `json:"message"`
+}
+
+// TextEdit is a text edit applicable to a document.
+type TextEdit struct {
+	Range   Range  `json:"range"`
+	NewText string `json:"newText"`
+}
+
+// WorkspaceEdit holds the changes to documents.
+type WorkspaceEdit struct {
+	Changes map[DocumentURI][]TextEdit `json:"changes"`
+}
+
+// CodeActionKindQuickFix is the kind of the code actions of suggested fixes.
+const CodeActionKindQuickFix = "quickfix"
+
+// CodeAction represents a change that can be performed in the client.
+type CodeAction struct {
+	Title       string         `json:"title"`
+	Kind        string         `json:"kind,omitempty"`
+	Diagnostics []Diagnostic   `json:"diagnostics,omitempty"`
+	Edit        *WorkspaceEdit `json:"edit,omitempty"`
+}
+
+// MarkupContent is a formatted (e.g., Markdown) string.
+type MarkupContent struct {
+	Kind  string `json:"kind"`
+	Value string `json:"value"`
+}
+
+// Hover is the result of a hover request.
+type Hover struct {
+	Contents MarkupContent `json:"contents"`
+	Range    *Range        `json:"range,omitempty"`
+}
+
+// TextDocumentIdentifier identifies a document.
+type TextDocumentIdentifier struct {
+	URI DocumentURI `json:"uri"`
+}
+
+// TextDocumentItem is a document opened in the client.
+type TextDocumentItem struct {
+	URI        DocumentURI `json:"uri"`
+	LanguageID string      `json:"languageId"`
+	Version    int         `json:"version"`
+	Text       string      `json:"text"`
+}
+
+// InitializeParams holds the params of the initialize request.
+type InitializeParams struct {
+	RootURI          DocumentURI       `json:"rootUri,omitempty"`
+	WorkspaceFolders []WorkspaceFolder `json:"workspaceFolders,omitempty"`
+	Capabilities     struct {
+		Workspace struct {
+			DidChangeWatchedFiles struct {
+				DynamicRegistration bool `json:"dynamicRegistration"`
+			} `json:"didChangeWatchedFiles"`
+		} `json:"workspace"`
+	} `json:"capabilities"`
+}
+
+// WorkspaceFolder is a workspace folder in the client.
+type WorkspaceFolder struct {
+	URI  DocumentURI `json:"uri"`
+	Name string      `json:"name"`
+}
+
+// InitializeResult is the result of the initialize request.
+type InitializeResult struct {
+	Capabilities ServerCapabilities `json:"capabilities"`
+	ServerInfo   ServerInfo         `json:"serverInfo"`
+}
+
+// ServerInfo holds the server name and version.
+type ServerInfo struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+// ServerCapabilities holds the capabilities of the server.
+type ServerCapabilities struct {
+	TextDocumentSync   TextDocumentSyncOptions `json:"textDocumentSync"`
+	HoverProvider      bool                    `json:"hoverProvider"`
+	CodeActionProvider CodeActionOptions       `json:"codeActionProvider"`
+}
+
+// TextDocumentSyncKindNone indicates that documents are not synced on every
+// change, since diagnostics are only published on open and save.
+const TextDocumentSyncKindNone = 0
+
+// TextDocumentSyncOptions holds the document sync options of the server.
+type TextDocumentSyncOptions struct {
+	OpenClose bool        `json:"openClose"`
+	Change    int         `json:"change"`
+	Save      SaveOptions `json:"save"`
+}
+
+// SaveOptions holds the options of the save notifications.
+type SaveOptions struct {
+	IncludeText bool `json:"includeText"`
+}
+
+// CodeActionOptions holds the code action options of the server.
+type CodeActionOptions struct {
+	CodeActionKinds []string `json:"codeActionKinds"`
+}
+
+// DidOpenTextDocumentParams holds the params of the didOpen notification.
+type DidOpenTextDocumentParams struct {
+	TextDocument TextDocumentItem `json:"textDocument"`
+}
+
+// DidSaveTextDocumentParams holds the params of the didSave notification.
+type DidSaveTextDocumentParams struct {
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+	Text         *string                `json:"text,omitempty"`
+}
+
+// DidCloseTextDocumentParams holds the params of the didClose notification.
+type DidCloseTextDocumentParams struct {
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+}
+
+// FileEvent is a change to a watched file.
+type FileEvent struct {
+	URI  DocumentURI `json:"uri"`
+	Type int         `json:"type"`
+}
+
+// DidChangeWatchedFilesParams holds the params of the didChangeWatchedFiles
+// notification.
+type DidChangeWatchedFilesParams struct {
+	Changes []FileEvent `json:"changes"`
+}
+
+// PublishDiagnosticsParams holds the params of the publishDiagnostics
+// notification.
+type PublishDiagnosticsParams struct {
+	URI         DocumentURI  `json:"uri"`
+	Diagnostics []Diagnostic `json:"diagnostics"`
+}
+
+// CodeActionParams holds the params of the codeAction request.
+type CodeActionParams struct {
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+	Range        Range                  `json:"range"`
+}
+
+// HoverParams holds the params of the hover request.
+type HoverParams struct {
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+	Position     Position               `json:"position"`
+}
+
+// ShowMessageParams holds the params of the showMessage and logMessage
+// notifications.
+type ShowMessageParams struct {
+	Type    MessageType `json:"type"`
+	Message string      `json:"message"`
+}
+
+// RegistrationParams holds the params of the registerCapability request.
+type RegistrationParams struct {
+	Registrations []Registration `json:"registrations"`
+}
+
+// Registration is a capability registration.
+type Registration struct {
+	ID              string `json:"id"`
+	Method          string `json:"method"`
+	RegisterOptions any    `json:"registerOptions,omitempty"`
+}
+
+// DidChangeWatchedFilesRegistrationOptions holds the options of the watched
+// files registration.
+type DidChangeWatchedFilesRegistrationOptions struct {
+	Watchers []FileSystemWatcher `json:"watchers"`
+}
+
+// FileSystemWatcher is a glob pattern of files to watch.
+type FileSystemWatcher struct {
+	GlobPattern string `json:"globPattern"`
+}
+
+// fileURI returns the URI of the given file path.
+func fileURI(path string) DocumentURI {
+	return DocumentURI((&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String())
+}
+
+// uriPath returns the file path of the given URI, or an empty string if the URI
+// is not a file URI.
+func uriPath(uri DocumentURI) string {
+	u, err := url.Parse(string(uri))
+	if err != nil || u.Scheme != "file" {
+		return ""
+	}
+	return filepath.Clean(filepath.FromSlash(u.Path))
+}
+
+// toPosition converts the given token position to an LSP position, based on the
+// given file content. Token columns are in bytes, whereas LSP characters are in
+// UTF-16 code units.
+func toPosition(content []byte, pos token.Position) Position {
+	if pos.Line < 1 {
+		return Position{}
+	}
+	prefix := content[:min(pos.Offset, len(content))]
+	lineStart := bytes.LastIndexByte(prefix, '\n') + 1
+	return Position{
+		Line:      pos.Line - 1,
+		Character: util.UTF16Len(prefix[lineStart:]),
+	}
+}
+
+// toOffset converts the given LSP position to a byte offset in the given file
+// content. Positions beyond the end of a line (or the file) are clamped.
+func toOffset(content []byte, pos Position) int {
+	offset := 0
+	for range pos.Line {
+		i := bytes.IndexByte(content[offset:], '\n')
+		if i < 0 {
+			return len(content)
+		}
+		offset += i + 1
+	}
+
+	for chars := 0; offset < len(content) && chars < pos.Character; {
+		r, size := utf8.DecodeRune(content[offset:])
+		if r == '\n' {
+			break
+		}
+		chars += utf16.RuneLen(r)
+		offset += size
+	}
+	return offset
+}
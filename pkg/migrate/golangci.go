@@ -0,0 +1,368 @@
+package migrate
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"go.yaml.in/yaml/v3"
+
+	"github.com/godoc-lint/godoc-lint/pkg/model"
+)
+
+// golangciConfig represents the relevant settings of a Golangci-lint config
+// file, in either v1 or v2 format.
+type golangciConfig struct {
+	Version         string           `yaml:"version"`
+	Linters         golangciLinters  `yaml:"linters"`
+	LintersSettings golangciSettings `yaml:"linters-settings"` // v1
+	Issues          golangciIssues   `yaml:"issues"`
+}
+
+// golangciLinters represents the "linters" section.
+type golangciLinters struct {
+	Enable  []string `yaml:"enable"`
+	Disable []string `yaml:"disable"`
+
+	// v1
+	EnableAll  bool     `yaml:"enable-all"`
+	DisableAll bool     `yaml:"disable-all"`
+	Presets    []string `yaml:"presets"`
+
+	// v2
+	Default    string             `yaml:"default"`
+	Settings   golangciSettings   `yaml:"settings"`
+	Exclusions golangciExclusions `yaml:"exclusions"`
+}
+
+// golangciSettings represents the settings of the linters.
+type golangciSettings struct {
+	Revive      *golangciRevive      `yaml:"revive"`
+	Stylecheck  *golangciStaticcheck `yaml:"stylecheck"` // v1
+	Staticcheck *golangciStaticcheck `yaml:"staticcheck"`
+	Golint      *golangciGolint      `yaml:"golint"` // v1
+}
+
+// golangciRevive represents the settings of revive.
+type golangciRevive struct {
+	Confidence     *float64             `yaml:"confidence"`
+	EnableAllRules bool                 `yaml:"enable-all-rules"`
+	Rules          []golangciReviveRule `yaml:"rules"`
+}
+
+// golangciReviveRule represents the settings of a revive rule.
+type golangciReviveRule struct {
+	Name      string   `yaml:"name"`
+	Disabled  bool     `yaml:"disabled"`
+	Arguments []any    `yaml:"arguments"`
+	Exclude   []string `yaml:"exclude"`
+}
+
+// golangciStaticcheck represents the settings of staticcheck, or stylecheck
+// (in v1).
+type golangciStaticcheck struct {
+	Checks []string `yaml:"checks"`
+}
+
+// golangciGolint represents the settings of golint (in v1).
+type golangciGolint struct {
+	MinConfidence *float64 `yaml:"min-confidence"`
+}
+
+// golangciIssues represents the "issues" section.
+type golangciIssues struct {
+	// v1
+	ExcludeUseDefault *bool                   `yaml:"exclude-use-default"`
+	Include           []string                `yaml:"include"`
+	Exclude           []string                `yaml:"exclude"`
+	ExcludeFiles      []string                `yaml:"exclude-files"`
+	ExcludeDirs       []string                `yaml:"exclude-dirs"`
+	ExcludeRules      []golangciExclusionRule `yaml:"exclude-rules"`
+}
+
+// golangciExclusions represents the "linters.exclusions" section (in v2).
+type golangciExclusions struct {
+	Presets []string                `yaml:"presets"`
+	Paths   []string                `yaml:"paths"`
+	Rules   []golangciExclusionRule `yaml:"rules"`
+}
+
+// golangciExclusionRule represents an exclusion rule.
+type golangciExclusionRule struct {
+	Path       string   `yaml:"path"`
+	PathExcept string   `yaml:"path-except"`
+	Linters    []string `yaml:"linters"`
+	Text       string   `yaml:"text"`
+	Source     string   `yaml:"source"`
+}
+
+// Names of the linters with godoc checks.
+const (
+	reviveLinter      = "revive"
+	stylecheckLinter  = "stylecheck"
+	staticcheckLinter = "staticcheck"
+	golintLinter      = "golint"
+	godotLinter       = "godot"
+)
+
+// golangciDefaultExclusions holds the IDs of the Golangci-lint default
+// exclusions about godocs, which apply (in v1) unless disabled via the
+// "issues.exclude-use-default" or "issues.include" settings. In v2, the same
+// exclusions are provided by the "comments" preset.
+var golangciDefaultExclusions = []string{
+	"EXC0002", // golint
+	"EXC0011", // stylecheck
+	"EXC0012", // revive exported (missing godocs)
+	"EXC0013", // revive package-comments (package godoc form)
+	"EXC0014", // revive exported (godoc form)
+	"EXC0015", // revive package-comments (missing package godocs)
+}
+
+// defaultStaticcheckChecks holds the checks enabled by default by
+// Golangci-lint, for stylecheck (in v1), or staticcheck (in v2).
+var defaultStaticcheckChecks = []string{"all", "-ST1000", "-ST1003", "-ST1016", "-ST1020", "-ST1021", "-ST1022"}
+
+// fromGolangciTOML translates the given Golangci-lint TOML config, which has
+// the same structure as the YAML one.
+func (t *translator) fromGolangciTOML(in []byte) error {
+	var raw map[string]any
+	if _, err := toml.Decode(string(in), &raw); err != nil {
+		return fmt.Errorf("cannot parse Golangci-lint config: %w", err)
+	}
+	asYAML, err := yaml.Marshal(raw)
+	if err != nil {
+		return &model.InternalError{Err: fmt.Errorf("cannot convert Golangci-lint config to YAML: %w", err)}
+	}
+	return t.fromGolangci(asYAML)
+}
+
+// fromGolangci translates the given Golangci-lint config file content.
+func (t *translator) fromGolangci(in []byte) error {
+	var cfg golangciConfig
+	if err := yaml.Unmarshal(in, &cfg); err != nil {
+		return fmt.Errorf("cannot parse Golangci-lint config: %w", err)
+	}
+
+	v2 := cfg.Version == "2"
+	settings, linters := cfg.LintersSettings, []string{reviveLinter, stylecheckLinter, golintLinter, godotLinter}
+	if v2 {
+		settings, linters = cfg.Linters.Settings, []string{reviveLinter, staticcheckLinter, godotLinter}
+	}
+
+	t.suppressed = make(map[string]string)
+	if v2 {
+		if slices.Contains(cfg.Linters.Exclusions.Presets, "comments") {
+			for _, id := range golangciDefaultExclusions {
+				t.suppressed[id] = `the "comments" exclusion preset`
+			}
+		}
+	} else if cfg.Issues.ExcludeUseDefault == nil || *cfg.Issues.ExcludeUseDefault {
+		for _, id := range golangciDefaultExclusions {
+			if !slices.Contains(cfg.Issues.Include, id) {
+				t.suppressed[id] = fmt.Sprintf("the default exclusion %s (See the issues.exclude-use-default and issues.include settings)", id)
+			}
+		}
+	}
+
+	var migrated []string
+	for _, linter := range linters {
+		if !t.isGolangciLinterEnabled(&cfg, v2, linter) {
+			continue
+		}
+		migrated = append(migrated, linter)
+
+		switch linter {
+		case reviveLinter:
+			t.fromRevive(golangciReviveConfig(settings.Revive), linter)
+		case stylecheckLinter:
+			t.fromStaticcheck(settings.Stylecheck, linter)
+		case staticcheckLinter:
+			t.fromStaticcheck(settings.Staticcheck, linter)
+		case golintLinter:
+			t.fromGolint(settings.Golint)
+		case godotLinter:
+			t.note(linter, "no equivalent, since it checks that comments end with a period")
+		}
+	}
+
+	if v2 {
+		for _, pattern := range cfg.Linters.Exclusions.Paths {
+			t.addExclude(fmt.Sprintf("linters.exclusions.paths %q", pattern), pattern)
+		}
+		t.fromGolangciExclusionRules(cfg.Linters.Exclusions.Rules, "linters.exclusions.rules", migrated)
+		return nil
+	}
+
+	for _, pattern := range cfg.Issues.ExcludeFiles {
+		t.addExclude(fmt.Sprintf("issues.exclude-files %q", pattern), pattern)
+	}
+	for _, pattern := range cfg.Issues.ExcludeDirs {
+		// Directory patterns match the paths of the directories, so they are
+		// changed to match the paths of the files in them.
+		t.addExclude(fmt.Sprintf("issues.exclude-dirs %q", pattern), "(?:"+strings.TrimSuffix(pattern, "$")+")/")
+	}
+	if len(cfg.Issues.Exclude) > 0 {
+		t.note("issues.exclude", "not migrated, since findings cannot be excluded by their text")
+	}
+	t.fromGolangciExclusionRules(cfg.Issues.ExcludeRules, "issues.exclude-rules", migrated)
+	return nil
+}
+
+// isGolangciLinterEnabled determines whether the given linter is enabled in the
+// given config.
+func (t *translator) isGolangciLinterEnabled(cfg *golangciConfig, v2 bool, linter string) bool {
+	if slices.Contains(cfg.Linters.Disable, linter) {
+		return false
+	}
+	if slices.Contains(cfg.Linters.Enable, linter) {
+		return true
+	}
+
+	if v2 {
+		switch cfg.Linters.Default {
+		case "all":
+			return true
+		case "", "standard":
+			return linter == staticcheckLinter
+		case "fast":
+			t.note(fmt.Sprintf("linters.default %q, linter %s", cfg.Linters.Default, linter), "not migrated, unless enabled explicitly")
+		}
+		return false
+	}
+
+	if cfg.Linters.EnableAll {
+		return true
+	}
+	if cfg.Linters.DisableAll {
+		return false
+	}
+	// None of the linters are enabled by default (in v1), but they are all in
+	// the "style" preset, and godot is in the "comment" preset, too.
+	return slices.Contains(cfg.Linters.Presets, "style") || linter == godotLinter && slices.Contains(cfg.Linters.Presets, "comment")
+}
+
+// golangciReviveConfig returns the revive config equivalent to the given
+// settings. If no rule is configured, revive's default rules (including the
+// ones about godocs) are enabled.
+func golangciReviveConfig(settings *golangciRevive) *reviveConfig {
+	if settings == nil {
+		settings = &golangciRevive{}
+	}
+	cfg := &reviveConfig{
+		Confidence:         settings.Confidence,
+		EnableAllRules:     settings.EnableAllRules,
+		EnableDefaultRules: len(settings.Rules) == 0,
+		Rules:              make(map[string]reviveRule, len(settings.Rules)),
+	}
+	for _, rule := range settings.Rules {
+		cfg.Rules[rule.Name] = reviveRule{
+			Disabled:  rule.Disabled,
+			Arguments: rule.Arguments,
+			Exclude:   rule.Exclude,
+		}
+	}
+	return cfg
+}
+
+// fromStaticcheck translates the godoc checks of the given staticcheck (or
+// stylecheck) settings. The given name is used to refer to the linter in the
+// notes.
+func (t *translator) fromStaticcheck(settings *golangciStaticcheck, name string) {
+	checks := defaultStaticcheckChecks
+	if settings != nil && len(settings.Checks) > 0 {
+		checks = settings.Checks
+	}
+
+	if isStaticcheckEnabled(checks, "ST1000") {
+		t.enable(name+" check ST1000", "EXC0011", model.PkgDocRule, model.RequirePkgDocRule)
+	}
+
+	kinds := map[string]string{
+		"ST1020": "functions",
+		"ST1021": "types",
+		"ST1022": "variables and constants",
+	}
+	var ids, covered []string
+	for _, id := range []string{"ST1020", "ST1021", "ST1022"} {
+		if isStaticcheckEnabled(checks, id) {
+			ids = append(ids, id)
+			covered = append(covered, kinds[id])
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+	setting := fmt.Sprintf("%s check(s) %s", name, strings.Join(ids, ", "))
+	if t.enable(setting, "EXC0011", model.StartWithNameRule) && len(ids) < len(kinds) {
+		t.note(setting, "migrated as start-with-name, which applies to all declarations, rather than only %s", strings.Join(covered, ", "))
+	}
+}
+
+// isStaticcheckEnabled determines whether the check with the given ID is
+// enabled by the given list of checks, where the last matching entry wins.
+// Entries can be "all" (or "*"), IDs with a "*" suffix (e.g., "ST*"), or exact
+// IDs, which are negated by a "-" prefix.
+func isStaticcheckEnabled(checks []string, id string) bool {
+	enabled := false
+	for _, check := range checks {
+		if check == "inherit" {
+			enabled = isStaticcheckEnabled(defaultStaticcheckChecks, id)
+			continue
+		}
+		pattern, negated := strings.CutPrefix(check, "-")
+		if pattern == "all" || pattern == "*" {
+			enabled = !negated
+		} else if prefix, ok := strings.CutSuffix(pattern, "*"); ok && strings.HasPrefix(id, prefix) {
+			enabled = !negated
+		} else if pattern == id {
+			enabled = !negated
+		}
+	}
+	return enabled
+}
+
+// fromGolint translates the given golint settings.
+func (t *translator) fromGolint(settings *golangciGolint) {
+	confidence := defaultConfidence
+	if settings != nil && settings.MinConfidence != nil {
+		confidence = *settings.MinConfidence
+	}
+
+	t.enable(golintLinter+" (missing godocs)", "EXC0002", model.RequireDocRule)
+	t.enable(golintLinter+" (godoc form)", "EXC0002", model.StartWithNameRule)
+	t.enable(golintLinter+" (package godoc form)", "EXC0002", model.PkgDocRule)
+	if confidence > missingPkgDocConfidence {
+		t.note(golintLinter+" (missing package godocs)", "not migrated, since its findings have a confidence of %v, which is below the minimum (%v)", missingPkgDocConfidence, confidence)
+	} else {
+		t.enable(golintLinter+" (missing package godocs)", "EXC0002", model.RequirePkgDocRule)
+	}
+	t.note(golintLinter+" (stuttering names)", "no equivalent, since it is not about godocs")
+}
+
+// fromGolangciExclusionRules translates the given exclusion rules, which are at
+// the given path of the config, where the rules that do not apply to any of the
+// given migrated linters are ignored.
+func (t *translator) fromGolangciExclusionRules(rules []golangciExclusionRule, path string, migrated []string) {
+	for i, rule := range rules {
+		if len(rule.Linters) > 0 && !slices.ContainsFunc(rule.Linters, func(linter string) bool {
+			return slices.Contains(migrated, linter)
+		}) {
+			continue
+		}
+
+		setting := fmt.Sprintf("%s[%d]", path, i)
+		switch {
+		case rule.Text != "" || rule.Source != "":
+			t.note(setting, "not migrated, since findings cannot be excluded by their text or source")
+		case rule.PathExcept != "":
+			t.note(setting, "not migrated, since files can only be included via the global include setting")
+		case rule.Path == "":
+			t.note(setting, "not migrated, since it excludes all findings of %s", strings.Join(rule.Linters, ", "))
+		default:
+			if t.addExclude(setting, rule.Path) && len(rule.Linters) > 0 {
+				t.note(setting, "migrated as %q, which excludes the files from all rules", rule.Path)
+			}
+		}
+	}
+}
@@ -0,0 +1,251 @@
+// Package migrate provides translation of the configurations of other linters
+// with godoc checks (i.e., revive, golint, stylecheck and godot) into an
+// equivalent plain config.
+//
+// The supported sources are revive config files (e.g., ".revive.toml"), and
+// Golangci-lint config files (e.g., ".golangci.yml" or ".golangci.toml"), in
+// both v1 and v2 formats. Settings that cannot be translated are reported as
+// notes, rather than failing the migration.
+package migrate
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/godoc-lint/godoc-lint/pkg/config"
+	"github.com/godoc-lint/godoc-lint/pkg/model"
+)
+
+// configVersion is the version of the migrated configuration.
+const configVersion = "1.0"
+
+// Source is the enum type for the supported source config formats.
+type Source string
+
+const (
+	// SourceRevive represents revive config files (i.e., TOML).
+	SourceRevive Source = "revive"
+	// SourceGolangci represents Golangci-lint config files in YAML or JSON,
+	// in either v1 or v2 format.
+	SourceGolangci Source = "golangci"
+	// SourceGolangciTOML represents Golangci-lint config files in TOML, in
+	// either v1 or v2 format.
+	SourceGolangciTOML Source = "golangci-toml"
+)
+
+// DefaultFiles returns the names of the config files that are looked up, in
+// order, when no file is given.
+func DefaultFiles() []string {
+	return []string{
+		".golangci.yml",
+		".golangci.yaml",
+		".golangci.toml",
+		".golangci.json",
+		"revive.toml",
+		".revive.toml",
+	}
+}
+
+// DetectSource returns the source format of the given config file, based on
+// its name.
+func DetectSource(path string) (Source, error) {
+	switch ext, base := filepath.Ext(path), filepath.Base(path); {
+	case ext == ".toml" && strings.Contains(base, "golangci"):
+		return SourceGolangciTOML, nil
+	case ext == ".toml" && strings.Contains(base, "revive"):
+		return SourceRevive, nil
+	case ext == ".yml" || ext == ".yaml" || ext == ".json":
+		return SourceGolangci, nil
+	default:
+		return "", fmt.Errorf("cannot detect config format of %q; expected a revive TOML file, or a Golangci-lint YAML, JSON or TOML file", path)
+	}
+}
+
+// Note represents a setting that cannot be translated, or is translated with
+// a different behavior.
+type Note struct {
+	// Setting identifies the setting (e.g., `revive rule "exported", argument
+	// "checkPrivateReceivers"`).
+	Setting string
+
+	// Message explains why the setting cannot be translated.
+	Message string
+}
+
+// String returns a human-readable representation of the note.
+func (n Note) String() string {
+	return n.Setting + ": " + n.Message
+}
+
+// Result represents the result of a migration.
+type Result struct {
+	// Config holds the migrated configuration.
+	Config *config.PlainConfig
+
+	// Notes holds the notes about the settings that cannot be translated, in
+	// the order they are found.
+	Notes []Note
+}
+
+// FromFile migrates the given config file, whose format is detected by its
+// name (See [DetectSource]). Errors about the content of the file (including
+// [ErrNoRules]) are returned as [model.ConfigError].
+func FromFile(path string) (*Result, error) {
+	source, err := DetectSource(path)
+	if err != nil {
+		return nil, err
+	}
+	in, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read file: %w", err)
+	}
+	r, err := From(source, in)
+	if err != nil {
+		var ierr *model.InternalError
+		if errors.As(err, &ierr) {
+			return nil, err
+		}
+		return nil, &model.ConfigError{File: path, Err: err}
+	}
+	return r, nil
+}
+
+// From migrates the given config content of the given source format.
+func From(source Source, in []byte) (*Result, error) {
+	t := &translator{}
+
+	var err error
+	switch source {
+	case SourceRevive:
+		err = t.fromReviveTOML(in)
+	case SourceGolangci:
+		err = t.fromGolangci(in)
+	case SourceGolangciTOML:
+		err = t.fromGolangciTOML(in)
+	default:
+		err = fmt.Errorf("unknown source format %q", source)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return t.result()
+}
+
+// ErrNoRules is returned when the source config does not enable any godoc
+// check that can be translated.
+var ErrNoRules = errors.New("no godoc checks found to migrate")
+
+// translator accumulates the translated settings.
+type translator struct {
+	rules   model.RuleSet
+	exclude []string
+	notes   []Note
+
+	// suppressed maps the IDs of Golangci-lint default exclusions (e.g.,
+	// "EXC0012") that are in effect, to a description of how they are
+	// configured.
+	suppressed map[string]string
+}
+
+// note adds a note about the given setting.
+func (t *translator) note(setting, format string, args ...any) {
+	t.notes = append(t.notes, Note{Setting: setting, Message: fmt.Sprintf(format, args...)})
+}
+
+// enable enables the given rules, as the translation of the given check, unless
+// the findings of the check are suppressed by the given Golangci-lint default
+// exclusion. It returns false if the findings are suppressed.
+func (t *translator) enable(check, exclusion string, rules ...model.Rule) bool {
+	if how, ok := t.suppressed[exclusion]; ok {
+		t.note(check, "not migrated, since its findings are excluded by %s", how)
+		return false
+	}
+	t.rules = t.rules.Add(rules...)
+	return true
+}
+
+// addExclude adds the given path pattern to exclude, as the translation of the
+// given setting, if not already added. It returns false if the pattern is not a
+// valid regexp.
+func (t *translator) addExclude(setting, pattern string) bool {
+	if _, err := regexp.Compile(pattern); err != nil {
+		t.note(setting, "not migrated, since %q is not a valid regexp", pattern)
+		return false
+	}
+	if !slices.Contains(t.exclude, pattern) {
+		t.exclude = append(t.exclude, pattern)
+	}
+	return true
+}
+
+// result returns the migration result, where the configuration is validated.
+func (t *translator) result() (*Result, error) {
+	rules := t.rules.List()
+	if len(rules) == 0 {
+		return nil, ErrNoRules
+	}
+
+	enable := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		enable = append(enable, string(rule))
+	}
+
+	version := configVersion
+	none := string(model.DefaultSetNone)
+	pcfg := &config.PlainConfig{
+		Version: &version,
+		Default: &none,
+		Enable:  enable,
+		Exclude: t.exclude,
+	}
+	if err := pcfg.Validate(); err != nil {
+		return nil, &model.InternalError{Err: fmt.Errorf("migrated config is invalid: %w", err)}
+	}
+	return &Result{Config: pcfg, Notes: t.notes}, nil
+}
+
+// yamlConfig is the same as [config.PlainConfig], where empty fields are
+// omitted when written.
+type yamlConfig struct {
+	Version *string                  `yaml:"version,omitempty"`
+	Exclude []string                 `yaml:"exclude,omitempty"`
+	Include []string                 `yaml:"include,omitempty"`
+	Default *string                  `yaml:"default,omitempty"`
+	Enable  []string                 `yaml:"enable,omitempty"`
+	Disable []string                 `yaml:"disable,omitempty"`
+	Options *config.PlainRuleOptions `yaml:"options,omitempty"`
+}
+
+// WriteYAML writes the migrated configuration as a YAML config file, where the
+// notes are written as comments, at the top.
+func (r *Result) WriteYAML(w io.Writer, from string) error {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "# Migrated by \"godoclint migrate-config\" from %q.\n", filepath.ToSlash(from))
+	if len(r.Notes) > 0 {
+		fmt.Fprintln(&buf, "#")
+		fmt.Fprintln(&buf, "# The following settings could not be translated exactly:")
+		for _, n := range r.Notes {
+			fmt.Fprintf(&buf, "#   - %s\n", n)
+		}
+	}
+
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(yamlConfig(*r.Config)); err != nil {
+		return err
+	}
+	if err := enc.Close(); err != nil {
+		return err
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
@@ -0,0 +1,323 @@
+package migrate_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/godoc-lint/godoc-lint/pkg/config"
+	"github.com/godoc-lint/godoc-lint/pkg/migrate"
+	"github.com/godoc-lint/godoc-lint/pkg/model"
+)
+
+func TestFromRevive(t *testing.T) {
+	tests := []struct {
+		name        string
+		in          string
+		wantEnable  []string
+		wantExclude []string
+		wantNotes   []string
+	}{{
+		name: "exported and package-comments",
+		in: `
+confidence = 0.8
+
+[rule.exported]
+arguments = ["checkPrivateReceivers", "disable-stuttering-check", "disableChecksOnConstants"]
+exclude = ["TEST", "internal/**/gen_*.go", "~^legacy/"]
+
+[rule.package-comments]
+
+[rule.var-naming]
+`,
+		wantEnable:  []string{"pkg-doc", "require-doc", "start-with-name"},
+		wantExclude: []string{`^internal/(?:.*/)?gen_[^/]*\.go$`, `^legacy/`},
+		wantNotes: []string{
+			`revive rule "exported", argument "checkPrivateReceivers"`,
+			`revive rule "exported", argument "disableChecksOnConstants"`,
+			`revive rule "exported", exclude "internal/**/gen_*.go"`,
+			`revive rule "exported", exclude "~^legacy/"`,
+			`revive rule "package-comments" (missing package godocs)`,
+		},
+	}, {
+		name: "all rules with low confidence",
+		in: `
+enableAllRules = true
+confidence = 0.1
+
+[rule.exported]
+disabled = true
+`,
+		wantEnable: []string{"pkg-doc", "require-pkg-doc"},
+	}, {
+		name: "stuttering check",
+		in: `
+[rule.exported]
+`,
+		wantEnable: []string{"require-doc", "start-with-name"},
+		wantNotes:  []string{`revive rule "exported" (stuttering names)`},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			r, err := migrate.From(migrate.SourceRevive, []byte(tt.in))
+			require.NoError(err)
+			require.Equal(tt.wantEnable, r.Config.Enable)
+			require.Equal(tt.wantExclude, r.Config.Exclude)
+			require.Equal(tt.wantNotes, settingsOf(r.Notes))
+		})
+	}
+}
+
+func TestFromGolangci(t *testing.T) {
+	tests := []struct {
+		name        string
+		in          string
+		wantEnable  []string
+		wantExclude []string
+		wantNotes   []string
+		wantErr     error
+	}{{
+		name: "v1 with default exclusions",
+		in: `
+linters:
+  enable: [revive, stylecheck, godot]
+linters-settings:
+  stylecheck:
+    checks: ["all", "-ST1000", "-ST1021"]
+issues:
+  include: [EXC0012]
+  exclude-dirs: ["^gen$"]
+  exclude-files: ["_mock\\.go$"]
+  exclude-rules:
+    - path: ^internal/
+      linters: [revive]
+    - path: ^cmd/
+      linters: [errcheck]
+    - text: "should have comment"
+      linters: [revive]
+`,
+		wantEnable:  []string{"require-doc"},
+		wantExclude: []string{`_mock\.go$`, `(?:^gen)/`, `^internal/`},
+		wantNotes: []string{
+			`revive rule "exported" (godoc form)`,
+			`revive rule "exported" (stuttering names)`,
+			`revive rule "package-comments" (package godoc form)`,
+			`revive rule "package-comments" (missing package godocs)`,
+			`stylecheck check(s) ST1020, ST1022`,
+			`godot`,
+			`issues.exclude-rules[0]`,
+			`issues.exclude-rules[2]`,
+		},
+	}, {
+		name: "v1 without default exclusions",
+		in: `
+linters:
+  disable-all: true
+  enable: [golint, stylecheck]
+linters-settings:
+  golint:
+    min-confidence: 0.2
+  stylecheck:
+    checks: ["ST1000"]
+issues:
+  exclude-use-default: false
+`,
+		wantEnable: []string{"pkg-doc", "require-doc", "require-pkg-doc", "start-with-name"},
+		wantNotes:  []string{`golint (stuttering names)`},
+	}, {
+		name: "v1 style preset",
+		in: `
+linters:
+  presets: [style]
+  disable: [revive, golint, godot]
+linters-settings:
+  stylecheck:
+    checks: ["ST*"]
+issues:
+  include: [EXC0011]
+`,
+		wantEnable: []string{"pkg-doc", "require-pkg-doc", "start-with-name"},
+	}, {
+		name: "v2",
+		in: `
+version: "2"
+linters:
+  enable: [revive]
+  settings:
+    staticcheck:
+      checks: ["inherit", "ST1020", "ST1021", "ST1022"]
+    revive:
+      rules:
+        - name: exported
+          arguments: [disableStutteringCheck]
+          exclude: ["TEST"]
+        - name: package-comments
+          disabled: true
+  exclusions:
+    paths: ["^third_party/"]
+    rules:
+      - path: _test\.go
+        linters: [staticcheck]
+      - path-except: _test\.go
+        linters: [revive]
+`,
+		wantEnable:  []string{"require-doc", "start-with-name"},
+		wantExclude: []string{`^third_party/`, `_test\.go`},
+		wantNotes: []string{
+			`linters.exclusions.rules[0]`,
+			`linters.exclusions.rules[1]`,
+		},
+	}, {
+		name: "v2 comments preset",
+		in: `
+version: "2"
+linters:
+  default: all
+  exclusions:
+    presets: [comments]
+`,
+		wantErr: migrate.ErrNoRules,
+	}, {
+		name: "v2 standard",
+		in: `
+version: "2"
+linters:
+  settings:
+    staticcheck:
+      checks: ["all", "-ST1003"]
+    revive:
+      confidence: 0
+`,
+		wantEnable: []string{"pkg-doc", "require-pkg-doc", "start-with-name"},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			r, err := migrate.From(migrate.SourceGolangci, []byte(tt.in))
+			if tt.wantErr != nil {
+				require.ErrorIs(err, tt.wantErr)
+				return
+			}
+			require.NoError(err)
+			require.Equal(tt.wantEnable, r.Config.Enable)
+			require.Equal(tt.wantExclude, r.Config.Exclude)
+			require.Equal(tt.wantNotes, settingsOf(r.Notes))
+		})
+	}
+}
+
+func TestFromGolangciTOML(t *testing.T) {
+	require := require.New(t)
+
+	r, err := migrate.From(migrate.SourceGolangciTOML, []byte(`
+version = "2"
+
+[linters]
+enable = ["revive"]
+
+[[linters.settings.revive.rules]]
+name = "exported"
+arguments = ["disableStutteringCheck"]
+
+[linters.exclusions]
+paths = ["^third_party/"]
+`))
+	require.NoError(err)
+	require.Equal([]string{"require-doc", "start-with-name"}, r.Config.Enable)
+	require.Equal([]string{`^third_party/`}, r.Config.Exclude)
+	require.Empty(r.Notes)
+
+	r, err = migrate.From(migrate.SourceGolangciTOML, []byte(`
+[linters]
+disable-all = true
+enable = ["golint"]
+
+[issues]
+exclude-use-default = false
+`))
+	require.NoError(err)
+	require.Equal([]string{"pkg-doc", "require-doc", "start-with-name"}, r.Config.Enable)
+
+	_, err = migrate.From(migrate.SourceGolangciTOML, []byte("linters = ["))
+	require.ErrorContains(err, "cannot parse Golangci-lint config")
+}
+
+func TestFromFile(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".revive.toml")
+	require.NoError(os.WriteFile(path, []byte("[rule.package-comments]\n"), 0o644))
+
+	r, err := migrate.FromFile(path)
+	require.NoError(err)
+	require.Equal([]string{"pkg-doc"}, r.Config.Enable)
+
+	require.NoError(os.WriteFile(path, []byte("[rule.var-naming]\n"), 0o644))
+	_, err = migrate.FromFile(path)
+	require.ErrorIs(err, migrate.ErrNoRules)
+	var cerr *model.ConfigError
+	require.ErrorAs(err, &cerr)
+	require.Equal(path, cerr.File)
+
+	require.NoError(os.WriteFile(path, []byte("rule = ["), 0o644))
+	_, err = migrate.FromFile(path)
+	require.ErrorAs(err, &cerr)
+
+	golangciPath := filepath.Join(dir, ".golangci.toml")
+	require.NoError(os.WriteFile(golangciPath, []byte("[linters]\nenable = [\"stylecheck\"]\n[linters-settings.stylecheck]\nchecks = [\"ST*\"]\n[issues]\nexclude-use-default = false\n"), 0o644))
+	r, err = migrate.FromFile(golangciPath)
+	require.NoError(err)
+	require.Equal([]string{"pkg-doc", "require-pkg-doc", "start-with-name"}, r.Config.Enable)
+
+	_, err = migrate.FromFile(filepath.Join(dir, "config.toml"))
+	require.ErrorContains(err, "cannot detect config format")
+}
+
+func TestWriteYAML(t *testing.T) {
+	require := require.New(t)
+
+	r, err := migrate.From(migrate.SourceRevive, []byte(`
+[rule.exported]
+arguments = ["checkPublicInterface", "disableStutteringCheck"]
+exclude = ["gen/*.go"]
+`))
+	require.NoError(err)
+
+	buf := bytes.NewBuffer(nil)
+	require.NoError(r.WriteYAML(buf, ".revive.toml"))
+	require.Equal(`# Migrated by "godoclint migrate-config" from ".revive.toml".
+#
+# The following settings could not be translated exactly:
+#   - revive rule "exported", argument "checkPublicInterface": no equivalent
+#   - revive rule "exported", exclude "gen/*.go": migrated as "^gen/[^/]*\\.go$", which excludes the files from all rules
+version: "1.0"
+exclude:
+  - ^gen/[^/]*\.go$
+default: none
+enable:
+  - require-doc
+  - start-with-name
+`, buf.String())
+
+	pcfg, err := config.FromYAML(buf.Bytes())
+	require.NoError(err)
+	require.NoError(pcfg.Validate())
+	require.Equal(r.Config, pcfg)
+}
+
+func settingsOf(notes []migrate.Note) []string {
+	var settings []string
+	for _, n := range notes {
+		settings = append(settings, n.Setting)
+	}
+	return settings
+}
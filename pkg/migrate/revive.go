@@ -0,0 +1,176 @@
+package migrate
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/godoc-lint/godoc-lint/pkg/model"
+)
+
+const (
+	// reviveExportedRule is the name of the revive rule that checks the godocs
+	// of exported symbols.
+	reviveExportedRule = "exported"
+
+	// revivePackageCommentsRule is the name of the revive rule that checks
+	// package godocs.
+	revivePackageCommentsRule = "package-comments"
+)
+
+const (
+	// defaultConfidence is the default minimum confidence of the findings
+	// reported by revive and golint.
+	defaultConfidence = 0.8
+
+	// missingPkgDocConfidence is the confidence of the findings about missing
+	// package godocs, reported by revive and golint. With the default minimum
+	// confidence, these findings are not reported.
+	missingPkgDocConfidence = 0.2
+)
+
+// reviveConfig represents the relevant settings of a revive config file.
+type reviveConfig struct {
+	Confidence         *float64              `toml:"confidence"`
+	EnableAllRules     bool                  `toml:"enableAllRules"`
+	EnableDefaultRules bool                  `toml:"enableDefaultRules"`
+	Rules              map[string]reviveRule `toml:"rule"`
+}
+
+// reviveRule represents the settings of a revive rule.
+type reviveRule struct {
+	Disabled  bool     `toml:"disabled"`
+	Arguments []any    `toml:"arguments"`
+	Exclude   []string `toml:"exclude"`
+}
+
+// fromReviveTOML translates the given revive config file content.
+func (t *translator) fromReviveTOML(in []byte) error {
+	var cfg reviveConfig
+	if _, err := toml.Decode(string(in), &cfg); err != nil {
+		return fmt.Errorf("cannot parse revive config: %w", err)
+	}
+	t.fromRevive(&cfg, "revive")
+	return nil
+}
+
+// fromRevive translates the given revive settings. The given name is used to
+// refer to the linter in the notes.
+func (t *translator) fromRevive(cfg *reviveConfig, name string) {
+	confidence := defaultConfidence
+	if cfg.Confidence != nil {
+		confidence = *cfg.Confidence
+	}
+
+	for _, ruleName := range []string{reviveExportedRule, revivePackageCommentsRule} {
+		rule, ok := cfg.Rules[ruleName]
+		if rule.Disabled || !ok && !cfg.EnableAllRules && !cfg.EnableDefaultRules {
+			continue
+		}
+
+		setting := fmt.Sprintf("%s rule %q", name, ruleName)
+		switch ruleName {
+		case reviveExportedRule:
+			t.fromReviveExported(setting, rule.Arguments)
+		case revivePackageCommentsRule:
+			t.fromRevivePackageComments(setting, confidence)
+		}
+
+		for _, pattern := range rule.Exclude {
+			re, ok := reviveExcludePattern(pattern)
+			if !ok {
+				continue
+			}
+			excludeSetting := fmt.Sprintf("%s, exclude %q", setting, pattern)
+			if t.addExclude(excludeSetting, re) {
+				t.note(excludeSetting, "migrated as %q, which excludes the files from all rules", re)
+			}
+		}
+	}
+}
+
+// fromReviveExported translates the "exported" rule of revive, with the given
+// arguments.
+func (t *translator) fromReviveExported(setting string, args []any) {
+	t.enable(setting+" (missing godocs)", "EXC0012", model.RequireDocRule)
+	t.enable(setting+" (godoc form)", "EXC0014", model.StartWithNameRule)
+
+	stuttering := true
+	for _, arg := range args {
+		s, ok := arg.(string)
+		if !ok {
+			t.note(fmt.Sprintf("%s, argument %v", setting, arg), "unsupported argument type")
+			continue
+		}
+
+		argSetting := fmt.Sprintf("%s, argument %q", setting, s)
+		// Newer versions of revive accept the arguments in any case, and with
+		// dashes or underscores (e.g., "check-private-receivers").
+		normalized := strings.ToLower(strings.NewReplacer("-", "", "_", "").Replace(s))
+		switch {
+		case normalized == "disablestutteringcheck":
+			stuttering = false
+		case normalized == "preservescope", normalized == "sayrepetitiveinsteadofstutters":
+			// These only change the wording of the findings.
+		case normalized == "checkprivatereceivers":
+			t.note(argSetting, "no equivalent; the methods of unexported types are checked only if unexported symbols are (See the require-doc/ignore-unexported and start-with-name/include-unexported options)")
+		case normalized == "checkpublicinterface":
+			t.note(argSetting, "no equivalent")
+		case strings.HasPrefix(normalized, "disablecheckson"):
+			t.note(argSetting, "no equivalent; the rules apply to all kinds of declarations")
+		default:
+			t.note(argSetting, "unknown argument")
+		}
+	}
+	if stuttering {
+		t.note(setting+" (stuttering names)", "no equivalent, since it is not about godocs")
+	}
+}
+
+// fromRevivePackageComments translates the "package-comments" rule of revive,
+// with the given minimum confidence.
+func (t *translator) fromRevivePackageComments(setting string, confidence float64) {
+	t.enable(setting+" (package godoc form)", "EXC0013", model.PkgDocRule)
+	if confidence > missingPkgDocConfidence {
+		t.note(setting+" (missing package godocs)", "not migrated, since its findings have a confidence of %v, which is below the minimum (%v)", missingPkgDocConfidence, confidence)
+		return
+	}
+	t.enable(setting+" (missing package godocs)", "EXC0015", model.RequirePkgDocRule)
+}
+
+// reviveExcludePattern returns the path pattern equivalent to the given revive
+// file filter. Test files (i.e., the "TEST" filter) are excluded by default, so
+// there is no equivalent pattern for them.
+//
+// Filters starting with "~" are regexps, and other filters are globs, where "*"
+// matches any part of a path segment, and "**" matches any number of segments.
+func reviveExcludePattern(filter string) (string, bool) {
+	if filter == "" || filter == "TEST" {
+		return "", false
+	}
+	if re, ok := strings.CutPrefix(filter, "~"); ok {
+		return re, true
+	}
+
+	filter = strings.TrimPrefix(filter, "./")
+	var sb strings.Builder
+	sb.WriteString("^")
+	for i := 0; i < len(filter); i++ {
+		switch {
+		case strings.HasPrefix(filter[i:], "**/"):
+			sb.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(filter[i:], "**"):
+			sb.WriteString(".*")
+			i++
+		case filter[i] == '*':
+			sb.WriteString("[^/]*")
+		default:
+			sb.WriteString(regexp.QuoteMeta(filter[i : i+1]))
+		}
+	}
+	sb.WriteString("$")
+	return sb.String(), true
+}
@@ -12,6 +12,18 @@ type AnalysisContext struct {
 
 	// Pass is the analysis Pass instance.
 	Pass *analysis.Pass
+
+	// Options holds the options of the running checker, if it is configurable
+	// (See [ConfigurableChecker]). Use [GetOptions] to access them as the
+	// concrete type.
+	Options CheckerOptions
+}
+
+// GetOptions returns the options of the running checker as the given type,
+// which should be the same type that the checker's NewOptions method returns.
+// It panics if the options are of a different type.
+func GetOptions[T CheckerOptions](actx *AnalysisContext) T {
+	return actx.Options.(T)
 }
 
 // Checker defines a rule checker.
@@ -22,3 +34,37 @@ type Checker interface {
 	// Apply checks for the rule(s).
 	Apply(actx *AnalysisContext) error
 }
+
+// RuleMetadataProvider defines a checker that provides the metadata of the
+// rules it covers.
+//
+// Checkers are not required to implement it. For rules without metadata, the
+// registry falls back to the rule name, in the extra category (i.e., only
+// included in the "all" default set).
+type RuleMetadataProvider interface {
+	// ListRuleMetadata returns the metadata of the rules applied by the
+	// checker.
+	ListRuleMetadata() []RuleMetadata
+}
+
+// CheckerOptions defines the options of a configurable checker.
+//
+// Implementations should be pointers to structs, whose fields are tagged (via
+// "yaml" tags) with the option names, as they appear in the configuration file
+// (e.g., "max-len/length"). Option names should be prefixed with the name of
+// a rule that the checker covers.
+type CheckerOptions interface {
+	// Validate validates the option values. It is called once the options are
+	// decoded from the configuration, so it can also prepare derived values
+	// (e.g., compiled regexps).
+	Validate() error
+}
+
+// ConfigurableChecker defines a rule checker with options.
+type ConfigurableChecker interface {
+	Checker
+
+	// NewOptions returns a new instance of the checker options, holding the
+	// default values.
+	NewOptions() CheckerOptions
+}
@@ -12,6 +12,8 @@ type ConfigBuilder interface {
 	SetOverride(override *ConfigOverride)
 
 	// GetConfig builds and returns the configuration object for the given path.
+	// Errors in resolving or validating the configuration are returned as
+	// [ConfigError].
 	GetConfig(cwd string) (Config, error)
 }
 
@@ -30,7 +32,8 @@ const (
 	DefaultDefaultSet = DefaultSetBasic
 )
 
-// DefaultSetToRules maps default sets to the corresponding rule sets.
+// DefaultSetToRules maps default sets to the corresponding sets of built-in
+// rules. To include custom rules, use [GetDefaultSetRules].
 var DefaultSetToRules = map[DefaultSet]RuleSet{
 	DefaultSetAll:  AllRules,
 	DefaultSetNone: {},
@@ -56,7 +59,8 @@ var DefaultSetValues = func() []DefaultSet {
 // Non-nil values (including empty slices) indicate that the corresponding field
 // is overridden.
 type ConfigOverride struct {
-	// ConfigFilePath is the path to config file.
+	// ConfigFilePath is the path to config file. Relative paths are resolved
+	// against the base directory.
 	ConfigFilePath *string
 
 	// Include is the overridden list of regexp patterns matching the files that
@@ -101,13 +105,40 @@ type Config interface {
 	// patterns, or does not match the excluded path patterns.
 	IsPathApplicable(path string) bool
 
+	// IsFileApplicable is the same as IsPathApplicable, for the given file of
+	// the package with the given import path. In hermetic mode, the path
+	// patterns are matched against the import path joined with the file name
+	// (e.g., "example.com/foo/bar.go"), rather than the file path.
+	IsFileApplicable(pkgPath, path string) bool
+
+	// GetCheckerOptions returns the options of the given checker, decoded
+	// from the configuration. It returns nil if the checker is not
+	// configurable (See [ConfigurableChecker]) or it is not known to the
+	// configuration builder.
+	GetCheckerOptions(checker Checker) CheckerOptions
+
+	// GetCoverageMin returns the minimum required documentation coverage
+	// percentage (i.e., the "coverage/min" option).
+	GetCoverageMin() float64
+
+	// Fingerprint returns a digest of the resolved configuration, including
+	// the content of the configuration file (if any). Configurations with the
+	// same fingerprint produce the same analysis results.
+	Fingerprint() string
+
 	// Returns the rule-specific options.
 	//
 	// It never returns a nil pointer.
+	//
+	// Deprecated: Use GetCheckerOptions instead, which also covers the options
+	// of custom checkers.
 	GetRuleOptions() *RuleOptions
 }
 
 // RuleOptions represents individual linter rule configurations.
+//
+// Deprecated: Use the options of the checkers instead (See
+// [Config.GetCheckerOptions]).
 type RuleOptions struct {
 	MaxLenLength                     uint
 	MaxLenIncludeTests               bool
@@ -0,0 +1,56 @@
+package model
+
+import "fmt"
+
+// ConfigError represents an error in resolving or validating the
+// configuration (e.g., a malformed config file, or an unknown rule name).
+//
+// Hosts embedding the linter can detect it via [errors.As] to tell the
+// configuration issues apart from other errors.
+type ConfigError struct {
+	// File is the path to the config file. It is empty if the configuration
+	// does not come from a file (e.g., it is given as a plain config value).
+	File string
+
+	// Line is the 1-based line number in the config file, where the error
+	// occurred. It is zero if not known.
+	Line int
+
+	// Err is the underlying error.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *ConfigError) Error() string {
+	switch {
+	case e.File != "" && e.Line > 0:
+		return fmt.Sprintf("invalid config at %q (line %d): %v", e.File, e.Line, e.Err)
+	case e.File != "":
+		return fmt.Sprintf("invalid config at %q: %v", e.File, e.Err)
+	default:
+		return fmt.Sprintf("invalid config: %v", e.Err)
+	}
+}
+
+// Unwrap returns the underlying error.
+func (e *ConfigError) Unwrap() error {
+	return e.Err
+}
+
+// InternalError represents an unexpected error in the linter, which is not
+// caused by the user input (e.g., a misconfigured composition of the linter
+// components).
+type InternalError struct {
+	// Err is the underlying error.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *InternalError) Error() string {
+	return fmt.Sprintf("internal error: %v", e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *InternalError) Unwrap() error {
+	return e.Err
+}
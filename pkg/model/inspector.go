@@ -3,6 +3,7 @@ package model
 import (
 	"go/ast"
 	"go/doc/comment"
+	"sync"
 
 	"golang.org/x/tools/go/analysis"
 )
@@ -17,6 +18,15 @@ type Inspector interface {
 type InspectorResult struct {
 	// Files provides extracted information per AST file.
 	Files map[*ast.File]*FileInspection
+
+	// ConfigErr is the error in resolving the configuration of the package,
+	// if any, in which case Files is nil.
+	//
+	// The inspector does not fail on configuration errors, since go/analysis
+	// drivers only report the failure of the dependent analyzers (i.e., as
+	// "failed prerequisites"), rather than the error itself. Instead, the
+	// dependent analyzers should fail with this error.
+	ConfigErr error
 }
 
 // FileInspection represents the inspection result for a single file.
@@ -201,17 +211,32 @@ type SymbolDecl struct {
 }
 
 // CommentGroup represents an [ast.CommentGroup] and its parsed godoc instance.
+//
+// Instances should not be copied, and should be used via pointers.
 type CommentGroup struct {
 	// CG represents the AST comment group.
 	CG ast.CommentGroup
 
-	// Parsed represents the comment group parsed into a godoc.
-	Parsed comment.Doc
-
 	// Test is the comment group text.
 	Text string
 
 	// DisabledRules contains information about rules disabled in the comment
 	// group.
 	DisabledRules InspectorResultDisableRules
+
+	parseOnce sync.Once
+	parsed    *comment.Doc
+}
+
+// Parsed returns the comment group text parsed into a godoc. Since not all
+// rules need parsed godocs, the text is parsed on the first call.
+//
+// In the earlier versions, the parsed godoc was held by a field of the same
+// name, which this method replaces (i.e., cg.Parsed becomes cg.Parsed()).
+func (cg *CommentGroup) Parsed() *comment.Doc {
+	cg.parseOnce.Do(func() {
+		var p comment.Parser
+		cg.parsed = p.Parse(cg.Text)
+	})
+	return cg.parsed
 }
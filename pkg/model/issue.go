@@ -0,0 +1,73 @@
+package model
+
+import "go/token"
+
+// Issue represents a finding reported by the linter, detached from the
+// underlying analysis pass.
+type Issue struct {
+	// Rule is the rule that produced the issue.
+	Rule Rule
+
+	// PkgPath is the import path of the package the issue belongs to.
+	PkgPath string
+
+	// Symbol is the name of the top-level symbol the issue is reported on
+	// (e.g., "Foo", or "Foo.Bar" for methods). It is empty if the issue is
+	// not related to a symbol declaration (e.g., a package godoc issue).
+	Symbol string
+
+	// Pos is the starting position of the issue.
+	Pos token.Position
+
+	// End is the ending position of the issue. It is invalid (i.e., zero) if
+	// the issue is reported at a single position.
+	End token.Position
+
+	// Message is the issue message.
+	Message string
+
+	// URL is the URL to the documentation of the rule.
+	URL string
+
+	// Fixes holds the suggested fixes, if any.
+	Fixes []IssueFix
+
+	// Related holds other locations involved in the issue, if any.
+	Related []IssueRelated
+}
+
+// IssueRelated represents a location related to an issue (e.g., another
+// occurrence of the same problem).
+type IssueRelated struct {
+	// Pos is the starting position of the location.
+	Pos token.Position
+
+	// End is the ending position of the location. It is invalid (i.e., zero)
+	// if the location is a single position.
+	End token.Position
+
+	// Message describes the location.
+	Message string
+}
+
+// IssueFix represents a suggested fix for an issue.
+type IssueFix struct {
+	// Message describes the fix.
+	Message string
+
+	// Edits holds the text edits to apply.
+	Edits []IssueTextEdit
+}
+
+// IssueTextEdit represents a text edit, replacing the text between the given
+// positions with the new text.
+type IssueTextEdit struct {
+	// Pos is the starting position of the edit.
+	Pos token.Position
+
+	// End is the ending position of the edit.
+	End token.Position
+
+	// NewText is the replacement text.
+	NewText string
+}
@@ -0,0 +1,102 @@
+package model
+
+import "slices"
+
+// RuleCategory is the enum type for the rule categories.
+type RuleCategory string
+
+const (
+	// RuleCategoryBasic represents rules recommended by Go Doc Comments, which
+	// need low effort to follow.
+	RuleCategoryBasic RuleCategory = "basic"
+	// RuleCategoryStrict represents rules recommended by Go Doc Comments, which
+	// need high effort to follow.
+	RuleCategoryStrict RuleCategory = "strict"
+	// RuleCategoryExtra represents extra rules, compatible with Go Doc
+	// Comments.
+	RuleCategoryExtra RuleCategory = "extra"
+)
+
+// RuleMetadata holds the metadata of a rule.
+type RuleMetadata struct {
+	// Rule is the rule name.
+	Rule Rule
+
+	// Category is the rule category.
+	Category RuleCategory
+
+	// Summary is a one-line description of the rule.
+	Summary string
+
+	// Description is the full description of the rule, in plain text. It may
+	// span multiple paragraphs, separated by blank lines.
+	Description string
+
+	// BadExample is a Go code snippet that violates the rule.
+	BadExample string
+
+	// GoodExample is a Go code snippet that follows the rule.
+	GoodExample string
+
+	// Options holds the rule options, as they appear in the configuration
+	// file.
+	Options []RuleOptionMetadata
+
+	// PackageWide indicates that the rule needs all files of a package to be
+	// checked properly (e.g., to tell whether any file has a package godoc).
+	// Such rules are skipped when linting individual files, rather than
+	// packages.
+	PackageWide bool
+}
+
+// Presets returns the default sets (i.e., presets) that include the rule,
+// sorted. All rules are included in the "all" set, and rules of the basic
+// category are also included in the "basic" set.
+func (m RuleMetadata) Presets() []DefaultSet {
+	if m.Category == RuleCategoryBasic {
+		return []DefaultSet{DefaultSetAll, DefaultSetBasic}
+	}
+	return []DefaultSet{DefaultSetAll}
+}
+
+// GetDefaultSetRules returns the set of rules, among the given ones, that are
+// included in the given default set.
+func GetDefaultSetRules(set DefaultSet, metadata []RuleMetadata) RuleSet {
+	rs := RuleSet{}
+	for _, m := range metadata {
+		if slices.Contains(m.Presets(), set) {
+			rs = rs.Add(m.Rule)
+		}
+	}
+	return rs
+}
+
+// RuleOptionMetadata holds the metadata of a rule option.
+type RuleOptionMetadata struct {
+	// Name is the option name, as it appears in the configuration file (e.g.,
+	// "max-len/length").
+	Name string
+
+	// Type is the option value type, as a Go type (e.g., "uint" or
+	// "[]string").
+	Type string
+
+	// Default is the default value of the option, as YAML (e.g., "77" or
+	// "[]").
+	Default string
+
+	// Description is a one-line description of the option.
+	Description string
+}
+
+// SortRuleMetadata sorts the given rule metadata by rule name.
+func SortRuleMetadata(metadata []RuleMetadata) {
+	slices.SortFunc(metadata, func(a, b RuleMetadata) int {
+		if a.Rule < b.Rule {
+			return -1
+		} else if a.Rule > b.Rule {
+			return 1
+		}
+		return 0
+	})
+}
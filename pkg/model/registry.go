@@ -11,4 +11,12 @@ type Registry interface {
 	// GetCoveredRules returns the set of rules covered by the registered
 	// checkers.
 	GetCoveredRules() RuleSet
+
+	// GetRuleMetadata returns the metadata of the given rule, if it is covered
+	// by the registered checkers.
+	GetRuleMetadata(Rule) (RuleMetadata, bool)
+
+	// ListRuleMetadata returns the metadata of the rules covered by the
+	// registered checkers, sorted by rule name.
+	ListRuleMetadata() []RuleMetadata
 }
@@ -24,7 +24,10 @@ const (
 	NoUnusedLinkRule Rule = "no-unused-link"
 )
 
-// AllRules is the set of all supported rules.
+// AllRules is the set of all built-in rules.
+//
+// Custom rules, provided by checkers added to the registry, are not included.
+// To get the set of all rules, use [Registry.GetCoveredRules].
 var AllRules = func() RuleSet {
 	return RuleSet{}.Add(
 		PkgDocRule,
@@ -38,3 +41,15 @@ var AllRules = func() RuleSet {
 		NoUnusedLinkRule,
 	)
 }()
+
+// ruleDocBaseURL is the base URL for rule documentation.
+const ruleDocBaseURL = "https://github.com/godoc-lint/godoc-lint"
+
+// DocURL returns the URL to the rule documentation. For custom (i.e.,
+// non-built-in) rules, it returns an empty string.
+func (r Rule) DocURL() string {
+	if !AllRules.Has(r) {
+		return ""
+	}
+	return ruleDocBaseURL + "#" + string(r)
+}
@@ -0,0 +1,70 @@
+// Package nogo provides the linter analyzer for sandboxed build systems, like
+// Bazel's nogo, where the configuration is hermetic.
+//
+// The analyzer does not look up any config file. Instead, the configuration is
+// supplied via the analyzer flags, either as a JSON-serialized plain config
+// (i.e., -config-json), or via the same flags as the godoclint command (i.e.,
+// -default, -include, -exclude, -enable, and -disable), which take precedence.
+// The path patterns to include/exclude are matched against the package import
+// path joined with the file name (e.g., "example.com/foo/bar.go").
+//
+// For example, in the nogo config file:
+//
+//	{
+//	  "godoclint": {
+//	    "analyzer_flags": {
+//	      "config-json": "{\"default\":\"all\",\"exclude\":[\"^example\\\\.com/foo/internal/\"]}"
+//	    }
+//	  }
+//	}
+package nogo
+
+import (
+	"errors"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/godoc-lint/godoc-lint/pkg/compose"
+	"github.com/godoc-lint/godoc-lint/pkg/config"
+	"github.com/godoc-lint/godoc-lint/pkg/model"
+)
+
+// Analyzer is the linter analyzer, in hermetic mode, to be referenced by nogo.
+var Analyzer = NewAnalyzer()
+
+// NewAnalyzer returns a new instance of the linter analyzer, in hermetic mode.
+func NewAnalyzer() *analysis.Analyzer {
+	// The plain config is populated when the -config-json flag is parsed.
+	pcfg := &config.PlainConfig{}
+
+	composition := compose.Compose(compose.CompositionConfig{
+		BaseDirPlainConfig: pcfg,
+		Hermetic:           true,
+	})
+
+	override := model.NewConfigOverride()
+	composition.ConfigBuilder.SetOverride(override)
+
+	analyzer := composition.Analyzer.GetAnalyzer()
+	knownRules := composition.Registry.GetCoveredRules()
+
+	var configJSONSet bool
+	analyzer.Flags.Func("config-json", "JSON-serialized config, with the same keys as the config file", func(s string) error {
+		if configJSONSet {
+			return errors.New("config is set multiple times")
+		}
+		parsed, err := config.FromJSON([]byte(s))
+		if err != nil {
+			return err
+		}
+		if err := parsed.ValidateWithRules(knownRules); err != nil {
+			return err
+		}
+		configJSONSet = true
+		*pcfg = *parsed
+		return nil
+	})
+	config.RegisterOverrideFlags(&analyzer.Flags, override, knownRules)
+
+	return analyzer
+}
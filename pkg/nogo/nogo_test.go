@@ -0,0 +1,84 @@
+package nogo_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/godoc-lint/godoc-lint/pkg/nogo"
+)
+
+func TestAnalyzer(t *testing.T) {
+	wd, err := os.Getwd()
+	require.NoError(t, err, "failed to get wd")
+
+	testdir := filepath.Join(wd, "../../testdata/nogo")
+
+	t.Run("default config", func(t *testing.T) {
+		analyzer := nogo.NewAnalyzer()
+		analysistest.Run(t, testdir, analyzer, "example.com/basic")
+	})
+
+	t.Run("config via flags", func(t *testing.T) {
+		analyzer := nogo.NewAnalyzer()
+		require.NoError(t, analyzer.Flags.Set("config-json", `{
+			"default": "none",
+			"enable": ["max-len", "start-with-name"],
+			"exclude": ["^example\\.com/configured/internal/"],
+			"options": {"max-len/length": 60}
+		}`))
+		require.NoError(t, analyzer.Flags.Set("disable", "start-with-name"))
+		analysistest.Run(t, testdir, analyzer, "example.com/configured/...")
+	})
+}
+
+func TestAnalyzerInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		flag    string
+		value   string
+		wantErr string
+	}{
+		{
+			name:    "malformed json",
+			flag:    "config-json",
+			value:   `{"default":`,
+			wantErr: "cannot parse config from JSON",
+		},
+		{
+			name:    "unknown key",
+			flag:    "config-json",
+			value:   `{"foo": "bar"}`,
+			wantErr: `unknown field "foo"`,
+		},
+		{
+			name:    "unknown rule",
+			flag:    "config-json",
+			value:   `{"enable": ["foo"]}`,
+			wantErr: `invalid rule name(s) to enable: ["foo"]`,
+		},
+		{
+			name:    "unknown option",
+			flag:    "config-json",
+			value:   `{"options": {"foo/bar": 1}}`,
+			wantErr: `unknown option(s): ["foo/bar"]`,
+		},
+		{
+			name:    "unknown rule via override flag",
+			flag:    "enable",
+			value:   "foo",
+			wantErr: `unknown rule name to enable "foo"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			analyzer := nogo.NewAnalyzer()
+			err := analyzer.Flags.Set(tt.flag, tt.value)
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
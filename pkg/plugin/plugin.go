@@ -0,0 +1,132 @@
+// Package plugin provides the linter as a Golangci-lint module plugin.
+//
+// To build a custom Golangci-lint binary with the plugin, it should be added to
+// the .custom-gcl.yml file:
+//
+//	version: v2.5.0
+//	plugins:
+//	  - module: github.com/godoc-lint/godoc-lint
+//	    import: github.com/godoc-lint/godoc-lint/pkg/plugin
+//	    version: latest
+//
+// Then, it can be enabled and configured in the .golangci.yml file, where the
+// settings are the same as the linter's configuration file:
+//
+//	version: "2"
+//	linters:
+//	  enable:
+//	    - godoc-lint
+//	  settings:
+//	    custom:
+//	      godoc-lint:
+//	        type: module
+//	        settings:
+//	          default: all
+//	          options:
+//	            max-len/length: 100
+package plugin
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/golangci/plugin-module-register/register"
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/godoc-lint/godoc-lint/pkg/compose"
+	"github.com/godoc-lint/godoc-lint/pkg/config"
+	"github.com/godoc-lint/godoc-lint/pkg/model"
+)
+
+// Name is the name of the plugin, to be used in the Golangci-lint
+// configuration. It is different from the name of the linter bundled with
+// Golangci-lint (i.e., "godoclint"), to avoid conflicts.
+const Name = "godoc-lint"
+
+func init() {
+	register.Plugin(Name, New)
+}
+
+// Plugin implements the Golangci-lint module plugin interface.
+type Plugin struct {
+	pcfg     *config.PlainConfig
+	checkers []model.Checker
+}
+
+// New returns a new instance of the plugin with the given settings, which are
+// decoded into a plain config (See [config.PlainConfig]).
+func New(settings any) (register.LinterPlugin, error) {
+	return NewWithCheckers()(settings)
+}
+
+// NewWithCheckers returns a plugin constructor, where the given custom checkers
+// are registered in addition to the built-in ones (See
+// [compose.CompositionConfig.Checkers]).
+//
+// This is meant to be used by custom plugins, which register themselves with a
+// different name, like:
+//
+//	func init() {
+//		register.Plugin("acme-godoc-lint", plugin.NewWithCheckers(acme.NewNoTodoChecker()))
+//	}
+func NewWithCheckers(checkers ...model.Checker) register.NewPlugin {
+	return func(settings any) (register.LinterPlugin, error) {
+		pcfg, err := decodeSettings(settings)
+		if err != nil {
+			return nil, err
+		}
+		return &Plugin{
+			pcfg:     pcfg,
+			checkers: checkers,
+		}, nil
+	}
+}
+
+// decodeSettings decodes the given plugin settings into a plain config. Unknown
+// keys are not allowed.
+func decodeSettings(settings any) (*config.PlainConfig, error) {
+	pcfg := &config.PlainConfig{}
+	if settings == nil {
+		return pcfg, nil
+	}
+
+	dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result:      pcfg,
+		TagName:     "mapstructure",
+		ErrorUnused: true,
+	})
+	if err != nil {
+		return nil, &model.InternalError{Err: err}
+	}
+	if err := dec.Decode(settings); err != nil {
+		return nil, &model.ConfigError{Err: err}
+	}
+	return pcfg, nil
+}
+
+// BuildAnalyzers implements the corresponding interface method.
+func (p *Plugin) BuildAnalyzers() ([]*analysis.Analyzer, error) {
+	baseDir, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("cannot get current working directory: %w", err)
+	}
+
+	composition := compose.Compose(compose.CompositionConfig{
+		BaseDir:            baseDir,
+		BaseDirPlainConfig: p.pcfg,
+		Checkers:           p.checkers,
+	})
+
+	// To fail early, rather than on analyzing every package, the config for
+	// the base directory (including the checker options) is validated here.
+	if _, err := composition.ConfigBuilder.GetConfig(baseDir); err != nil {
+		return nil, err
+	}
+	return []*analysis.Analyzer{composition.Analyzer.GetAnalyzer()}, nil
+}
+
+// GetLoadMode implements the corresponding interface method.
+func (p *Plugin) GetLoadMode() string {
+	return register.LoadModeSyntax
+}
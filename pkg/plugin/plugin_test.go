@@ -0,0 +1,94 @@
+package plugin_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golangci/plugin-module-register/register"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/godoc-lint/godoc-lint/pkg/model"
+	"github.com/godoc-lint/godoc-lint/pkg/plugin"
+)
+
+func TestPlugin(t *testing.T) {
+	wd, err := os.Getwd()
+	require.NoError(t, err, "failed to get wd")
+
+	testdir := filepath.Join(wd, "../../testdata/plugin")
+
+	// Golangci-lint runs the analyzers in the project root directory.
+	t.Chdir(testdir)
+
+	newPlugin, err := register.GetPlugin(plugin.Name)
+	require.NoError(t, err)
+
+	p, err := newPlugin(map[string]any{
+		"default": "none",
+		"enable":  []any{"max-len", "start-with-name"},
+		"disable": []any{"start-with-name"},
+		"options": map[string]any{
+			"max-len/length": 50,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, register.LoadModeSyntax, p.GetLoadMode())
+
+	analyzers, err := p.BuildAnalyzers()
+	require.NoError(t, err)
+	require.Len(t, analyzers, 1)
+
+	analysistest.Run(t, testdir, analyzers[0], "example.com/foo")
+}
+
+func TestPluginInvalidSettings(t *testing.T) {
+	tests := []struct {
+		name          string
+		settings      any
+		wantBuildErr  bool
+		wantErrSubstr string
+	}{
+		{
+			name:          "unknown key",
+			settings:      map[string]any{"foo": "bar"},
+			wantErrSubstr: "foo",
+		},
+		{
+			name:          "invalid type",
+			settings:      map[string]any{"enable": 1},
+			wantErrSubstr: "enable",
+		},
+		{
+			name:          "unknown rule",
+			settings:      map[string]any{"enable": []any{"foo"}},
+			wantBuildErr:  true,
+			wantErrSubstr: `invalid rule name(s) to enable: ["foo"]`,
+		},
+		{
+			name:          "invalid option",
+			settings:      map[string]any{"options": map[string]any{"max-len/length": "foo"}},
+			wantBuildErr:  true,
+			wantErrSubstr: "max-len",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := plugin.New(tt.settings)
+			if !tt.wantBuildErr {
+				var cerr *model.ConfigError
+				require.ErrorAs(t, err, &cerr)
+				require.ErrorContains(t, err, tt.wantErrSubstr)
+				return
+			}
+			require.NoError(t, err)
+
+			_, err = p.BuildAnalyzers()
+			var cerr *model.ConfigError
+			require.ErrorAs(t, err, &cerr)
+			require.ErrorContains(t, err, tt.wantErrSubstr)
+		})
+	}
+}
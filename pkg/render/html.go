@@ -0,0 +1,185 @@
+package render
+
+import (
+	"cmp"
+	"fmt"
+	"go/doc/comment"
+	"html/template"
+	"io"
+	"path/filepath"
+	"slices"
+
+	"github.com/godoc-lint/godoc-lint/pkg/model"
+)
+
+// HTMLOptions holds the options for rendering HTML pages.
+type HTMLOptions struct {
+	// Issues holds the issues to annotate next to the affected declarations.
+	// Issues that do not fall into any declaration are listed at the top of
+	// the page.
+	Issues []model.Issue
+
+	// DocLinkURL returns the URL of the given doc link (e.g., [fmt.Println]).
+	// If nil, the links point to pkg.go.dev.
+	DocLinkURL func(link *comment.DocLink) string
+
+	// IndexURL is the URL of the index page, which is linked from the page. If
+	// empty, no link is added.
+	IndexURL string
+}
+
+// WriteHTML writes the given package as a standalone HTML page.
+func WriteHTML(w io.Writer, p *Package, opts HTMLOptions) error {
+	pkgPrinter, declPrinter := newPrinter(3, opts.DocLinkURL), newPrinter(4, opts.DocLinkURL)
+
+	type htmlDecl struct {
+		Decl
+		DocHTML template.HTML
+		Issues  []model.Issue
+	}
+	data := struct {
+		Package  *Package
+		IndexURL string
+		DocHTML  template.HTML
+		Issues   []model.Issue
+		Decls    []*htmlDecl
+	}{
+		Package:  p,
+		IndexURL: opts.IndexURL,
+	}
+	if p.Doc != nil {
+		data.DocHTML = template.HTML(pkgPrinter.HTML(p.Doc))
+	}
+	for _, d := range p.Decls {
+		hd := &htmlDecl{Decl: d}
+		if d.Doc != nil {
+			hd.DocHTML = template.HTML(declPrinter.HTML(d.Doc))
+		}
+		data.Decls = append(data.Decls, hd)
+	}
+
+	issues := slices.Clone(opts.Issues)
+	slices.SortStableFunc(issues, func(a, b model.Issue) int {
+		return cmp.Or(
+			cmp.Compare(a.Pos.Filename, b.Pos.Filename),
+			cmp.Compare(a.Pos.Offset, b.Pos.Offset),
+		)
+	})
+	for _, issue := range issues {
+		ix := slices.IndexFunc(data.Decls, func(d *htmlDecl) bool {
+			return d.Range.Contains(issue.Pos)
+		})
+		if ix == -1 {
+			data.Issues = append(data.Issues, issue)
+			continue
+		}
+		data.Decls[ix].Issues = append(data.Decls[ix].Issues, issue)
+	}
+
+	return pageTemplate.Execute(w, data)
+}
+
+// IndexEntry represents a package listed in an HTML index page.
+type IndexEntry struct {
+	// Package is the package to list.
+	Package *Package
+
+	// URL is the URL of the package page.
+	URL string
+
+	// Issues is the number of issues in the package.
+	Issues int
+}
+
+// WriteHTMLIndex writes an HTML page listing the given packages.
+func WriteHTMLIndex(w io.Writer, entries []IndexEntry) error {
+	return indexTemplate.Execute(w, entries)
+}
+
+var templateFuncs = template.FuncMap{
+	"position": func(issue model.Issue) string {
+		return fmt.Sprintf("%s:%d:%d", filepath.Base(issue.Pos.Filename), issue.Pos.Line, issue.Pos.Column)
+	},
+}
+
+var indexTemplate = template.Must(template.New("index").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Packages</title>
+<style>
+` + pageStyle + `</style>
+</head>
+<body>
+<h1>Packages</h1>
+<table>
+<tr><th>Package</th><th>Issues</th><th>Synopsis</th></tr>
+{{- range .}}
+<tr><td><a href="{{.URL}}">{{.Package.Path}}</a></td><td{{if .Issues}} class="count"{{end}}>{{.Issues}}</td><td>{{.Package.Synopsis}}</td></tr>
+{{- end}}
+</table>
+</body>
+</html>
+`))
+
+var pageTemplate = template.Must(template.New("page").Funcs(templateFuncs).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Package.Path}}</title>
+<style>
+` + pageStyle + `</style>
+</head>
+<body>
+{{- if .IndexURL}}
+<nav><a href="{{.IndexURL}}">Packages</a></nav>
+{{- end}}
+<h1>package {{.Package.Name}}</h1>
+<pre>import "{{.Package.Path}}"</pre>
+{{- template "issues" .Issues}}
+{{- if .DocHTML}}
+<h2 id="pkg-overview">Overview</h2>
+{{.DocHTML}}
+{{- else}}
+<p class="nodoc">No package godoc.</p>
+{{- end}}
+{{- if .Decls}}
+<h2 id="pkg-decls">Declarations</h2>
+{{- range .Decls}}
+<section id="{{.ID}}">
+<h3>{{.Title}}</h3>
+<pre>{{.Code}}</pre>
+{{- template "issues" .Issues}}
+{{- if .DocHTML}}
+{{.DocHTML}}
+{{- else}}
+<p class="nodoc">No godoc.</p>
+{{- end}}
+</section>
+{{- end}}
+{{- end}}
+</body>
+</html>
+{{define "issues"}}
+{{- if .}}
+<ul class="issues">
+{{- range .}}
+<li><span class="rule">{{.Rule}}</span>: {{.Message}} <span class="pos">({{position .}})</span></li>
+{{- end}}
+</ul>
+{{- end}}
+{{- end}}
+`))
+
+// pageStyle is the stylesheet shared by the HTML pages.
+const pageStyle = `body { font-family: sans-serif; line-height: 1.5; max-width: 60em; margin: 0 auto; padding: 1em; }
+pre { background: #f5f5f5; padding: 0.5em; overflow-x: auto; }
+section { border-top: 1px solid #ddd; margin-top: 1.5em; }
+.issues { border-left: 4px solid #d33; background: #fff3f3; margin: 0.5em 0; padding: 0.25em 0.75em; list-style: none; }
+.issues .rule { font-family: monospace; font-weight: bold; }
+.issues .pos { color: #777; font-size: smaller; }
+.nodoc { color: #777; font-style: italic; }
+table { border-collapse: collapse; }
+td, th { text-align: left; padding: 0.25em 1em 0.25em 0; vertical-align: top; }
+.count { color: #d33; font-weight: bold; }
+`
@@ -0,0 +1,214 @@
+// Package render provides rendering of package godocs (e.g., as HTML), based on
+// the comment groups parsed by the inspector.
+package render
+
+import (
+	"bytes"
+	"go/ast"
+	"go/doc"
+	"go/doc/comment"
+	"go/printer"
+	"go/token"
+	"strings"
+
+	"github.com/godoc-lint/godoc-lint/pkg/model"
+)
+
+// File represents an inspected file of a package.
+type File struct {
+	// AST is the parsed file.
+	AST *ast.File
+
+	// Inspection is the inspection result of the file.
+	Inspection *model.FileInspection
+}
+
+// Package represents the rendering model of a package.
+type Package struct {
+	// Path is the package path.
+	Path string
+
+	// Name is the package name.
+	Name string
+
+	// Doc is the package godoc, or nil if there is none. If more than one file
+	// has a package godoc, the first one is used.
+	Doc *comment.Doc
+
+	// Synopsis is the first sentence of the package godoc.
+	Synopsis string
+
+	// DocRange is the range of the package godoc (or the package clause, if
+	// there is no godoc).
+	DocRange Range
+
+	// Decls holds the top-level declarations, in the order they are declared.
+	Decls []Decl
+}
+
+// Decl represents the rendering model of a top-level declaration, which may
+// declare more than one symbol (e.g., a group of constants).
+type Decl struct {
+	// Kind is the declaration kind.
+	Kind model.SymbolDeclKind
+
+	// ID is the identifier of the declaration, which is the first declared
+	// symbol name, or "Type.Method" for methods.
+	ID string
+
+	// Names holds the names of the declared symbols.
+	Names []string
+
+	// Recv is the method receiver base type name, if the declaration is a
+	// method.
+	Recv string
+
+	// Code is the source code of the declaration (without the godoc or the
+	// function body).
+	Code string
+
+	// Doc is the declaration godoc, or nil if there is none.
+	Doc *comment.Doc
+
+	// Range is the range of the declaration, including its godoc.
+	Range Range
+}
+
+// Title returns a short title for the declaration (e.g., "func (T) Foo").
+func (d *Decl) Title() string {
+	kind := string(d.Kind)
+	if d.Recv != "" {
+		return kind + " (" + d.Recv + ") " + strings.Join(d.Names, ", ")
+	}
+	return kind + " " + strings.Join(d.Names, ", ")
+}
+
+// Range represents a range of lines in a file.
+type Range struct {
+	// Filename is the path to the file.
+	Filename string
+
+	// Start is the first line of the range.
+	Start int
+
+	// End is the last line of the range.
+	End int
+}
+
+// Contains determines whether the given position is within the range.
+func (r Range) Contains(pos token.Position) bool {
+	return r.Filename == pos.Filename && r.Start <= pos.Line && pos.Line <= r.End
+}
+
+// NewPackage returns the rendering model of the package with the given path,
+// name and inspected files. Files should be given in the order they appear in
+// the package.
+func NewPackage(fset *token.FileSet, path, name string, files []File) *Package {
+	p := &Package{
+		Path: path,
+		Name: name,
+	}
+
+	for _, f := range files {
+		if p.Doc == nil && f.Inspection.PackageDoc != nil && f.Inspection.PackageDoc.Text != "" {
+			p.Doc = f.Inspection.PackageDoc.Parsed()
+			p.Synopsis = new(doc.Package).Synopsis(f.Inspection.PackageDoc.Text)
+			p.DocRange = newRange(fset, f.AST.Doc.Pos(), f.AST.Name.End())
+		} else if p.DocRange.Filename == "" {
+			p.DocRange = newRange(fset, f.AST.Package, f.AST.Name.End())
+		}
+
+		var last ast.Decl
+		for _, sd := range f.Inspection.SymbolDecl {
+			if sd.Kind == model.SymbolDeclKindBad {
+				continue
+			}
+			if sd.Decl == last {
+				d := &p.Decls[len(p.Decls)-1]
+				d.Names = append(d.Names, sd.Name)
+				continue
+			}
+			last = sd.Decl
+			p.Decls = append(p.Decls, newDecl(fset, f.AST, sd))
+		}
+	}
+	return p
+}
+
+// pkgGoDevURL is the base URL used for doc links by default.
+const pkgGoDevURL = "https://pkg.go.dev"
+
+// newPrinter returns a new comment printer with the given heading level and doc
+// link URL function. If the function is nil, the links point to pkg.go.dev.
+func newPrinter(headingLevel int, docLinkURL func(*comment.DocLink) string) *comment.Printer {
+	if docLinkURL == nil {
+		docLinkURL = func(link *comment.DocLink) string {
+			return link.DefaultURL(pkgGoDevURL)
+		}
+	}
+	return &comment.Printer{
+		HeadingLevel: headingLevel,
+		DocLinkURL:   docLinkURL,
+	}
+}
+
+// printerConfig is the same configuration that gofmt uses.
+var printerConfig = &printer.Config{Mode: printer.UseSpaces | printer.TabIndent, Tabwidth: 8}
+
+func newDecl(fset *token.FileSet, f *ast.File, sd model.SymbolDecl) Decl {
+	d := Decl{
+		Kind:  sd.Kind,
+		ID:    sd.Name,
+		Names: []string{sd.Name},
+	}
+
+	var doc *model.CommentGroup
+	switch {
+	case sd.MultiSpecDecl:
+		doc = sd.ParentDoc
+	case sd.Doc != nil:
+		doc = sd.Doc
+	default:
+		doc = sd.TrailingDoc
+	}
+	if doc != nil && doc.Text != "" {
+		d.Doc = doc.Parsed()
+	}
+
+	var node ast.Node
+	start := sd.Decl.Pos()
+	switch dt := sd.Decl.(type) {
+	case *ast.FuncDecl:
+		if sd.IsMethod {
+			d.Recv = sd.MethodRecvBaseTypeName
+			if d.Recv != "" {
+				d.ID = d.Recv + "." + sd.Name
+			}
+		}
+		if dt.Doc != nil {
+			start = dt.Doc.Pos()
+		}
+		fd := *dt
+		fd.Doc = nil
+		fd.Body = nil
+		node = &fd
+	case *ast.GenDecl:
+		if dt.Doc != nil {
+			start = dt.Doc.Pos()
+		}
+		gd := *dt
+		gd.Doc = nil
+		node = &gd
+	}
+
+	var buf bytes.Buffer
+	_ = printerConfig.Fprint(&buf, fset, &printer.CommentedNode{Node: node, Comments: f.Comments})
+	d.Code = strings.TrimRight(buf.String(), "\n")
+	d.Range = newRange(fset, start, sd.Decl.End())
+	return d
+}
+
+func newRange(fset *token.FileSet, start, end token.Pos) Range {
+	s, e := fset.Position(start), fset.Position(end)
+	return Range{Filename: s.Filename, Start: s.Line, End: e.Line}
+}
@@ -0,0 +1,149 @@
+package render_test
+
+import (
+	"bytes"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/godoc-lint/godoc-lint/pkg/config"
+	"github.com/godoc-lint/godoc-lint/pkg/inspect"
+	"github.com/godoc-lint/godoc-lint/pkg/model"
+	"github.com/godoc-lint/godoc-lint/pkg/render"
+)
+
+// loadPackage runs the inspector on the given testdata package and returns its
+// rendering model.
+func loadPackage(t *testing.T, name string) *render.Package {
+	t.Helper()
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	testdir := filepath.Join(wd, "../../testdata/render")
+
+	inspector := inspect.NewInspector(config.NewOnceConfigBuilder(config.NewConfigBuilder(testdir)))
+	ars := analysistest.Run(t, testdir, inspector.GetAnalyzer(), "./"+name)
+	require.Len(t, ars, 1)
+
+	ar := ars[0]
+	result, ok := ar.Result.(*model.InspectorResult)
+	require.True(t, ok, "unknown result type")
+
+	var files []render.File
+	for _, f := range ar.Pass.Files {
+		files = append(files, render.File{AST: f, Inspection: result.Files[f]})
+	}
+	return render.NewPackage(ar.Pass.Fset, ar.Pass.Pkg.Path(), ar.Pass.Pkg.Name(), files)
+}
+
+func TestNewPackage(t *testing.T) {
+	p := loadPackage(t, "basic")
+
+	require.Equal(t, "basic", p.Name)
+	require.Equal(t, "Package basic is a package to test rendering.", p.Synopsis)
+	require.NotNil(t, p.Doc)
+	require.Equal(t, 1, p.DocRange.Start)
+	require.Equal(t, 6, p.DocRange.End)
+
+	type decl struct {
+		Title string
+		ID    string
+		Code  string
+		Doc   bool
+		Start int
+		End   int
+	}
+	var got []decl
+	for _, d := range p.Decls {
+		got = append(got, decl{d.Title(), d.ID, d.Code, d.Doc != nil, d.Range.Start, d.Range.End})
+	}
+	require.Equal(t, []decl{
+		{"func Foo", "Foo", "func Foo()", true, 8, 11},
+		{"func bar", "bar", "func bar()", false, 13, 13},
+		{"const A, B", "A", "const (\n\t// A is a constant.\n\tA = 0\n\tB = 1 // B is a constant.\n)", true, 15, 20},
+		{"const C", "C", "const C = 0 // C is a constant.", true, 22, 22},
+		{"type T", "T", "type T struct {\n\t// F is a field.\n\tF int\n}", true, 24, 28},
+		{"func (T) M", "T.M", "func (*T) M()", true, 30, 31},
+	}, got)
+}
+
+func TestWriteHTML(t *testing.T) {
+	p := loadPackage(t, "basic")
+
+	filename := p.DocRange.Filename
+	issue := func(line int, rule model.Rule, message string) model.Issue {
+		return model.Issue{
+			Rule:    rule,
+			Pos:     token.Position{Filename: filename, Line: line, Column: 1},
+			Message: message,
+		}
+	}
+
+	var buf bytes.Buffer
+	err := render.WriteHTML(&buf, p, render.HTMLOptions{
+		Issues: []model.Issue{
+			issue(13, model.RequireDocRule, "symbol should have a godoc"),
+			issue(1, model.PkgDocRule, "package <godoc> issue"),
+		},
+		IndexURL: "/",
+	})
+	require.NoError(t, err)
+	html := buf.String()
+
+	require.Contains(t, html, `<nav><a href="/">Packages</a></nav>`)
+	require.Contains(t, html, `<h3 id="hdr-Heading">Heading</h3>`)
+	require.Contains(t, html, `<a href="https://pkg.go.dev/fmt#Println">fmt.Println</a>`)
+	require.Contains(t, html, `<span class="rule">pkg-doc</span>: package &lt;godoc&gt; issue <span class="pos">(basic.go:1:1)</span>`)
+	require.Regexp(t, `(?s)<section id="bar">\s*<h3>func bar</h3>\s*<pre>func bar\(\)</pre>\s*<ul class="issues">\s*<li><span class="rule">require-doc</span>`, html)
+	require.Contains(t, html, `<section id="T.M">`)
+}
+
+func TestWriteTextAndMarkdown(t *testing.T) {
+	p := loadPackage(t, "basic")
+	p.Path = "example.com/basic"
+
+	tests := []struct {
+		name   string
+		write  func(*bytes.Buffer, *render.Package) error
+		golden string
+	}{{
+		name:   "text",
+		write:  func(b *bytes.Buffer, p *render.Package) error { return render.WriteText(b, p) },
+		golden: "basic.txt",
+	}, {
+		name:   "markdown",
+		write:  func(b *bytes.Buffer, p *render.Package) error { return render.WriteMarkdown(b, p) },
+		golden: "basic.md",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, tt.write(&buf, p))
+
+			want, err := os.ReadFile(filepath.Join("../../testdata/render/basic", tt.golden))
+			require.NoError(t, err)
+			require.Equal(t, string(want), buf.String())
+		})
+	}
+}
+
+func TestWriteDocMarkdown(t *testing.T) {
+	p := loadPackage(t, "basic")
+	p.Path = "example.com/basic"
+
+	var buf bytes.Buffer
+	require.NoError(t, render.WritePackageDocMarkdown(&buf, p))
+	require.Equal(t, "```go\npackage basic // import \"example.com/basic\"\n```\n\n"+
+		"Package basic is a package to test rendering.\n\n"+
+		"#### Heading {#hdr-Heading}\n\n"+
+		"See [fmt.Println](https://pkg.go.dev/fmt#Println).\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, render.WriteDeclMarkdown(&buf, &p.Decls[0]))
+	require.Equal(t, "```go\nfunc Foo()\n```\n\nFoo is a function.\n", buf.String())
+}
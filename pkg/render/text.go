@@ -0,0 +1,103 @@
+package render
+
+import (
+	"bufio"
+	"fmt"
+	"go/doc/comment"
+	"io"
+	"strings"
+)
+
+// textIndent is the indentation of declaration godocs in the text output, which
+// is the same as the go doc command.
+const textIndent = "    "
+
+// WriteText writes the given package godocs as plain text, in a format similar
+// to the go doc command.
+func WriteText(w io.Writer, p *Package) error {
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, "package %s // import %q\n", p.Name, p.Path)
+	if p.Doc != nil {
+		bw.WriteString("\n")
+		bw.Write(newPrinter(0, nil).Text(p.Doc))
+	}
+
+	declPrinter := newPrinter(0, nil)
+	declPrinter.TextPrefix = textIndent
+	for _, d := range p.Decls {
+		bw.WriteString("\n")
+		bw.WriteString(d.Code)
+		bw.WriteString("\n")
+		if d.Doc != nil {
+			bw.Write(declPrinter.Text(d.Doc))
+		}
+	}
+	return bw.Flush()
+}
+
+// WriteMarkdown writes the given package godocs as Markdown.
+func WriteMarkdown(w io.Writer, p *Package) error {
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, "# package %s\n\n", p.Name)
+	fmt.Fprintf(bw, "```go\nimport %q\n```\n", p.Path)
+	if p.Doc != nil {
+		bw.WriteString("\n## Overview\n\n")
+		bw.Write(newPrinter(3, nil).Markdown(p.Doc))
+	}
+
+	if len(p.Decls) > 0 {
+		bw.WriteString("\n## Declarations\n")
+	}
+	declPrinter := newPrinter(4, nil)
+	for _, d := range p.Decls {
+		fmt.Fprintf(bw, "\n### %s\n\n", d.Title())
+		writeDeclMarkdown(bw, &d, declPrinter)
+	}
+	return bw.Flush()
+}
+
+// WritePackageDocMarkdown writes the package clause and godoc of the given
+// package as Markdown (e.g., for hover previews in editors).
+func WritePackageDocMarkdown(w io.Writer, p *Package) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "```go\npackage %s // import %q\n```\n", p.Name, p.Path)
+	if p.Doc != nil {
+		bw.WriteString("\n")
+		bw.Write(newPrinter(4, nil).Markdown(p.Doc))
+	}
+	return bw.Flush()
+}
+
+// WriteDeclMarkdown writes the code and godoc of the given declaration as
+// Markdown (e.g., for hover previews in editors).
+func WriteDeclMarkdown(w io.Writer, d *Decl) error {
+	bw := bufio.NewWriter(w)
+	writeDeclMarkdown(bw, d, newPrinter(4, nil))
+	return bw.Flush()
+}
+
+func writeDeclMarkdown(bw *bufio.Writer, d *Decl, printer *comment.Printer) {
+	fence := codeFence(d.Code)
+	fmt.Fprintf(bw, "%sgo\n%s\n%s\n", fence, d.Code, fence)
+	if d.Doc != nil {
+		bw.WriteString("\n")
+		bw.Write(printer.Markdown(d.Doc))
+	}
+}
+
+// codeFence returns a Markdown code fence that is longer than any run of
+// backticks in the given code.
+func codeFence(code string) string {
+	longest, run := 0, 0
+	for _, r := range code {
+		if r == '`' {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	return strings.Repeat("`", max(3, longest+1))
+}
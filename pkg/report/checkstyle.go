@@ -0,0 +1,90 @@
+package report
+
+import (
+	"encoding/xml"
+	"io"
+
+	"github.com/godoc-lint/godoc-lint/pkg/model"
+)
+
+const (
+	checkstyleVersion  = "5.0"
+	checkstyleSeverity = "error"
+)
+
+// CheckstyleReporter writes issues in the Checkstyle XML format.
+//
+// Issues are grouped by file, in the order they appear. The rule name is used
+// as the source of each reported error. File paths are written relative to the
+// base directory, if possible.
+type CheckstyleReporter struct {
+	baseDir string
+}
+
+// NewCheckstyleReporter returns a new instance of the corresponding reporter.
+func NewCheckstyleReporter(baseDir string) *CheckstyleReporter {
+	return &CheckstyleReporter{
+		baseDir: baseDir,
+	}
+}
+
+type checkstyleOutput struct {
+	XMLName xml.Name          `xml:"checkstyle"`
+	Version string            `xml:"version,attr"`
+	Files   []*checkstyleFile `xml:"file"`
+}
+
+type checkstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []checkstyleError `xml:"error"`
+}
+
+type checkstyleError struct {
+	Line     int    `xml:"line,attr"`
+	Column   int    `xml:"column,attr"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+// Report implements the corresponding interface method.
+func (r *CheckstyleReporter) Report(w io.Writer, issues []model.Issue) error {
+	out := checkstyleOutput{
+		Version: checkstyleVersion,
+		Files:   make([]*checkstyleFile, 0, 10),
+	}
+
+	files := make(map[string]*checkstyleFile, 10)
+	for _, issue := range issues {
+		f, ok := files[issue.Pos.Filename]
+		if !ok {
+			name, _ := relPath(r.baseDir, issue.Pos.Filename)
+			f = &checkstyleFile{Name: name}
+			files[issue.Pos.Filename] = f
+			out.Files = append(out.Files, f)
+		}
+		f.Errors = append(f.Errors, checkstyleError{
+			Line:     issue.Pos.Line,
+			Column:   issue.Pos.Column,
+			Severity: checkstyleSeverity,
+			Message:  issue.Message,
+			Source:   string(issue.Rule),
+		})
+	}
+
+	return writeXML(w, out)
+}
+
+// writeXML writes the given value as an indented XML document.
+func writeXML(w io.Writer, v any) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
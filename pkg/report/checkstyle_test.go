@@ -0,0 +1,63 @@
+package report_test
+
+import (
+	"bytes"
+	"go/token"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/godoc-lint/godoc-lint/pkg/model"
+	"github.com/godoc-lint/godoc-lint/pkg/report"
+)
+
+var _ report.Reporter = &report.CheckstyleReporter{}
+
+func TestCheckstyleReporter(t *testing.T) {
+	require := require.New(t)
+
+	baseDir := t.TempDir()
+	foo := filepath.Join(baseDir, "foo.go")
+	bar := filepath.Join(baseDir, "bar", "bar.go")
+
+	issues := []model.Issue{
+		{
+			Rule:    model.MaxLenRule,
+			Pos:     token.Position{Filename: foo, Line: 2, Column: 1},
+			Message: "godoc line is too long (90 > 77)",
+		},
+		{
+			Rule:    model.RequireDocRule,
+			Pos:     token.Position{Filename: bar, Line: 5, Column: 6},
+			Message: `symbol should have a godoc ("Bar")`,
+		},
+		{
+			Rule:    model.StartWithNameRule,
+			Pos:     token.Position{Filename: foo, Line: 7, Column: 1},
+			Message: `godoc should start with symbol name ("Foo")`,
+		},
+	}
+
+	buf := bytes.NewBuffer(nil)
+	require.NoError(report.NewCheckstyleReporter(baseDir).Report(buf, issues))
+	require.Equal(`<?xml version="1.0" encoding="UTF-8"?>
+<checkstyle version="5.0">
+  <file name="foo.go">
+    <error line="2" column="1" severity="error" message="godoc line is too long (90 &gt; 77)" source="max-len"></error>
+    <error line="7" column="1" severity="error" message="godoc should start with symbol name (&#34;Foo&#34;)" source="start-with-name"></error>
+  </file>
+  <file name="bar/bar.go">
+    <error line="5" column="6" severity="error" message="symbol should have a godoc (&#34;Bar&#34;)" source="require-doc"></error>
+  </file>
+</checkstyle>
+`, buf.String())
+}
+
+func TestCheckstyleReporterNoIssues(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	require.NoError(t, report.NewCheckstyleReporter("").Report(buf, nil))
+	require.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<checkstyle version="5.0"></checkstyle>
+`, buf.String())
+}
@@ -0,0 +1,67 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/godoc-lint/godoc-lint/pkg/model"
+)
+
+// GitHubReporter writes issues as GitHub Actions workflow commands (i.e.,
+// "::error ...::message"), so that they appear as annotations on the
+// corresponding source lines.
+//
+// File paths are written relative to the base directory, if possible, which is
+// expected to be the repository root.
+type GitHubReporter struct {
+	baseDir string
+}
+
+// NewGitHubReporter returns a new instance of the corresponding reporter.
+func NewGitHubReporter(baseDir string) *GitHubReporter {
+	return &GitHubReporter{
+		baseDir: baseDir,
+	}
+}
+
+// Report implements the corresponding interface method.
+func (r *GitHubReporter) Report(w io.Writer, issues []model.Issue) error {
+	for _, issue := range issues {
+		name, _ := relPath(r.baseDir, issue.Pos.Filename)
+
+		props := []string{
+			"file=" + escapeGitHubProperty(name),
+			fmt.Sprintf("line=%d", issue.Pos.Line),
+			fmt.Sprintf("col=%d", issue.Pos.Column),
+		}
+		if issue.End.IsValid() {
+			props = append(props,
+				fmt.Sprintf("endLine=%d", issue.End.Line),
+				fmt.Sprintf("endColumn=%d", issue.End.Column),
+			)
+		}
+		props = append(props, "title="+escapeGitHubProperty("godoclint/"+string(issue.Rule)))
+
+		if _, err := fmt.Fprintf(w, "::error %s::%s\n", strings.Join(props, ","), escapeGitHubData(issue.Message)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// escapeGitHubData escapes the given workflow command data (i.e., the message).
+func escapeGitHubData(s string) string {
+	s = strings.ReplaceAll(s, "%", "%25")
+	s = strings.ReplaceAll(s, "\r", "%0D")
+	s = strings.ReplaceAll(s, "\n", "%0A")
+	return s
+}
+
+// escapeGitHubProperty escapes the given workflow command property value.
+func escapeGitHubProperty(s string) string {
+	s = escapeGitHubData(s)
+	s = strings.ReplaceAll(s, ":", "%3A")
+	s = strings.ReplaceAll(s, ",", "%2C")
+	return s
+}
@@ -0,0 +1,43 @@
+package report_test
+
+import (
+	"bytes"
+	"go/token"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/godoc-lint/godoc-lint/pkg/model"
+	"github.com/godoc-lint/godoc-lint/pkg/report"
+)
+
+var _ report.Reporter = &report.GitHubReporter{}
+
+func TestGitHubReporter(t *testing.T) {
+	require := require.New(t)
+
+	baseDir := t.TempDir()
+	foo := filepath.Join(baseDir, "foo", "foo.go")
+
+	issues := []model.Issue{
+		{
+			Rule:    model.MaxLenRule,
+			Pos:     token.Position{Filename: foo, Line: 2, Column: 1},
+			End:     token.Position{Filename: foo, Line: 2, Column: 91},
+			Message: "godoc line is too long (90 > 77)",
+		},
+		{
+			Rule:    model.NoUnusedLinkRule,
+			Pos:     token.Position{Filename: foo, Line: 7, Column: 1},
+			Message: "100% unused link\nwith: multiple lines",
+		},
+	}
+
+	buf := bytes.NewBuffer(nil)
+	require.NoError(report.NewGitHubReporter(baseDir).Report(buf, issues))
+	require.Equal(``+
+		"::error file=foo/foo.go,line=2,col=1,endLine=2,endColumn=91,title=godoclint/max-len::godoc line is too long (90 > 77)\n"+
+		"::error file=foo/foo.go,line=7,col=1,title=godoclint/no-unused-link::100%25 unused link%0Awith: multiple lines\n",
+		buf.String())
+}
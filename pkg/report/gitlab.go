@@ -0,0 +1,89 @@
+package report
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/godoc-lint/godoc-lint/pkg/model"
+)
+
+const gitLabSeverity = "major"
+
+// GitLabReporter writes issues as a GitLab Code Quality report (JSON).
+//
+// Issue fingerprints are computed from the rule name, the file path and the
+// symbol name, rather than the line number or the message, so that they remain
+// stable when code moves around, or when the details in the message change
+// (e.g., the length of a long line). Issues of the same rule on the same symbol
+// are told apart by their order of appearance.
+//
+// File paths are written relative to the base directory, if possible, which is
+// expected to be the repository root.
+type GitLabReporter struct {
+	baseDir string
+}
+
+// NewGitLabReporter returns a new instance of the corresponding reporter.
+func NewGitLabReporter(baseDir string) *GitLabReporter {
+	return &GitLabReporter{
+		baseDir: baseDir,
+	}
+}
+
+type gitLabIssue struct {
+	Description string         `json:"description"`
+	CheckName   string         `json:"check_name"`
+	Fingerprint string         `json:"fingerprint"`
+	Severity    string         `json:"severity"`
+	Location    gitLabLocation `json:"location"`
+}
+
+type gitLabLocation struct {
+	Path  string      `json:"path"`
+	Lines gitLabLines `json:"lines"`
+}
+
+type gitLabLines struct {
+	Begin int `json:"begin"`
+	End   int `json:"end,omitempty"`
+}
+
+// Report implements the corresponding interface method.
+func (r *GitLabReporter) Report(w io.Writer, issues []model.Issue) error {
+	occurrences := make(map[string]int, len(issues))
+
+	out := make([]gitLabIssue, 0, len(issues))
+	for _, issue := range issues {
+		path, _ := relPath(r.baseDir, issue.Pos.Filename)
+
+		key := fmt.Sprintf("%s\x00%s\x00%s", issue.Rule, path, issue.Symbol)
+		n := occurrences[key]
+		occurrences[key] = n + 1
+
+		sum := sha256.Sum256(fmt.Appendf(nil, "%s\x00%d", key, n))
+
+		lines := gitLabLines{Begin: issue.Pos.Line}
+		if issue.End.IsValid() {
+			lines.End = issue.End.Line
+		}
+
+		out = append(out, gitLabIssue{
+			Description: issue.Message,
+			CheckName:   "godoclint/" + string(issue.Rule),
+			Fingerprint: hex.EncodeToString(sum[:]),
+			Severity:    gitLabSeverity,
+			Location: gitLabLocation{
+				Path:  path,
+				Lines: lines,
+			},
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
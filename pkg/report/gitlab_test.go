@@ -0,0 +1,87 @@
+package report_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/godoc-lint/godoc-lint/pkg/model"
+	"github.com/godoc-lint/godoc-lint/pkg/report"
+)
+
+var _ report.Reporter = &report.GitLabReporter{}
+
+func TestGitLabReporter(t *testing.T) {
+	require := require.New(t)
+
+	baseDir := t.TempDir()
+	foo := filepath.Join(baseDir, "foo", "foo.go")
+
+	issue := func(line int, symbol string, length int) model.Issue {
+		return model.Issue{
+			Rule:    model.MaxLenRule,
+			Symbol:  symbol,
+			Pos:     token.Position{Filename: foo, Line: line, Column: 1},
+			End:     token.Position{Filename: foo, Line: line, Column: length + 1},
+			Message: fmt.Sprintf("godoc line is too long (%d > 77)", length),
+		}
+	}
+
+	type gitLabIssue struct {
+		Description string `json:"description"`
+		CheckName   string `json:"check_name"`
+		Fingerprint string `json:"fingerprint"`
+		Severity    string `json:"severity"`
+		Location    struct {
+			Path  string `json:"path"`
+			Lines struct {
+				Begin int `json:"begin"`
+				End   int `json:"end"`
+			} `json:"lines"`
+		} `json:"location"`
+	}
+
+	run := func(issues ...model.Issue) []gitLabIssue {
+		buf := bytes.NewBuffer(nil)
+		require.NoError(report.NewGitLabReporter(baseDir).Report(buf, issues))
+		var got []gitLabIssue
+		require.NoError(json.Unmarshal(buf.Bytes(), &got))
+		return got
+	}
+
+	got := run(issue(2, "Foo", 90), issue(3, "Foo", 90), issue(10, "Bar", 90))
+	require.Len(got, 3)
+	require.Equal("godoc line is too long (90 > 77)", got[0].Description)
+	require.Equal("godoclint/max-len", got[0].CheckName)
+	require.Equal("major", got[0].Severity)
+	require.Equal("foo/foo.go", got[0].Location.Path)
+	require.Equal(2, got[0].Location.Lines.Begin)
+	require.Equal(2, got[0].Location.Lines.End)
+
+	// Identical issues on the same symbol should still be distinct.
+	require.NotEqual(got[0].Fingerprint, got[1].Fingerprint)
+	require.NotEqual(got[0].Fingerprint, got[2].Fingerprint)
+
+	// Fingerprints should not change when lines shift.
+	shifted := run(issue(12, "Foo", 90), issue(13, "Foo", 90), issue(20, "Bar", 90))
+	for i := range got {
+		require.Equal(got[i].Fingerprint, shifted[i].Fingerprint)
+	}
+
+	// Fingerprints should not change when the message details change.
+	edited := run(issue(2, "Foo", 95), issue(3, "Foo", 80), issue(10, "Bar", 100))
+	for i := range got {
+		require.Equal(got[i].Fingerprint, edited[i].Fingerprint)
+	}
+}
+
+func TestGitLabReporterNoIssues(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	require.NoError(t, report.NewGitLabReporter("").Report(buf, nil))
+	require.Equal(t, "[]\n", buf.String())
+}
@@ -0,0 +1,108 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/godoc-lint/godoc-lint/pkg/model"
+)
+
+// jsonAnalyzerName is the analyzer name used as the second-level key in the
+// JSON output.
+const jsonAnalyzerName = "godoclint"
+
+// JSONReporter writes issues in the JSON format that the go/analysis drivers
+// use (i.e., the output of the -json flag); diagnostics are grouped by package
+// path and then by the analyzer name.
+type JSONReporter struct{}
+
+// NewJSONReporter returns a new instance of the corresponding reporter.
+func NewJSONReporter() *JSONReporter {
+	return &JSONReporter{}
+}
+
+type jsonTextEdit struct {
+	Filename string `json:"filename"`
+	Start    int    `json:"start"`
+	End      int    `json:"end"`
+	New      string `json:"new"`
+}
+
+type jsonSuggestedFix struct {
+	Message string         `json:"message"`
+	Edits   []jsonTextEdit `json:"edits"`
+}
+
+type jsonRelatedInformation struct {
+	Posn    string `json:"posn"`
+	End     string `json:"end"`
+	Message string `json:"message"`
+}
+
+type jsonDiagnostic struct {
+	Category       string                   `json:"category,omitempty"`
+	Posn           string                   `json:"posn"`
+	End            string                   `json:"end"`
+	Message        string                   `json:"message"`
+	SuggestedFixes []jsonSuggestedFix       `json:"suggested_fixes,omitempty"`
+	Related        []jsonRelatedInformation `json:"related,omitempty"`
+}
+
+// Report implements the corresponding interface method.
+func (r *JSONReporter) Report(w io.Writer, issues []model.Issue) error {
+	tree := make(map[string]map[string][]jsonDiagnostic, 10)
+	for _, issue := range issues {
+		if _, ok := tree[issue.PkgPath]; !ok {
+			tree[issue.PkgPath] = map[string][]jsonDiagnostic{}
+		}
+
+		end := issue.End
+		if !end.IsValid() {
+			end = issue.Pos
+		}
+
+		var fixes []jsonSuggestedFix
+		for _, fix := range issue.Fixes {
+			edits := make([]jsonTextEdit, 0, len(fix.Edits))
+			for _, edit := range fix.Edits {
+				edits = append(edits, jsonTextEdit{
+					Filename: edit.Pos.Filename,
+					Start:    edit.Pos.Offset,
+					End:      edit.End.Offset,
+					New:      edit.NewText,
+				})
+			}
+			fixes = append(fixes, jsonSuggestedFix{
+				Message: fix.Message,
+				Edits:   edits,
+			})
+		}
+
+		var related []jsonRelatedInformation
+		for _, rel := range issue.Related {
+			relEnd := rel.End
+			if !relEnd.IsValid() {
+				relEnd = rel.Pos
+			}
+			related = append(related, jsonRelatedInformation{
+				Posn:    rel.Pos.String(),
+				End:     relEnd.String(),
+				Message: rel.Message,
+			})
+		}
+
+		tree[issue.PkgPath][jsonAnalyzerName] = append(tree[issue.PkgPath][jsonAnalyzerName], jsonDiagnostic{
+			Category:       string(issue.Rule),
+			Posn:           issue.Pos.String(),
+			End:            end.String(),
+			Message:        issue.Message,
+			SuggestedFixes: fixes,
+			Related:        related,
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "\t")
+	return enc.Encode(tree)
+}
@@ -0,0 +1,65 @@
+package report_test
+
+import (
+	"bytes"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/godoc-lint/godoc-lint/pkg/model"
+	"github.com/godoc-lint/godoc-lint/pkg/report"
+)
+
+var _ report.Reporter = &report.JSONReporter{}
+
+func TestJSONReporter(t *testing.T) {
+	issues := []model.Issue{
+		{
+			Rule:    model.MaxLenRule,
+			PkgPath: "example.com/foo",
+			Pos:     token.Position{Filename: "foo.go", Offset: 10, Line: 2, Column: 1},
+			End:     token.Position{Filename: "foo.go", Offset: 100, Line: 2, Column: 91},
+			Message: "godoc line is too long (90 > 77)",
+		},
+		{
+			Rule:    model.SinglePkgDocRule,
+			PkgPath: "example.com/foo",
+			Pos:     token.Position{Filename: "foo.go", Offset: 0, Line: 1, Column: 1},
+			End:     token.Position{Filename: "foo.go", Offset: 20, Line: 1, Column: 21},
+			Message: `package has more than one godoc ("foo")`,
+			Related: []model.IssueRelated{{
+				Pos:     token.Position{Filename: "bar.go", Offset: 0, Line: 1, Column: 1},
+				Message: "other package godoc",
+			}},
+		},
+	}
+
+	buf := bytes.NewBuffer(nil)
+	require.NoError(t, report.NewJSONReporter().Report(buf, issues))
+	require.JSONEq(t, `{
+		"example.com/foo": {
+			"godoclint": [
+				{
+					"category": "max-len",
+					"posn": "foo.go:2:1",
+					"end": "foo.go:2:91",
+					"message": "godoc line is too long (90 > 77)"
+				},
+				{
+					"category": "single-pkg-doc",
+					"posn": "foo.go:1:1",
+					"end": "foo.go:1:21",
+					"message": "package has more than one godoc (\"foo\")",
+					"related": [
+						{
+							"posn": "bar.go:1:1",
+							"end": "bar.go:1:1",
+							"message": "other package godoc"
+						}
+					]
+				}
+			]
+		}
+	}`, buf.String())
+}
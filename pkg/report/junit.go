@@ -0,0 +1,112 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/godoc-lint/godoc-lint/pkg/model"
+)
+
+const junitName = "godoclint"
+
+// JUnitReporter writes issues in the JUnit XML format.
+//
+// Every analyzed package is represented by a test case, which fails if there
+// are issues in the package. File paths are written relative to the base
+// directory, if possible.
+type JUnitReporter struct {
+	baseDir  string
+	packages []string
+}
+
+// NewJUnitReporter returns a new instance of the corresponding reporter. The
+// packages argument is the list of analyzed package paths; packages that only
+// appear in issues are also included.
+func NewJUnitReporter(baseDir string, packages []string) *JUnitReporter {
+	return &JUnitReporter{
+		baseDir:  baseDir,
+		packages: packages,
+	}
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",cdata"`
+}
+
+// Report implements the corresponding interface method.
+func (r *JUnitReporter) Report(w io.Writer, issues []model.Issue) error {
+	pkgIssues := make(map[string][]model.Issue, len(r.packages))
+	pkgs := make([]string, 0, len(r.packages))
+	for _, pkg := range r.packages {
+		if _, ok := pkgIssues[pkg]; ok {
+			continue
+		}
+		pkgIssues[pkg] = nil
+		pkgs = append(pkgs, pkg)
+	}
+	for _, issue := range issues {
+		if _, ok := pkgIssues[issue.PkgPath]; !ok {
+			pkgs = append(pkgs, issue.PkgPath)
+		}
+		pkgIssues[issue.PkgPath] = append(pkgIssues[issue.PkgPath], issue)
+	}
+
+	suite := junitTestSuite{
+		Name:      junitName,
+		Tests:     len(pkgs),
+		TestCases: make([]junitTestCase, 0, len(pkgs)),
+	}
+	for _, pkg := range pkgs {
+		tc := junitTestCase{
+			ClassName: junitName,
+			Name:      pkg,
+		}
+		if issues := pkgIssues[pkg]; len(issues) > 0 {
+			suite.Failures++
+
+			lines := make([]string, 0, len(issues))
+			for _, issue := range issues {
+				name, _ := relPath(r.baseDir, issue.Pos.Filename)
+				lines = append(lines, fmt.Sprintf("%s:%d:%d: %s (%s)", name, issue.Pos.Line, issue.Pos.Column, issue.Message, issue.Rule))
+			}
+			tc.Failure = &junitFailure{
+				Message: fmt.Sprintf("%d issue(s) found", len(issues)),
+				Type:    junitName,
+				Text:    strings.Join(lines, "\n"),
+			}
+		}
+		suite.TestCases = append(suite.TestCases, tc)
+	}
+
+	return writeXML(w, junitTestSuites{
+		Name:     junitName,
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Suites:   []junitTestSuite{suite},
+	})
+}
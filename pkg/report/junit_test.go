@@ -0,0 +1,52 @@
+package report_test
+
+import (
+	"bytes"
+	"go/token"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/godoc-lint/godoc-lint/pkg/model"
+	"github.com/godoc-lint/godoc-lint/pkg/report"
+)
+
+var _ report.Reporter = &report.JUnitReporter{}
+
+func TestJUnitReporter(t *testing.T) {
+	require := require.New(t)
+
+	baseDir := t.TempDir()
+	foo := filepath.Join(baseDir, "foo", "foo.go")
+
+	issues := []model.Issue{
+		{
+			Rule:    model.MaxLenRule,
+			PkgPath: "example.com/foo",
+			Pos:     token.Position{Filename: foo, Line: 2, Column: 1},
+			Message: "godoc line is too long (90 > 77)",
+		},
+		{
+			Rule:    model.StartWithNameRule,
+			PkgPath: "example.com/foo",
+			Pos:     token.Position{Filename: foo, Line: 7, Column: 1},
+			Message: `godoc should start with symbol name ("Foo")`,
+		},
+	}
+
+	buf := bytes.NewBuffer(nil)
+	r := report.NewJUnitReporter(baseDir, []string{"example.com/bar", "example.com/foo"})
+	require.NoError(r.Report(buf, issues))
+	require.Equal(`<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="godoclint" tests="2" failures="1">
+  <testsuite name="godoclint" tests="2" failures="1">
+    <testcase classname="godoclint" name="example.com/bar"></testcase>
+    <testcase classname="godoclint" name="example.com/foo">
+      <failure message="2 issue(s) found" type="godoclint"><![CDATA[foo/foo.go:2:1: godoc line is too long (90 > 77) (max-len)
+foo/foo.go:7:1: godoc should start with symbol name ("Foo") (start-with-name)]]></failure>
+    </testcase>
+  </testsuite>
+</testsuites>
+`, buf.String())
+}
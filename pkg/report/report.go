@@ -0,0 +1,101 @@
+// Package report provides reporters to write linter issues in different
+// formats.
+package report
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/godoc-lint/godoc-lint/pkg/model"
+	"github.com/godoc-lint/godoc-lint/pkg/util"
+)
+
+// Format represents an output format.
+type Format string
+
+const (
+	// FormatText represents the plain text format.
+	FormatText Format = "text"
+	// FormatJSON represents the JSON format.
+	FormatJSON Format = "json"
+	// FormatSARIF represents the SARIF (v2.1.0) format.
+	FormatSARIF Format = "sarif"
+	// FormatCheckstyle represents the Checkstyle XML format.
+	FormatCheckstyle Format = "checkstyle"
+	// FormatJUnit represents the JUnit XML format.
+	FormatJUnit Format = "junit"
+	// FormatGitHub represents the GitHub Actions workflow commands format.
+	FormatGitHub Format = "github"
+	// FormatGitLab represents the GitLab Code Quality report format.
+	FormatGitLab Format = "gitlab"
+)
+
+// FormatValues holds the valid values for Format.
+var FormatValues = []Format{
+	FormatText,
+	FormatJSON,
+	FormatSARIF,
+	FormatCheckstyle,
+	FormatJUnit,
+	FormatGitHub,
+	FormatGitLab,
+}
+
+// Reporter defines an issue reporter.
+type Reporter interface {
+	// Report writes the given issues.
+	Report(w io.Writer, issues []model.Issue) error
+}
+
+// Options holds the options shared among reporters.
+type Options struct {
+	// BaseDir is the directory to which file paths are relativized, where
+	// applicable.
+	BaseDir string
+
+	// Rules is the metadata of the rules to describe, where applicable.
+	Rules []model.RuleMetadata
+
+	// Packages is the list of analyzed package paths, where applicable.
+	Packages []string
+
+	// ShowRule indicates whether issue messages should be prefixed with the
+	// rule name, where applicable.
+	ShowRule bool
+}
+
+// NewReporter returns a new reporter for the given format.
+func NewReporter(format Format, opts Options) (Reporter, error) {
+	switch format {
+	case FormatText:
+		return NewTextReporter(opts.ShowRule), nil
+	case FormatJSON:
+		return NewJSONReporter(), nil
+	case FormatSARIF:
+		return NewSARIFReporter(opts.BaseDir, opts.Rules), nil
+	case FormatCheckstyle:
+		return NewCheckstyleReporter(opts.BaseDir), nil
+	case FormatJUnit:
+		return NewJUnitReporter(opts.BaseDir, opts.Packages), nil
+	case FormatGitHub:
+		return NewGitHubReporter(opts.BaseDir), nil
+	case FormatGitLab:
+		return NewGitLabReporter(opts.BaseDir), nil
+	}
+	return nil, fmt.Errorf("unknown format %q, must be one of %q", format, FormatValues)
+}
+
+// relPath returns the given path relative to the base directory, in Unix
+// style. If the path is not under the base directory, the second returned
+// value will be false, and the path is returned as is.
+func relPath(baseDir, path string) (string, bool) {
+	if baseDir == "" || !util.IsPathUnderBaseDir(baseDir, path) {
+		return path, false
+	}
+	rel, err := filepath.Rel(baseDir, path)
+	if err != nil {
+		return path, false
+	}
+	return filepath.ToSlash(rel), true
+}
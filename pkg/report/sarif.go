@@ -0,0 +1,294 @@
+package report
+
+import (
+	"encoding/json"
+	"go/token"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/godoc-lint/godoc-lint/pkg/model"
+	"github.com/godoc-lint/godoc-lint/pkg/util"
+	"github.com/godoc-lint/godoc-lint/pkg/version"
+)
+
+const (
+	sarifVersion   = "2.1.0"
+	sarifSchema    = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifToolName  = "godoclint"
+	sarifToolURI   = "https://github.com/godoc-lint/godoc-lint"
+	sarifSrcRootID = "%SRCROOT%"
+	sarifLevel     = "error"
+
+	// sarifColumnKind is the unit of the region columns, which is the same as
+	// the one used by most editors and code scanning dashboards.
+	sarifColumnKind = "utf16CodeUnits"
+)
+
+// SARIFReporter writes issues in the SARIF (v2.1.0) format.
+//
+// Every rule is described by a reporting descriptor, and file locations are
+// written relative to the base directory (as the "%SRCROOT%" URI base), if
+// possible. Region columns are in UTF-16 code units, for which the files are
+// read from the disk.
+type SARIFReporter struct {
+	baseDir string
+	rules   []model.RuleMetadata
+}
+
+// NewSARIFReporter returns a new instance of the corresponding reporter.
+func NewSARIFReporter(baseDir string, rules []model.RuleMetadata) *SARIFReporter {
+	return &SARIFReporter{
+		baseDir: baseDir,
+		rules:   rules,
+	}
+}
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool               sarifTool                        `json:"tool"`
+	OriginalURIBaseIDs map[string]sarifArtifactLocation `json:"originalUriBaseIds,omitempty"`
+	Results            []sarifResult                    `json:"results"`
+	ColumnKind         string                           `json:"columnKind"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string                     `json:"name"`
+	Version        string                     `json:"version"`
+	InformationURI string                     `json:"informationUri"`
+	Rules          []sarifReportingDescriptor `json:"rules"`
+}
+
+type sarifReportingDescriptor struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+	FullDescription  sarifMessage `json:"fullDescription"`
+	HelpURI          string       `json:"helpUri,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex *int            `json:"ruleIndex,omitempty"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+	Related   []sarifLocation `json:"relatedLocations,omitempty"`
+	Fixes     []sarifFix      `json:"fixes,omitempty"`
+}
+
+type sarifLocation struct {
+	ID               *int                  `json:"id,omitempty"`
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+	Message          *sarifMessage         `json:"message,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+	EndLine     int `json:"endLine,omitempty"`
+	EndColumn   int `json:"endColumn,omitempty"`
+}
+
+type sarifFix struct {
+	Description     sarifMessage          `json:"description"`
+	ArtifactChanges []sarifArtifactChange `json:"artifactChanges"`
+}
+
+type sarifArtifactChange struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Replacements     []sarifReplacement    `json:"replacements"`
+}
+
+type sarifReplacement struct {
+	DeletedRegion   sarifRegion   `json:"deletedRegion"`
+	InsertedContent *sarifMessage `json:"insertedContent,omitempty"`
+}
+
+// Report implements the corresponding interface method.
+func (r *SARIFReporter) Report(w io.Writer, issues []model.Issue) error {
+	descriptors := make([]sarifReportingDescriptor, 0, len(r.rules))
+	ruleIndex := make(map[model.Rule]int, len(r.rules))
+	for i, m := range r.rules {
+		ruleIndex[m.Rule] = i
+		descriptors = append(descriptors, sarifReportingDescriptor{
+			ID:               string(m.Rule),
+			ShortDescription: sarifMessage{Text: m.Summary},
+			FullDescription:  sarifMessage{Text: m.Description},
+			HelpURI:          m.Rule.DocURL(),
+		})
+	}
+
+	columns := sarifColumns{}
+	results := make([]sarifResult, 0, len(issues))
+	for _, issue := range issues {
+		result := sarifResult{
+			RuleID:  string(issue.Rule),
+			Level:   sarifLevel,
+			Message: sarifMessage{Text: issue.Message},
+			Locations: []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: r.artifactLocation(issue.Pos.Filename),
+					Region:           columns.region(issue.Pos, issue.End),
+				},
+			}},
+		}
+		if ix, ok := ruleIndex[issue.Rule]; ok {
+			result.RuleIndex = &ix
+		}
+
+		for i, rel := range issue.Related {
+			result.Related = append(result.Related, sarifLocation{
+				ID: &i,
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: r.artifactLocation(rel.Pos.Filename),
+					Region:           columns.region(rel.Pos, rel.End),
+				},
+				Message: &sarifMessage{Text: rel.Message},
+			})
+		}
+
+		for _, fix := range issue.Fixes {
+			result.Fixes = append(result.Fixes, r.fix(fix, columns))
+		}
+		results = append(results, result)
+	}
+
+	run := sarifRun{
+		Tool: sarifTool{
+			Driver: sarifDriver{
+				Name:           sarifToolName,
+				Version:        version.Current.String(),
+				InformationURI: sarifToolURI,
+				Rules:          descriptors,
+			},
+		},
+		Results:    results,
+		ColumnKind: sarifColumnKind,
+	}
+	if r.baseDir != "" {
+		run.OriginalURIBaseIDs = map[string]sarifArtifactLocation{
+			sarifSrcRootID: {URI: fileURI(r.baseDir, true)},
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(sarifLog{
+		Version: sarifVersion,
+		Schema:  sarifSchema,
+		Runs:    []sarifRun{run},
+	})
+}
+
+func (r *SARIFReporter) fix(fix model.IssueFix, columns sarifColumns) sarifFix {
+	changes := make([]sarifArtifactChange, 0, 1)
+	changeIndex := make(map[string]int, 1)
+	for _, edit := range fix.Edits {
+		ix, ok := changeIndex[edit.Pos.Filename]
+		if !ok {
+			ix = len(changes)
+			changeIndex[edit.Pos.Filename] = ix
+			changes = append(changes, sarifArtifactChange{
+				ArtifactLocation: r.artifactLocation(edit.Pos.Filename),
+			})
+		}
+
+		replacement := sarifReplacement{
+			DeletedRegion: columns.region(edit.Pos, edit.End),
+		}
+		if edit.NewText != "" {
+			replacement.InsertedContent = &sarifMessage{Text: edit.NewText}
+		}
+		changes[ix].Replacements = append(changes[ix].Replacements, replacement)
+	}
+	return sarifFix{
+		Description:     sarifMessage{Text: fix.Message},
+		ArtifactChanges: changes,
+	}
+}
+
+func (r *SARIFReporter) artifactLocation(path string) sarifArtifactLocation {
+	if rel, ok := relPath(r.baseDir, path); ok {
+		return sarifArtifactLocation{
+			URI:       (&url.URL{Path: rel}).EscapedPath(),
+			URIBaseID: sarifSrcRootID,
+		}
+	}
+	return sarifArtifactLocation{URI: fileURI(path, false)}
+}
+
+// sarifColumns converts the byte columns of token positions to UTF-16 code
+// units, based on the content of the files, which are read once. It maps file
+// paths to their contents.
+type sarifColumns map[string][]byte
+
+func (c sarifColumns) region(pos, end token.Position) sarifRegion {
+	region := sarifRegion{
+		StartLine:   pos.Line,
+		StartColumn: c.column(pos),
+	}
+	if end.IsValid() {
+		region.EndLine = end.Line
+		region.EndColumn = c.column(end)
+	}
+	return region
+}
+
+// column returns the 1-based column of the given position in UTF-16 code units.
+// If the file cannot be read, or it does not match the position, the byte
+// column is returned as is.
+func (c sarifColumns) column(pos token.Position) int {
+	if pos.Column < 1 {
+		return pos.Column
+	}
+	content, ok := c[pos.Filename]
+	if !ok {
+		content, _ = os.ReadFile(pos.Filename)
+		c[pos.Filename] = content
+	}
+	lineStart := pos.Offset - (pos.Column - 1)
+	if lineStart < 0 || pos.Offset > len(content) || (lineStart > 0 && content[lineStart-1] != '\n') {
+		return pos.Column
+	}
+	return util.UTF16Len(content[lineStart:pos.Offset]) + 1
+}
+
+// fileURI returns the "file://" URI of the given path. If dir is true, the URI
+// will end with a slash, as required by SARIF for base URIs.
+func fileURI(path string, dir bool) string {
+	p := filepath.ToSlash(path)
+	if !strings.HasPrefix(p, "/") {
+		// Windows paths (e.g., C:/foo) need a leading slash.
+		p = "/" + p
+	}
+	if dir && !strings.HasSuffix(p, "/") {
+		p += "/"
+	}
+	return (&url.URL{Scheme: "file", Path: p}).String()
+}
@@ -0,0 +1,187 @@
+package report_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/godoc-lint/godoc-lint/pkg/check"
+	"github.com/godoc-lint/godoc-lint/pkg/model"
+	"github.com/godoc-lint/godoc-lint/pkg/report"
+)
+
+var _ report.Reporter = &report.SARIFReporter{}
+
+func TestSARIFReporter(t *testing.T) {
+	require := require.New(t)
+
+	baseDir := t.TempDir()
+	file := filepath.Join(baseDir, "foo", "foo.go")
+
+	issues := []model.Issue{
+		{
+			Rule:    model.MaxLenRule,
+			PkgPath: "example.com/foo",
+			Pos:     token.Position{Filename: file, Offset: 10, Line: 2, Column: 1},
+			End:     token.Position{Filename: file, Offset: 100, Line: 2, Column: 91},
+			Message: "godoc line is too long (90 > 77)",
+		},
+		{
+			Rule:    model.PkgDocRule,
+			PkgPath: "example.com/foo",
+			Pos:     token.Position{Filename: file, Offset: 0, Line: 1, Column: 1},
+			Message: `package godoc should start with "Package foo "`,
+			Fixes: []model.IssueFix{{
+				Message: "fix package godoc",
+				Edits: []model.IssueTextEdit{{
+					Pos:     token.Position{Filename: file, Offset: 3, Line: 1, Column: 4},
+					End:     token.Position{Filename: file, Offset: 7, Line: 1, Column: 8},
+					NewText: "Package foo",
+				}},
+			}},
+			Related: []model.IssueRelated{{
+				Pos:     token.Position{Filename: filepath.Join(baseDir, "foo", "bar.go"), Offset: 0, Line: 1, Column: 1},
+				End:     token.Position{Filename: filepath.Join(baseDir, "foo", "bar.go"), Offset: 20, Line: 1, Column: 21},
+				Message: "other package godoc",
+			}},
+		},
+	}
+
+	buf := bytes.NewBuffer(nil)
+	metadata := check.NewPopulatedRegistry().ListRuleMetadata()
+	r := report.NewSARIFReporter(baseDir, metadata)
+	require.NoError(r.Report(buf, issues))
+
+	var got map[string]any
+	require.NoError(json.Unmarshal(buf.Bytes(), &got))
+	require.Equal("2.1.0", got["version"])
+
+	run := got["runs"].([]any)[0].(map[string]any)
+	require.Equal("utf16CodeUnits", run["columnKind"])
+	driver := run["tool"].(map[string]any)["driver"].(map[string]any)
+	require.Equal("godoclint", driver["name"])
+
+	rules := driver["rules"].([]any)
+	require.Len(rules, len(metadata))
+	for i, m := range metadata {
+		descriptor := rules[i].(map[string]any)
+		require.Equal(string(m.Rule), descriptor["id"])
+		require.Equal(m.Summary, descriptor["shortDescription"].(map[string]any)["text"])
+		require.Equal(m.Description, descriptor["fullDescription"].(map[string]any)["text"])
+		require.Equal(m.Rule.DocURL(), descriptor["helpUri"])
+	}
+
+	results := run["results"].([]any)
+	require.Len(results, 2)
+
+	first := results[0].(map[string]any)
+	require.Equal("max-len", first["ruleId"])
+	require.Equal("godoc line is too long (90 > 77)", first["message"].(map[string]any)["text"])
+	require.NotContains(first, "fixes")
+	require.NotContains(first, "relatedLocations")
+	location := first["locations"].([]any)[0].(map[string]any)["physicalLocation"].(map[string]any)
+	require.Equal(map[string]any{
+		"uri":       "foo/foo.go",
+		"uriBaseId": "%SRCROOT%",
+	}, location["artifactLocation"])
+	require.Equal(map[string]any{
+		"startLine":   float64(2),
+		"startColumn": float64(1),
+		"endLine":     float64(2),
+		"endColumn":   float64(91),
+	}, location["region"])
+
+	second := results[1].(map[string]any)
+	require.Equal("pkg-doc", second["ruleId"])
+	fixes := second["fixes"].([]any)
+	require.Len(fixes, 1)
+	require.Equal(map[string]any{
+		"description": map[string]any{"text": "fix package godoc"},
+		"artifactChanges": []any{map[string]any{
+			"artifactLocation": map[string]any{
+				"uri":       "foo/foo.go",
+				"uriBaseId": "%SRCROOT%",
+			},
+			"replacements": []any{map[string]any{
+				"deletedRegion": map[string]any{
+					"startLine":   float64(1),
+					"startColumn": float64(4),
+					"endLine":     float64(1),
+					"endColumn":   float64(8),
+				},
+				"insertedContent": map[string]any{"text": "Package foo"},
+			}},
+		}},
+	}, fixes[0])
+	require.Equal([]any{map[string]any{
+		"id": float64(0),
+		"physicalLocation": map[string]any{
+			"artifactLocation": map[string]any{
+				"uri":       "foo/bar.go",
+				"uriBaseId": "%SRCROOT%",
+			},
+			"region": map[string]any{
+				"startLine":   float64(1),
+				"startColumn": float64(1),
+				"endLine":     float64(1),
+				"endColumn":   float64(21),
+			},
+		},
+		"message": map[string]any{"text": "other package godoc"},
+	}}, second["relatedLocations"])
+}
+
+func TestSARIFReporterUTF16Columns(t *testing.T) {
+	require := require.New(t)
+
+	baseDir := t.TempDir()
+	file := filepath.Join(baseDir, "foo.go")
+	content := "package foo\n\n// Foo is a 😀 symbol, café.\nconst Foo = 0\n"
+	require.NoError(os.WriteFile(file, []byte(content), 0o644))
+
+	// The issue spans from "symbol" to the end of the line, in bytes.
+	lineStart := strings.Index(content, "// Foo")
+	start := strings.Index(content, "symbol")
+	end := strings.Index(content, "\nconst")
+	issues := []model.Issue{{
+		Rule:    model.MaxLenRule,
+		Pos:     token.Position{Filename: file, Offset: start, Line: 3, Column: start - lineStart + 1},
+		End:     token.Position{Filename: file, Offset: end, Line: 3, Column: end - lineStart + 1},
+		Message: "foo",
+	}}
+
+	buf := bytes.NewBuffer(nil)
+	require.NoError(report.NewSARIFReporter(baseDir, nil).Report(buf, issues))
+
+	var got struct {
+		Runs []struct {
+			Results []struct {
+				Locations []struct {
+					PhysicalLocation struct {
+						Region map[string]int `json:"region"`
+					} `json:"physicalLocation"`
+				} `json:"locations"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	require.NoError(json.Unmarshal(buf.Bytes(), &got))
+	// The emoji takes two UTF-16 code units (rather than four bytes), and the
+	// accented letter takes one (rather than two bytes).
+	require.Equal(map[string]int{
+		"startLine":   3,
+		"startColumn": 16,
+		"endLine":     3,
+		"endColumn":   29,
+	}, got.Runs[0].Results[0].Locations[0].PhysicalLocation.Region)
+}
+
+func TestNewReporterUnknownFormat(t *testing.T) {
+	_, err := report.NewReporter("foo", report.Options{})
+	require.Error(t, err)
+}
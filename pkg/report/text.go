@@ -0,0 +1,44 @@
+package report
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/godoc-lint/godoc-lint/pkg/model"
+)
+
+// TextReporter writes issues as plain text lines, in the same format that the
+// go/analysis drivers use (i.e., "file:line:col: message").
+//
+// Optionally, messages can be prefixed with the rule name (i.e.,
+// "file:line:col: [rule] message"). Related locations, if any, are written on
+// the following lines, with their messages indented by a tab.
+type TextReporter struct {
+	showRule bool
+}
+
+// NewTextReporter returns a new instance of the corresponding reporter.
+func NewTextReporter(showRule bool) *TextReporter {
+	return &TextReporter{
+		showRule: showRule,
+	}
+}
+
+// Report implements the corresponding interface method.
+func (r *TextReporter) Report(w io.Writer, issues []model.Issue) error {
+	for _, issue := range issues {
+		message := issue.Message
+		if r.showRule && issue.Rule != "" {
+			message = "[" + string(issue.Rule) + "] " + message
+		}
+		if _, err := fmt.Fprintf(w, "%s: %s\n", issue.Pos, message); err != nil {
+			return err
+		}
+		for _, rel := range issue.Related {
+			if _, err := fmt.Fprintf(w, "%s: \t%s\n", rel.Pos, rel.Message); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
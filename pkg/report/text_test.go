@@ -0,0 +1,67 @@
+package report_test
+
+import (
+	"bytes"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/godoc-lint/godoc-lint/pkg/model"
+	"github.com/godoc-lint/godoc-lint/pkg/report"
+)
+
+var _ report.Reporter = &report.TextReporter{}
+
+func TestTextReporter(t *testing.T) {
+	issues := []model.Issue{
+		{
+			Rule:    model.MaxLenRule,
+			Pos:     token.Position{Filename: "foo.go", Line: 2, Column: 1},
+			Message: "godoc line is too long (90 > 77)",
+		},
+		{
+			Rule:    model.RequireDocRule,
+			Pos:     token.Position{Filename: "bar.go", Line: 5, Column: 6},
+			Message: `symbol should have a godoc ("Bar")`,
+		},
+		{
+			Rule:    model.SinglePkgDocRule,
+			Pos:     token.Position{Filename: "baz.go", Line: 1, Column: 1},
+			Message: `package has more than one godoc ("baz")`,
+			Related: []model.IssueRelated{{
+				Pos:     token.Position{Filename: "qux.go", Line: 1, Column: 1},
+				Message: "other package godoc",
+			}},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		showRule bool
+		expected string
+	}{{
+		name: "default",
+		expected: `foo.go:2:1: godoc line is too long (90 > 77)
+bar.go:5:6: symbol should have a godoc ("Bar")
+baz.go:1:1: package has more than one godoc ("baz")
+qux.go:1:1: 	other package godoc
+`,
+	}, {
+		name:     "show rule",
+		showRule: true,
+		expected: `foo.go:2:1: [max-len] godoc line is too long (90 > 77)
+bar.go:5:6: [require-doc] symbol should have a godoc ("Bar")
+baz.go:1:1: [single-pkg-doc] package has more than one godoc ("baz")
+qux.go:1:1: 	other package godoc
+`,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := bytes.NewBuffer(nil)
+			require.NoError(t, report.NewTextReporter(tt.showRule).Report(buf, issues))
+			require.Equal(t, tt.expected, buf.String())
+		})
+	}
+}
@@ -46,7 +46,7 @@ func AnalysisApplicableFiles(actx *model.AnalysisContext, includeTests bool, rul
 				continue
 			}
 
-			if !actx.Config.IsPathApplicable(ft.Name()) {
+			if !actx.Config.IsFileApplicable(actx.Pass.Pkg.Path(), ft.Name()) {
 				continue
 			}
 
@@ -0,0 +1,84 @@
+package util
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// CommentLine represents a line of a comment group, excluding the comment
+// markers (i.e., "//", "/*" and "*/").
+type CommentLine struct {
+	// Text is the line text.
+	Text string
+
+	// Pos is the position of the first character of the text.
+	Pos token.Pos
+}
+
+// CommentLines returns the lines of the given comment group, in order.
+func CommentLines(cg *ast.CommentGroup) []CommentLine {
+	lines := make([]CommentLine, 0, len(cg.List))
+	for _, c := range cg.List {
+		if text, ok := strings.CutPrefix(c.Text, "//"); ok {
+			lines = append(lines, CommentLine{Text: text, Pos: c.Slash + 2})
+			continue
+		}
+
+		text := strings.TrimSuffix(strings.TrimPrefix(c.Text, "/*"), "*/")
+		pos := c.Slash + 2
+		for line := range strings.SplitSeq(text, "\n") {
+			lines = append(lines, CommentLine{Text: line, Pos: pos})
+			pos += token.Pos(len(line) + 1)
+		}
+	}
+	return lines
+}
+
+// FindCommentLinePrefix returns the first line of the given comment group that
+// starts with the given prefix, ignoring leading whitespace, along with the
+// position of the prefix.
+func FindCommentLinePrefix(cg *ast.CommentGroup, prefix string) (CommentLine, token.Pos, bool) {
+	for _, line := range CommentLines(cg) {
+		trimmed := strings.TrimLeft(line.Text, " \t")
+		if strings.HasPrefix(trimmed, prefix) {
+			return line, line.Pos + token.Pos(len(line.Text)-len(trimmed)), true
+		}
+	}
+	return CommentLine{}, token.NoPos, false
+}
+
+// CommentLinePrefixRange returns the range of the given prefix, as found by
+// [FindCommentLinePrefix]. If the prefix is not found, the range of the whole
+// comment group is returned, so the result is always safe to report on.
+func CommentLinePrefixRange(cg *ast.CommentGroup, prefix string) analysis.Range {
+	if prefix == "" {
+		return cg
+	}
+	if _, pos, ok := FindCommentLinePrefix(cg, prefix); ok {
+		return NewRange(pos, pos+token.Pos(len(prefix)))
+	}
+	return cg
+}
+
+// NewRange returns a range between the given positions.
+func NewRange(pos, end token.Pos) analysis.Range {
+	return textRange{pos: pos, end: end}
+}
+
+// textRange implements [analysis.Range].
+type textRange struct {
+	pos, end token.Pos
+}
+
+// Pos implements the corresponding interface method.
+func (r textRange) Pos() token.Pos {
+	return r.pos
+}
+
+// End implements the corresponding interface method.
+func (r textRange) End() token.Pos {
+	return r.end
+}
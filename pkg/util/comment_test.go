@@ -0,0 +1,81 @@
+package util_test
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/godoc-lint/godoc-lint/pkg/util"
+)
+
+func TestCommentLines(t *testing.T) {
+	const src = `package foo
+
+// Foo is a function.
+//
+//	code
+func Foo() {}
+
+/* Bar is a function.
+   Deprecated: use Foo */
+func Bar() {}
+`
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "foo.go", src, parser.ParseComments)
+	require.NoError(t, err)
+	require.Len(t, f.Comments, 2)
+
+	offset := func(pos token.Pos) int {
+		return fset.Position(pos).Offset
+	}
+
+	tests := []struct {
+		name     string
+		index    int
+		expected []string
+	}{{
+		name:     "line comments",
+		index:    0,
+		expected: []string{" Foo is a function.", "", "\tcode"},
+	}, {
+		name:     "block comment",
+		index:    1,
+		expected: []string{" Bar is a function.", "   Deprecated: use Foo "},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := util.CommentLines(f.Comments[tt.index])
+			texts := make([]string, 0, len(lines))
+			for _, line := range lines {
+				texts = append(texts, line.Text)
+				require.True(t, strings.HasPrefix(src[offset(line.Pos):], line.Text), "line %q should start at its position", line.Text)
+			}
+			require.Equal(t, tt.expected, texts)
+		})
+	}
+
+	t.Run("prefix", func(t *testing.T) {
+		line, pos, ok := util.FindCommentLinePrefix(f.Comments[1], "Deprecated:")
+		require.True(t, ok)
+		require.Equal(t, "   Deprecated: use Foo ", line.Text)
+		require.True(t, strings.HasPrefix(src[offset(pos):], "Deprecated: use Foo"))
+
+		rng := util.CommentLinePrefixRange(f.Comments[1], "Deprecated:")
+		require.Equal(t, "Deprecated:", src[offset(rng.Pos()):offset(rng.End())])
+
+		_, _, ok = util.FindCommentLinePrefix(f.Comments[0], "code")
+		require.True(t, ok, "leading whitespace should be ignored")
+
+		_, _, ok = util.FindCommentLinePrefix(f.Comments[0], "Bar")
+		require.False(t, ok)
+
+		rng = util.CommentLinePrefixRange(f.Comments[0], "Bar")
+		require.Equal(t, f.Comments[0].Pos(), rng.Pos(), "should fall back to the comment group")
+		require.Equal(t, f.Comments[0].End(), rng.End(), "should fall back to the comment group")
+	})
+}
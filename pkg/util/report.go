@@ -0,0 +1,48 @@
+package util
+
+import (
+	"fmt"
+	"go/token"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/godoc-lint/godoc-lint/pkg/model"
+)
+
+// Reportf is a helper function to report a diagnostic at the given position,
+// while tagging it with the given rule name (as the diagnostic category) and
+// the rule documentation URL.
+func Reportf(pass *analysis.Pass, rule model.Rule, pos token.Pos, format string, args ...any) {
+	pass.Report(analysis.Diagnostic{
+		Pos:      pos,
+		Category: string(rule),
+		URL:      rule.DocURL(),
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// ReportRangef is a helper function to report a diagnostic over the given
+// range, while tagging it with the given rule name (as the diagnostic
+// category) and the rule documentation URL.
+func ReportRangef(pass *analysis.Pass, rule model.Rule, rng analysis.Range, format string, args ...any) {
+	pass.Report(analysis.Diagnostic{
+		Pos:      rng.Pos(),
+		End:      rng.End(),
+		Category: string(rule),
+		URL:      rule.DocURL(),
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// ReportRelatedRangef is the same as [ReportRangef], along with the given
+// related information (e.g., other locations involved in the issue).
+func ReportRelatedRangef(pass *analysis.Pass, rule model.Rule, rng analysis.Range, related []analysis.RelatedInformation, format string, args ...any) {
+	pass.Report(analysis.Diagnostic{
+		Pos:      rng.Pos(),
+		End:      rng.End(),
+		Category: string(rule),
+		URL:      rule.DocURL(),
+		Message:  fmt.Sprintf(format, args...),
+		Related:  related,
+	})
+}
@@ -0,0 +1,128 @@
+package util
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+)
+
+// FindSymbolAt returns the name of the top-level symbol declared in the given
+// file whose declaration (including its godoc) encloses the given position.
+//
+// Methods are named as "Recv.Name", where "Recv" is the base type name of the
+// receiver. For grouped const/var/type declarations, the names declared by
+// the enclosing spec are returned (comma-separated). If the position is inside
+// the parent godoc of a grouped declaration, the names of all specs are
+// returned.
+//
+// An empty string is returned if the position is not inside a symbol
+// declaration (e.g., it is in the package godoc).
+func FindSymbolAt(f *ast.File, pos token.Pos) string {
+	for _, d := range f.Decls {
+		if !isInNodeWithDoc(d, declDoc(d), pos) {
+			continue
+		}
+
+		switch dt := d.(type) {
+		case *ast.FuncDecl:
+			if dt.Recv != nil && len(dt.Recv.List) > 0 {
+				if recv := MethodRecvBaseTypeName(dt.Recv.List[0].Type); recv != "" {
+					return recv + "." + dt.Name.Name
+				}
+			}
+			return dt.Name.Name
+		case *ast.GenDecl:
+			all := make([]string, 0, len(dt.Specs))
+			for _, s := range dt.Specs {
+				names := specNames(s)
+				if isInNodeWithDoc(s, specDoc(s), pos) {
+					return strings.Join(names, ",")
+				}
+				all = append(all, names...)
+			}
+			return strings.Join(all, ",")
+		}
+		return ""
+	}
+	return ""
+}
+
+// MethodRecvBaseTypeName returns the base type name of the given method
+// receiver type expression. For example, it returns "Foo" for all of "Foo",
+// "*Foo", "Foo[T]", and "*Foo[T]".
+//
+// An empty string is returned if the expression is not supported.
+func MethodRecvBaseTypeName(expr ast.Expr) string {
+	switch tt := expr.(type) {
+	case *ast.Ident:
+		return tt.Name
+	case *ast.StarExpr:
+		return MethodRecvBaseTypeName(tt.X)
+	case *ast.IndexExpr:
+		return MethodRecvBaseTypeName(tt.X)
+	case *ast.IndexListExpr:
+		return MethodRecvBaseTypeName(tt.X)
+	}
+	return ""
+}
+
+func isInNodeWithDoc(n ast.Node, doc *ast.CommentGroup, pos token.Pos) bool {
+	start := n.Pos()
+	if doc != nil && doc.Pos() < start {
+		start = doc.Pos()
+	}
+	end := n.End()
+	if c := specComment(n); c != nil && c.End() > end {
+		end = c.End()
+	}
+	return start <= pos && pos <= end
+}
+
+func declDoc(d ast.Decl) *ast.CommentGroup {
+	switch dt := d.(type) {
+	case *ast.FuncDecl:
+		return dt.Doc
+	case *ast.GenDecl:
+		return dt.Doc
+	}
+	return nil
+}
+
+func specDoc(s ast.Spec) *ast.CommentGroup {
+	switch st := s.(type) {
+	case *ast.ValueSpec:
+		return st.Doc
+	case *ast.TypeSpec:
+		return st.Doc
+	}
+	return nil
+}
+
+func specComment(n ast.Node) *ast.CommentGroup {
+	switch st := n.(type) {
+	case *ast.ValueSpec:
+		return st.Comment
+	case *ast.TypeSpec:
+		return st.Comment
+	case *ast.GenDecl:
+		if st.Lparen == token.NoPos && len(st.Specs) == 1 {
+			// Single-spec declarations may have a trailing comment.
+			return specComment(st.Specs[0])
+		}
+	}
+	return nil
+}
+
+func specNames(s ast.Spec) []string {
+	switch st := s.(type) {
+	case *ast.ValueSpec:
+		names := make([]string, 0, len(st.Names))
+		for _, n := range st.Names {
+			names = append(names, n.Name)
+		}
+		return names
+	case *ast.TypeSpec:
+		return []string{st.Name.Name}
+	}
+	return nil
+}
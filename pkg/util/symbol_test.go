@@ -0,0 +1,90 @@
+package util_test
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/godoc-lint/godoc-lint/pkg/util"
+)
+
+func TestFindSymbolAt(t *testing.T) {
+	const src = `// Package foo is a package.
+package foo
+
+// Foo is a function.
+func Foo() {}
+
+// Bar is a method.
+func (*Baz[T]) Bar() {}
+
+// Qux is a constant.
+const Qux = 0 // trailing
+
+// parent godoc
+const (
+	// A is a constant.
+	A = 0
+
+	B, C = 0, 0 // trailing
+)
+
+// T is a type.
+type T int
+`
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "foo.go", src, parser.ParseComments)
+	require.NoError(t, err)
+
+	at := func(substr string) token.Pos {
+		ix := strings.Index(src, substr)
+		require.NotEqual(t, -1, ix, "substring not found: %q", substr)
+		return f.FileStart + token.Pos(ix)
+	}
+
+	tests := []struct {
+		at       string
+		expected string
+	}{
+		{"Package foo", ""},
+		{"package foo", ""},
+		{"Foo is", "Foo"},
+		{"Foo()", "Foo"},
+		{"Bar is", "Baz.Bar"},
+		{"Qux is", "Qux"},
+		{"trailing\n\n//", "Qux"},
+		{"parent godoc", "A,B,C"},
+		{"A is", "A"},
+		{"B, C", "B,C"},
+		{"trailing\n)", "B,C"},
+		{"T is", "T"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, util.FindSymbolAt(f, at(tt.at)), "at: %q", tt.at)
+	}
+}
+
+func TestMethodRecvBaseTypeName(t *testing.T) {
+	tests := []struct {
+		expr     string
+		expected string
+	}{
+		{"Foo", "Foo"},
+		{"*Foo", "Foo"},
+		{"Foo[T]", "Foo"},
+		{"*Foo[T, U]", "Foo"},
+		{"[]Foo", ""},
+	}
+
+	for _, tt := range tests {
+		expr, err := parser.ParseExpr(tt.expr)
+		require.NoError(t, err)
+		assert.Equal(t, tt.expected, util.MethodRecvBaseTypeName(expr), "expr: %q", tt.expr)
+	}
+}
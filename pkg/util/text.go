@@ -0,0 +1,19 @@
+package util
+
+import (
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// UTF16Len returns the length of the given UTF-8 text in UTF-16 code units, as
+// used for columns by editors (e.g., via LSP) and SARIF consumers. Invalid
+// bytes are counted as one code unit each.
+func UTF16Len(b []byte) int {
+	n := 0
+	for len(b) > 0 {
+		r, size := utf8.DecodeRune(b)
+		n += max(utf16.RuneLen(r), 1)
+		b = b[size:]
+	}
+	return n
+}
@@ -0,0 +1,30 @@
+package util_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/godoc-lint/godoc-lint/pkg/util"
+)
+
+func TestUTF16Len(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected int
+	}{
+		{name: "empty", text: "", expected: 0},
+		{name: "ascii", text: "foo", expected: 3},
+		{name: "two-byte", text: "é", expected: 1},
+		{name: "three-byte", text: "世界", expected: 2},
+		{name: "surrogate pair", text: "😀", expected: 2},
+		{name: "invalid", text: "\xff\xfe", expected: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, util.UTF16Len([]byte(tt.text)))
+		})
+	}
+}
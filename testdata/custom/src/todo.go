@@ -0,0 +1,13 @@
+// Package src is a test package for custom rules.
+package src
+
+// Foo is not done yet. TODO  // want `godoc should not contain TODO`
+const Foo = 0
+
+// Bar is not done yet. TODO
+//
+//godoclint:disable no-todo
+const Bar = 0
+
+// Baz is done.
+const Baz = 0
@@ -0,0 +1,31 @@
+// Package basic is a package to test dumping.
+//
+// # Heading
+//
+// See [fmt.Println], [the spec], or https://go.dev.
+//
+//   - item one
+//   - item two
+//
+// Code:
+//
+//	basic.Foo()
+//
+// [the spec]: https://go.dev/ref/spec
+package basic
+
+//godoclint:disable max-len
+
+// Foo is a function.
+//
+//godoclint:disable start-with-name
+func Foo() {}
+
+const (
+	A, B = 0, 1 // A and B are constants.
+)
+
+// T is a type.
+type T int
+
+func (T) m() {}
@@ -34,4 +34,15 @@ var (
 	MultiBar = 0
 )
 
+// Raw holds a directive-like text, which is not a directive.
+var Raw = `
+//godoclint:disable require-doc
+
+`
+
+func foo() {
+//godoclint:disable deprecated
+
+}
+
 //godoclint:disable max-len
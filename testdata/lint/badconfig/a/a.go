@@ -0,0 +1,2 @@
+// Package a is a test package, with an invalid config file.
+package a
@@ -0,0 +1,2 @@
+// Package b is a test package, with an invalid config file.
+package b
@@ -0,0 +1,8 @@
+// Package basic is a test package for the lint library API.
+package basic
+
+// This godoc does not start with the symbol name.
+const Foo = 0
+
+// Bar starts with the symbol name.
+const Bar = 0
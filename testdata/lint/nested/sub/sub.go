@@ -0,0 +1,5 @@
+// Package sub is a test package, with its own config file.
+package sub
+
+// This godoc does not start with the symbol name.
+const Foo = 0
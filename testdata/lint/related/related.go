@@ -0,0 +1,11 @@
+// Package related is a test package for the related information of issues.
+package related
+
+// Foo is a type.
+type Foo int
+
+// This godoc does not start with the method name.
+func (
+	Foo,
+) Bar() {
+}
@@ -0,0 +1,5 @@
+// Package basic is a test package for the hermetic mode.
+package basic
+
+// This godoc does not start with the symbol name.  // want `godoc should start with symbol name \("Foo"\)`
+const Foo = 0
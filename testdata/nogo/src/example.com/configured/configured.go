@@ -0,0 +1,8 @@
+// Package configured is a test package, with a config.
+package configured
+
+// Foo has a long godoc line.  // want `godoc line is too long \(72 > 60\)`
+const Foo = 0
+
+// This godoc does not start with the symbol name.
+const Bar = 0
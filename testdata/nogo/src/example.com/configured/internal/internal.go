@@ -0,0 +1,5 @@
+// Package internal is a test package, which is excluded by import path.
+package internal
+
+// This godoc does not start with the symbol name, but the package is excluded.
+const Foo = 0
@@ -0,0 +1,8 @@
+// Package foo is a test package for the plugin.
+package foo
+
+// Foo has a long godoc line.  // want `godoc line is too long \(72 > 50\)`
+const Foo = 0
+
+// This godoc does not start with the symbol name.
+const Bar = 0
@@ -0,0 +1,31 @@
+// Package basic is a package to test rendering.
+//
+// # Heading
+//
+// See [fmt.Println].
+package basic
+
+// Foo is a function.
+func Foo() {
+	_ = 0
+}
+
+func bar() {}
+
+// Group of constants.
+const (
+	// A is a constant.
+	A = 0
+	B = 1 // B is a constant.
+)
+
+const C = 0 // C is a constant.
+
+// T is a type.
+type T struct {
+	// F is a field.
+	F int
+}
+
+// M is a method.
+func (*T) M() {}
@@ -2,7 +2,7 @@
 
 // (BG: bad godoc)
 
-// some godoc // want `deprecation note should be formatted as "Deprecated: "`
+// some godoc // want +2 `deprecation note should be formatted as "Deprecated: "`
 //
 // deprecated: do not use
 //
@@ -14,14 +14,14 @@ package deprecated
 // godoc
 const FooBG = 0
 
-// some godoc // want `deprecation note should be formatted as "Deprecated: "`
+// some godoc // want +2 `deprecation note should be formatted as "Deprecated: "`
 //
 // deprecated:do not use
 const (
 	BarBG = 0
 )
 
-// some godoc // want `deprecation note should be formatted as "Deprecated: "`
+// some godoc // want +2 `deprecation note should be formatted as "Deprecated: "`
 //
 // deprecated:: do not use
 const (
@@ -29,49 +29,49 @@ const (
 	BazBG = 0
 )
 
-// some godoc // want `deprecation note should be formatted as "Deprecated: "`
+// some godoc // want +2 `deprecation note should be formatted as "Deprecated: "`
 //
 // DEPRECATED: do not use
 const (
-	// some godoc // want `deprecation note should be formatted as "Deprecated: "`
+	// some godoc // want +2 `deprecation note should be formatted as "Deprecated: "`
 	//
 	// deprecated:?do not use
 	YoBG = 0
 )
 
-// some godoc // want `deprecation note should be formatted as "Deprecated: "`
+// some godoc // want +2 `deprecation note should be formatted as "Deprecated: "`
 //
 // DEPRECATED: do not use
 const (
-	// some godoc // want `deprecation note should be formatted as "Deprecated: "`
+	// some godoc // want +2 `deprecation note should be formatted as "Deprecated: "`
 	//
 	// DEPRECATED: do not use
 	YoloBG = 0
 )
 
-// some godoc // want `deprecation note should be formatted as "Deprecated: "`
+// some godoc // want +2 `deprecation note should be formatted as "Deprecated: "`
 //
 // DEPRECATED:do not use
 type AlphaBG int
 
-// some godoc // want `deprecation note should be formatted as "Deprecated: "`
+// some godoc // want +2 `deprecation note should be formatted as "Deprecated: "`
 //
 // Deprecated:
 // do not use
 type BravoBG int
 
-// some godoc // want `deprecation note should be formatted as "Deprecated: "`
+// some godoc // want +2 `deprecation note should be formatted as "Deprecated: "`
 //
 // Deprecated:
 type CharlieBG int
 
-// some godoc // want `deprecation note should be formatted as "Deprecated: "`
+// some godoc // want +2 `deprecation note should be formatted as "Deprecated: "`
 //
 // DEPRECATED:
 // do not use
 type DeltaBG int
 
-// some godoc // want `deprecation note should be formatted as "Deprecated: "`
+// some godoc // want +2 `deprecation note should be formatted as "Deprecated: "`
 //
 // DEPRECATED:
 type EchoBG int
@@ -88,7 +88,7 @@ type GolfBG int
 // Deprecated://foo:bar // want `deprecation note should be formatted as "Deprecated: "`
 type HotelBG int
 
-// some godoc // want `deprecation note should be formatted as "Deprecated: "`
+// some godoc // want +2 `deprecation note should be formatted as "Deprecated: "`
 //
 // DePREcatED: do not use
 type IndiaBG int
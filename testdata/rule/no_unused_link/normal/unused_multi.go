@@ -1,36 +1,42 @@
 // some header
 
-// godoc with multiple unused links // want `godoc has unused link \("link1"\)` `godoc has unused link \("link2"\)`
+// godoc with multiple unused links // want +3 `godoc has unused link \("link1"\)`
+// that are not referenced // want +3 `godoc has unused link \("link2"\)`
 //
 // [link1]: https://foo.com
 // [link2]: https://foo.com
 package no_unused_link
 
-// godoc with multiple unused links // want `godoc has unused link \("link1"\)` `godoc has unused link \("link2"\)`
+// godoc with multiple unused links // want +3 `godoc has unused link \("link1"\)`
+// that are not referenced // want +3 `godoc has unused link \("link2"\)`
 //
 // [link1]: https://foo.com
 // [link2]: https://foo.com
 const MultipleUnusedLinkConst = 0
 
-// godoc with multiple unused links // want `godoc has unused link \("link1"\)` `godoc has unused link \("link2"\)`
+// godoc with multiple unused links // want +3 `godoc has unused link \("link1"\)`
+// that are not referenced // want +3 `godoc has unused link \("link2"\)`
 //
 // [link1]: https://foo.com
 // [link2]: https://foo.com
 const (
-	// godoc with multiple unused links // want `godoc has unused link \("link1"\)` `godoc has unused link \("link2"\)`
+	// godoc with multiple unused links // want +3 `godoc has unused link \("link1"\)`
+	// that are not referenced // want +3 `godoc has unused link \("link2"\)`
 	//
 	// [link1]: https://foo.com
 	// [link2]: https://foo.com
 	MultiMultipleUnusedLinkConst = 0
 )
 
-// godoc with multiple unused links // want `godoc has unused link \("link1"\)` `godoc has unused link \("link2"\)`
+// godoc with multiple unused links // want +3 `godoc has unused link \("link1"\)`
+// that are not referenced // want +3 `godoc has unused link \("link2"\)`
 //
 // [link1]: https://foo.com
 // [link2]: https://foo.com
 type TMultipleUnusedLink int
 
-// godoc with multiple unused links // want `godoc has unused link \("link1"\)` `godoc has unused link \("link2"\)`
+// godoc with multiple unused links // want +3 `godoc has unused link \("link1"\)`
+// that are not referenced // want +3 `godoc has unused link \("link2"\)`
 //
 // [link1]: https://foo.com
 // [link2]: https://foo.com
@@ -38,7 +44,8 @@ type (
 	TMultiMultipleUnusedLink int
 )
 
-// godoc with multiple unused links // want `godoc has unused link \("link1"\)` `godoc has unused link \("link2"\)`
+// godoc with multiple unused links // want +3 `godoc has unused link \("link1"\)`
+// that are not referenced // want +3 `godoc has unused link \("link2"\)`
 //
 // [link1]: https://foo.com
 // [link2]: https://foo.com
@@ -6,16 +6,16 @@ package normal
 // godoc with potential doclink to encoding/json.Encoder. // want `text "encoding/json\.Encoder" should be replaced with "\[encoding/json\.Encoder\]" to link to stdlib type`
 const AlphaBG = 0
 
-// godoc with potential doclink to encoding/json.Encoder and encoding/json.Encoder. // want `text "encoding/json\.Encoder" should be replaced with "\[encoding/json\.Encoder\]" to link to stdlib type \(2 instances\)`
+// godoc with potential doclink to encoding/json.Encoder and encoding/json.Encoder. // want `text "encoding/json\.Encoder" should be replaced with "\[encoding/json\.Encoder\]" to link to stdlib type` `text "encoding/json\.Encoder" should be replaced with "\[encoding/json\.Encoder\]" to link to stdlib type`
 const BravoBG = 0
 
-// godoc with potential doclink to encoding/json.Encoder and *encoding/json.Encoder. // want `text "encoding/json\.Encoder" should be replaced with "\[encoding/json\.Encoder\]" to link to stdlib type \(2 instances\)`
+// godoc with potential doclink to encoding/json.Encoder and *encoding/json.Encoder. // want `text "encoding/json\.Encoder" should be replaced with "\[encoding/json\.Encoder\]" to link to stdlib type` `text "encoding/json\.Encoder" should be replaced with "\[encoding/json\.Encoder\]" to link to stdlib type`
 const CharlieBG = 0
 
 // godoc with doclink to [encoding/json.Encoder] and potential doclink to bytes.Buffer. // want `text "bytes\.Buffer" should be replaced with "\[bytes\.Buffer\]" to link to stdlib type`
 const DeltaBG = 0
 
-// godoc with doclink to [encoding/json.Encoder] and potential doclink to bytes.Buffer and *bytes.Buffer. // want `text "bytes\.Buffer" should be replaced with "\[bytes\.Buffer\]" to link to stdlib type \(2 instances\)`
+// godoc with doclink to [encoding/json.Encoder] and potential doclink to bytes.Buffer and *bytes.Buffer. // want `text "bytes\.Buffer" should be replaced with "\[bytes\.Buffer\]" to link to stdlib type` `text "bytes\.Buffer" should be replaced with "\[bytes\.Buffer\]" to link to stdlib type`
 const EchoBG = 0
 
 // godoc with potential doclink to encoding/json.Encoder and bytes.Buffer. // want `text "encoding/json\.Encoder" should be replaced with "\[encoding/json\.Encoder\]" to link to stdlib type` `text "bytes\.Buffer" should be replaced with "\[bytes\.Buffer\]" to link to stdlib type`
@@ -24,13 +24,13 @@ const FoxtrotBG = 0
 // godoc with potential doclink to io.PipeWriter.Close. // want `text "io\.PipeWriter\.Close" should be replaced with "\[io\.PipeWriter\.Close\]" to link to stdlib method`
 const GolfBG = 0
 
-// godoc with potential doclink to io.PipeWriter.Close and io.PipeWriter.Close. // want `text "io\.PipeWriter\.Close" should be replaced with "\[io\.PipeWriter\.Close\]" to link to stdlib method \(2 instances\)`
+// godoc with potential doclink to io.PipeWriter.Close and io.PipeWriter.Close. // want `text "io\.PipeWriter\.Close" should be replaced with "\[io\.PipeWriter\.Close\]" to link to stdlib method` `text "io\.PipeWriter\.Close" should be replaced with "\[io\.PipeWriter\.Close\]" to link to stdlib method`
 const HotelBG = 0
 
 // godoc with doclink to [io.PipeWriter.Close] and potential doclink to bytes.Buffer. // want `text "bytes\.Buffer" should be replaced with "\[bytes\.Buffer\]" to link to stdlib type`
 const IndiaBG = 0
 
-// godoc with doclink to [io.PipeWriter.Close] and potential doclink to bytes.Buffer and *bytes.Buffer. // want `text "bytes\.Buffer" should be replaced with "\[bytes\.Buffer\]" to link to stdlib type \(2 instances\)`
+// godoc with doclink to [io.PipeWriter.Close] and potential doclink to bytes.Buffer and *bytes.Buffer. // want `text "bytes\.Buffer" should be replaced with "\[bytes\.Buffer\]" to link to stdlib type` `text "bytes\.Buffer" should be replaced with "\[bytes\.Buffer\]" to link to stdlib type`
 const JulietBG = 0
 
 // godoc with potential doclink to io.PipeWriter.Close and bytes.Buffer. // want `text "io\.PipeWriter\.Close" should be replaced with "\[io\.PipeWriter\.Close\]" to link to stdlib method` `text "bytes\.Buffer" should be replaced with "\[bytes\.Buffer\]" to link to stdlib type`
@@ -14,16 +14,16 @@ var _, _, _ = json.Encoder{}, bytesAlias.Buffer{}, ioAlias.EOF
 // godoc with potential doclink to json.Encoder. // want `text "json\.Encoder" should be replaced with "\[json\.Encoder\]" to link to stdlib type`
 const AlphaBG = 0
 
-// godoc with potential doclink to json.Encoder and json.Encoder. // want `text "json\.Encoder" should be replaced with "\[json\.Encoder\]" to link to stdlib type \(2 instances\)`
+// godoc with potential doclink to json.Encoder and json.Encoder. // want `text "json\.Encoder" should be replaced with "\[json\.Encoder\]" to link to stdlib type` `text "json\.Encoder" should be replaced with "\[json\.Encoder\]" to link to stdlib type`
 const BravoBG = 0
 
-// godoc with potential doclink to json.Encoder and *json.Encoder. // want `text "json\.Encoder" should be replaced with "\[json\.Encoder\]" to link to stdlib type \(2 instances\)`
+// godoc with potential doclink to json.Encoder and *json.Encoder. // want `text "json\.Encoder" should be replaced with "\[json\.Encoder\]" to link to stdlib type` `text "json\.Encoder" should be replaced with "\[json\.Encoder\]" to link to stdlib type`
 const CharlieBG = 0
 
 // godoc with doclink to [json.Encoder] and potential doclink to bytesAlias.Buffer. // want `text "bytesAlias\.Buffer" should be replaced with "\[bytesAlias\.Buffer\]" to link to stdlib type`
 const DeltaBG = 0
 
-// godoc with doclink to [json.Encoder] and potential doclink to bytesAlias.Buffer and *bytesAlias.Buffer. // want `text "bytesAlias\.Buffer" should be replaced with "\[bytesAlias\.Buffer\]" to link to stdlib type \(2 instances\)`
+// godoc with doclink to [json.Encoder] and potential doclink to bytesAlias.Buffer and *bytesAlias.Buffer. // want `text "bytesAlias\.Buffer" should be replaced with "\[bytesAlias\.Buffer\]" to link to stdlib type` `text "bytesAlias\.Buffer" should be replaced with "\[bytesAlias\.Buffer\]" to link to stdlib type`
 const EchoBG = 0
 
 // godoc with potential doclink to json.Encoder and bytesAlias.Buffer. // want `text "json\.Encoder" should be replaced with "\[json\.Encoder\]" to link to stdlib type` `text "bytesAlias\.Buffer" should be replaced with "\[bytesAlias\.Buffer\]" to link to stdlib type`
@@ -32,13 +32,13 @@ const FoxtrotBG = 0
 // godoc with potential doclink to ioAlias.PipeWriter.Close. // want `text "ioAlias\.PipeWriter\.Close" should be replaced with "\[ioAlias\.PipeWriter\.Close\]" to link to stdlib method`
 const GolfBG = 0
 
-// godoc with potential doclink to ioAlias.PipeWriter.Close and ioAlias.PipeWriter.Close. // want `text "ioAlias\.PipeWriter\.Close" should be replaced with "\[ioAlias\.PipeWriter\.Close\]" to link to stdlib method \(2 instances\)`
+// godoc with potential doclink to ioAlias.PipeWriter.Close and ioAlias.PipeWriter.Close. // want `text "ioAlias\.PipeWriter\.Close" should be replaced with "\[ioAlias\.PipeWriter\.Close\]" to link to stdlib method` `text "ioAlias\.PipeWriter\.Close" should be replaced with "\[ioAlias\.PipeWriter\.Close\]" to link to stdlib method`
 const HotelBG = 0
 
 // godoc with doclink to [ioAlias.PipeWriter.Close] and potential doclink to bytesAlias.Buffer. // want `text "bytesAlias\.Buffer" should be replaced with "\[bytesAlias\.Buffer\]" to link to stdlib type`
 const IndiaBG = 0
 
-// godoc with doclink to [ioAlias.PipeWriter.Close] and potential doclink to bytesAlias.Buffer and *bytesAlias.Buffer. // want `text "bytesAlias\.Buffer" should be replaced with "\[bytesAlias\.Buffer\]" to link to stdlib type \(2 instances\)`
+// godoc with doclink to [ioAlias.PipeWriter.Close] and potential doclink to bytesAlias.Buffer and *bytesAlias.Buffer. // want `text "bytesAlias\.Buffer" should be replaced with "\[bytesAlias\.Buffer\]" to link to stdlib type` `text "bytesAlias\.Buffer" should be replaced with "\[bytesAlias\.Buffer\]" to link to stdlib type`
 const JulietBG = 0
 
 // godoc with potential doclink to ioAlias.PipeWriter.Close and bytesAlias.Buffer. // want `text "ioAlias\.PipeWriter\.Close" should be replaced with "\[ioAlias\.PipeWriter\.Close\]" to link to stdlib method` `text "bytesAlias\.Buffer" should be replaced with "\[bytesAlias\.Buffer\]" to link to stdlib type`